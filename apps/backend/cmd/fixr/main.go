@@ -52,6 +52,11 @@ func main() {
 	}
 	handlers := handler.NewHandlers(srv, services)
 
+	// Start the job server now that every task handler is registered
+	if err := srv.Job.Start(); err != nil {
+		log.Fatal().Err(err).Msg("failed to start job server")
+	}
+
 	// Initialize router
 	r := router.NewRouter(srv, handlers, services)
 
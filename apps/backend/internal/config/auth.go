@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	AuthProviderClerk = "clerk"
+	AuthProviderJWKS  = "jwks"
+)
+
+type AuthConfig struct {
+	// Provider selects how bearer tokens are verified: "clerk" (default) or "jwks"
+	Provider string `koanf:"provider" validate:"omitempty,oneof=clerk jwks"`
+	// SecretKey is the Clerk secret key, required for the clerk provider only
+	SecretKey string         `koanf:"secret_key"`
+	JWKS      JWKSAuthConfig `koanf:"jwks"`
+	// WebhookSecret is the Clerk webhook signing secret ("whsec_..."); /webhooks/clerk rejects
+	// every request while it is unset
+	WebhookSecret string `koanf:"webhook_secret"`
+}
+
+// JWKSAuthConfig configures the generic OIDC/JWKS verifier, e.g. against a local key set in tests
+type JWKSAuthConfig struct {
+	// URL of the JSON Web Key Set; http(s):// for an identity provider, file:// for a local file
+	URL string `koanf:"url"`
+	// Issuer and Audience must match the iss and aud claims of every token; both are required so
+	// tokens minted for another application by the same provider are refused
+	Issuer   string        `koanf:"issuer"`
+	Audience string        `koanf:"audience"`
+	CacheTTL time.Duration `koanf:"cache_ttl"`
+	Leeway   time.Duration `koanf:"leeway"`
+}
+
+func (c *AuthConfig) Validate() error {
+	switch c.Provider {
+	case AuthProviderClerk:
+		if c.SecretKey == "" {
+			return fmt.Errorf("auth secret_key is required for the clerk provider")
+		}
+	case AuthProviderJWKS:
+		if c.JWKS.URL == "" {
+			return fmt.Errorf("auth jwks.url is required for the jwks provider")
+		}
+		if c.JWKS.Issuer == "" || c.JWKS.Audience == "" {
+			return fmt.Errorf("auth jwks.issuer and jwks.audience are required for the jwks provider")
+		}
+		if c.JWKS.CacheTTL < 0 || c.JWKS.Leeway < 0 {
+			return fmt.Errorf("auth jwks.cache_ttl and jwks.leeway must not be negative")
+		}
+	default:
+		return fmt.Errorf("invalid auth provider: %s (must be one of: clerk, jwks)", c.Provider)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthConfigValidate(t *testing.T) {
+	jwks := JWKSAuthConfig{URL: "file://./testdata/jwks.json", Issuer: "http://localhost", Audience: "fixr"}
+
+	tests := []struct {
+		name    string
+		cfg     AuthConfig
+		wantErr bool
+	}{
+		{name: "clerk", cfg: AuthConfig{Provider: AuthProviderClerk, SecretKey: "sk_test"}},
+		{name: "clerk without secret key", cfg: AuthConfig{Provider: AuthProviderClerk}, wantErr: true},
+		{name: "jwks without secret key", cfg: AuthConfig{Provider: AuthProviderJWKS, JWKS: jwks}},
+		{name: "jwks without url", cfg: AuthConfig{Provider: AuthProviderJWKS, JWKS: JWKSAuthConfig{Issuer: "http://localhost", Audience: "fixr"}}, wantErr: true},
+		{name: "jwks without issuer", cfg: AuthConfig{Provider: AuthProviderJWKS, JWKS: JWKSAuthConfig{URL: jwks.URL, Audience: "fixr"}}, wantErr: true},
+		{name: "jwks without audience", cfg: AuthConfig{Provider: AuthProviderJWKS, JWKS: JWKSAuthConfig{URL: jwks.URL, Issuer: "http://localhost"}}, wantErr: true},
+		{name: "jwks with negative leeway", cfg: AuthConfig{Provider: AuthProviderJWKS, JWKS: JWKSAuthConfig{URL: jwks.URL, Issuer: "http://localhost", Audience: "fixr", Leeway: -1}}, wantErr: true},
+		{name: "unknown provider", cfg: AuthConfig{Provider: "saml", SecretKey: "sk_test"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxServiceDepthLimit matches service.MaxTreeDepth, which bounds the recursive hierarchy queries
+const maxServiceDepthLimit = 32
+
+type CatalogConfig struct {
+	// MaxServiceDepth is how many levels a service hierarchy may have; 1 means no sub-services
+	MaxServiceDepth int `koanf:"max_service_depth"`
+	// TrashRetention is how long trashed services, categories and attachments can be restored before they are purged
+	TrashRetention time.Duration `koanf:"trash_retention"`
+	// TrashPurgeSchedule is the cron spec the purge job runs on
+	TrashPurgeSchedule string `koanf:"trash_purge_schedule"`
+}
+
+func DefaultCatalogConfig() *CatalogConfig {
+	return &CatalogConfig{
+		MaxServiceDepth:    5,
+		TrashRetention:     30 * 24 * time.Hour,
+		TrashPurgeSchedule: "@hourly",
+	}
+}
+
+func (c *CatalogConfig) Validate() error {
+	if c.MaxServiceDepth < 1 || c.MaxServiceDepth > maxServiceDepthLimit {
+		return fmt.Errorf("catalog max_service_depth must be between 1 and %d", maxServiceDepthLimit)
+	}
+
+	if c.TrashRetention <= 0 {
+		return fmt.Errorf("catalog trash_retention must be positive")
+	}
+
+	if c.TrashPurgeSchedule == "" {
+		return fmt.Errorf("catalog trash_purge_schedule is required")
+	}
+
+	return nil
+}
@@ -19,6 +19,8 @@ type Config struct {
 	Redis         RedisConfig          `koanf:"redis" validate:"required"`
 	Integration   IntegrationConfig    `koanf:"integration" validate:"required"`
 	Observability *ObservabilityConfig `koanf:"observability"`
+	Storage       *StorageConfig       `koanf:"storage"`
+	Catalog       *CatalogConfig       `koanf:"catalog"`
 }
 
 type Primary struct {
@@ -53,10 +55,6 @@ type IntegrationConfig struct {
 	ResendAPIKey string `koanf:"resend_api_key" validate:"required"`
 }
 
-type AuthConfig struct {
-	SecretKey string `koanf:"secret_key" validate:"required"`
-}
-
 func LoadConfig() (*Config, error) {
 	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
 
@@ -97,5 +95,39 @@ func LoadConfig() (*Config, error) {
 		logger.Fatal().Err(err).Msg("invalid observability config")
 	}
 
+	// Default to Clerk when no authentication provider is configured
+	if mainConfig.Auth.Provider == "" {
+		mainConfig.Auth.Provider = AuthProviderClerk
+	}
+
+	// Validate auth config
+	if err := mainConfig.Auth.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("invalid auth config")
+	}
+
+	// Set default storage config if not provided. The local driver still needs its own
+	// storage.local.signing_key, so download links never share a key with the auth provider.
+	if mainConfig.Storage == nil {
+		mainConfig.Storage = DefaultStorageConfig()
+	}
+
+	// Validate storage config
+	if err := mainConfig.Storage.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("invalid storage config")
+	}
+	if mainConfig.Storage.Driver == "local" && mainConfig.Storage.Local.SigningKey == mainConfig.Auth.SecretKey {
+		logger.Fatal().Msg("invalid storage config: local.signing_key must not reuse auth.secret_key")
+	}
+
+	// Set default catalog config if not provided
+	if mainConfig.Catalog == nil {
+		mainConfig.Catalog = DefaultCatalogConfig()
+	}
+
+	// Validate catalog config
+	if err := mainConfig.Catalog.Validate(); err != nil {
+		logger.Fatal().Err(err).Msg("invalid catalog config")
+	}
+
 	return mainConfig, nil
 }
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+type StorageConfig struct {
+	Driver        string             `koanf:"driver" validate:"required,oneof=local s3"`
+	MaxUploadSize int64              `koanf:"max_upload_size" validate:"min=1"`
+	URLExpiry     time.Duration      `koanf:"url_expiry" validate:"min=1s"`
+	Local         LocalStorageConfig `koanf:"local"`
+	S3            S3StorageConfig    `koanf:"s3"`
+}
+
+type LocalStorageConfig struct {
+	Path       string `koanf:"path"`
+	PublicURL  string `koanf:"public_url"`
+	SigningKey string `koanf:"signing_key"`
+}
+
+type S3StorageConfig struct {
+	Endpoint        string `koanf:"endpoint"`
+	Region          string `koanf:"region"`
+	Bucket          string `koanf:"bucket"`
+	AccessKeyID     string `koanf:"access_key_id"`
+	SecretAccessKey string `koanf:"secret_access_key"`
+	UseSSL          bool   `koanf:"use_ssl"`
+}
+
+func DefaultStorageConfig() *StorageConfig {
+	return &StorageConfig{
+		Driver:        "local",
+		MaxUploadSize: 10 << 20, // 10 MiB
+		URLExpiry:     15 * time.Minute,
+		Local: LocalStorageConfig{
+			Path:      "storage",
+			PublicURL: "http://localhost:8080",
+		},
+	}
+}
+
+func (c *StorageConfig) Validate() error {
+	switch c.Driver {
+	case "local":
+		if c.Local.Path == "" {
+			return fmt.Errorf("storage local.path is required for the local driver")
+		}
+		if c.Local.SigningKey == "" {
+			return fmt.Errorf("storage local.signing_key is required for the local driver")
+		}
+	case "s3":
+		if c.S3.Endpoint == "" || c.S3.Bucket == "" {
+			return fmt.Errorf("storage s3.endpoint and s3.bucket are required for the s3 driver")
+		}
+	default:
+		return fmt.Errorf("invalid storage driver: %s (must be one of: local, s3)", c.Driver)
+	}
+
+	if c.MaxUploadSize <= 0 {
+		return fmt.Errorf("storage max_upload_size must be positive")
+	}
+
+	if c.URLExpiry <= 0 {
+		return fmt.Errorf("storage url_expiry must be positive")
+	}
+
+	return nil
+}
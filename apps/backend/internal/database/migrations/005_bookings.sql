@@ -0,0 +1,43 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE bookings (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    provider_id TEXT NOT NULL,
+    customer_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ NOT NULL,
+    notes TEXT,
+    status_reason TEXT
+);
+
+CREATE INDEX idx_bookings_service_id ON bookings (service_id);
+
+CREATE INDEX idx_bookings_provider_id_starts_at ON bookings (provider_id, starts_at);
+
+CREATE INDEX idx_bookings_customer_id_starts_at ON bookings (customer_id, starts_at);
+
+CREATE INDEX idx_bookings_status ON bookings (status);
+
+CREATE TRIGGER set_bookings_updated_at
+BEFORE UPDATE ON bookings
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- constrains
+ALTER TABLE bookings
+ADD CONSTRAINT bookings_valid_status CHECK (status IN ('pending', 'confirmed', 'declined', 'cancelled', 'completed'));
+
+ALTER TABLE bookings
+ADD CONSTRAINT bookings_valid_range CHECK (ends_at > starts_at);
+
+-- a provider can never hold two confirmed bookings that overlap
+ALTER TABLE bookings
+ADD CONSTRAINT bookings_no_overlap EXCLUDE USING gist (
+    provider_id WITH =,
+    tstzrange(starts_at, ends_at) WITH &&
+)
+WHERE (status = 'confirmed');
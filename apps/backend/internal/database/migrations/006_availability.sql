@@ -0,0 +1,78 @@
+ALTER TABLE services
+ADD COLUMN duration_minutes INTEGER NOT NULL DEFAULT 60;
+
+ALTER TABLE services
+ADD CONSTRAINT services_positive_duration CHECK (duration_minutes > 0);
+
+CREATE TABLE availability_settings (
+    user_id TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    timezone TEXT NOT NULL DEFAULT 'UTC'
+);
+
+CREATE TRIGGER set_availability_settings_updated_at
+BEFORE UPDATE ON availability_settings
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- weekly recurring windows, minutes are counted from local midnight in the provider's timezone
+CREATE TABLE availability_rules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id TEXT NOT NULL,
+    weekday SMALLINT NOT NULL,
+    start_minute INTEGER NOT NULL,
+    end_minute INTEGER NOT NULL
+);
+
+CREATE INDEX idx_availability_rules_user_id ON availability_rules (user_id, weekday);
+
+CREATE TRIGGER set_availability_rules_updated_at
+BEFORE UPDATE ON availability_rules
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- date specific windows that replace the weekly rules for that date;
+-- a row without start/end minutes blacks the whole day out
+CREATE TABLE availability_overrides (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id TEXT NOT NULL,
+    date DATE NOT NULL,
+    start_minute INTEGER,
+    end_minute INTEGER
+);
+
+CREATE INDEX idx_availability_overrides_user_id_date ON availability_overrides (user_id, date);
+
+CREATE TRIGGER set_availability_overrides_updated_at
+BEFORE UPDATE ON availability_overrides
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- constrains
+ALTER TABLE availability_rules
+ADD CONSTRAINT availability_rules_valid_weekday CHECK (weekday BETWEEN 0 AND 6);
+
+ALTER TABLE availability_rules
+ADD CONSTRAINT availability_rules_valid_window CHECK (
+    start_minute >= 0
+    AND end_minute <= 1440
+    AND end_minute > start_minute
+);
+
+ALTER TABLE availability_overrides
+ADD CONSTRAINT availability_overrides_valid_window CHECK (
+    (
+        start_minute IS NULL
+        AND end_minute IS NULL
+    )
+    OR (
+        start_minute >= 0
+        AND end_minute <= 1440
+        AND end_minute > start_minute
+    )
+);
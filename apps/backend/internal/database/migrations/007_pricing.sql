@@ -0,0 +1,42 @@
+-- rates are stored in the minor unit of their currency (cents for USD)
+ALTER TABLE services
+ALTER COLUMN rate DROP DEFAULT;
+
+ALTER TABLE services
+ALTER COLUMN rate TYPE BIGINT USING ROUND(rate * 100)::BIGINT;
+
+ALTER TABLE services
+ALTER COLUMN rate SET DEFAULT 0;
+
+ALTER TABLE services
+ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+
+ALTER TABLE services
+ADD COLUMN unit_label TEXT;
+
+ALTER TABLE services
+ADD COLUMN price_tiers JSONB;
+
+-- constrains
+ALTER TABLE services
+ADD CONSTRAINT services_valid_method CHECK (
+    method IN ('hourly', 'fixed', 'per_unit', 'tiered', 'volume')
+);
+
+ALTER TABLE services
+ADD CONSTRAINT services_non_negative_rate CHECK (rate >= 0);
+
+ALTER TABLE services
+ADD CONSTRAINT services_valid_currency CHECK (currency ~ '^[A-Z]{3}$');
+
+ALTER TABLE services
+ADD CONSTRAINT services_per_unit_has_label CHECK (
+    method != 'per_unit'
+    OR unit_label IS NOT NULL
+);
+
+ALTER TABLE services
+ADD CONSTRAINT services_tiered_has_tiers CHECK (
+    method NOT IN ('tiered', 'volume')
+    OR jsonb_array_length(price_tiers) > 0
+);
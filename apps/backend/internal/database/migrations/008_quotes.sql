@@ -0,0 +1,71 @@
+CREATE TABLE quotes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id TEXT NOT NULL,
+    root_service_id UUID REFERENCES services (id) ON DELETE SET NULL,
+    title TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    currency TEXT NOT NULL,
+    subtotal BIGINT NOT NULL,
+    discount_rate INTEGER NOT NULL DEFAULT 0,
+    discount_amount BIGINT NOT NULL DEFAULT 0,
+    tax_rate INTEGER NOT NULL DEFAULT 0,
+    tax_amount BIGINT NOT NULL DEFAULT 0,
+    total BIGINT NOT NULL,
+    notes TEXT,
+    share_token TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    responded_at TIMESTAMPTZ,
+    response_note TEXT
+);
+
+CREATE INDEX idx_quotes_user_id ON quotes (user_id, created_at);
+
+CREATE UNIQUE INDEX quotes_unique_share_token ON quotes (share_token);
+
+CREATE TRIGGER set_quotes_updated_at
+BEFORE UPDATE ON quotes
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- line items keep a snapshot of the pricing used, so later service edits don't change old quotes
+CREATE TABLE quote_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    quote_id UUID NOT NULL REFERENCES quotes (id) ON DELETE CASCADE,
+    service_id UUID REFERENCES services (id) ON DELETE SET NULL,
+    description TEXT NOT NULL,
+    method TEXT NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL,
+    unit_label TEXT,
+    amount BIGINT NOT NULL,
+    lines JSONB NOT NULL DEFAULT '[]'::JSONB,
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_quote_items_quote_id ON quote_items (quote_id, sort_order);
+
+CREATE TRIGGER set_quote_items_updated_at
+BEFORE UPDATE ON quote_items
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- constrains
+ALTER TABLE quotes
+ADD CONSTRAINT quotes_valid_status CHECK (status IN ('pending', 'accepted', 'rejected', 'withdrawn'));
+
+ALTER TABLE quotes
+ADD CONSTRAINT quotes_valid_rates CHECK (
+    discount_rate BETWEEN 0 AND 10000
+    AND tax_rate BETWEEN 0 AND 10000
+);
+
+ALTER TABLE quotes
+ADD CONSTRAINT quotes_non_negative_amounts CHECK (
+    subtotal >= 0
+    AND discount_amount >= 0
+    AND tax_amount >= 0
+    AND total >= 0
+);
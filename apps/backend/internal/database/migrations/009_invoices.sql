@@ -0,0 +1,83 @@
+-- per-provider counter backing gapless invoice numbers
+CREATE TABLE invoice_sequences (
+    user_id TEXT PRIMARY KEY,
+    last_number BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE invoices (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id TEXT NOT NULL,
+    customer_id TEXT,
+    number BIGINT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'draft',
+    booking_id UUID REFERENCES bookings (id) ON DELETE SET NULL,
+    quote_id UUID REFERENCES quotes (id) ON DELETE SET NULL,
+    currency TEXT NOT NULL,
+    subtotal BIGINT NOT NULL,
+    discount_amount BIGINT NOT NULL DEFAULT 0,
+    tax_rate INTEGER NOT NULL DEFAULT 0,
+    tax_amount BIGINT NOT NULL DEFAULT 0,
+    total BIGINT NOT NULL,
+    notes TEXT,
+    due_at TIMESTAMPTZ,
+    sent_at TIMESTAMPTZ,
+    paid_at TIMESTAMPTZ,
+    voided_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX invoices_unique_number ON invoices (user_id, number);
+
+-- a booking or quote is billed at most once, unless the earlier invoice was voided
+CREATE UNIQUE INDEX invoices_unique_booking ON invoices (booking_id)
+WHERE
+    status != 'void';
+
+CREATE UNIQUE INDEX invoices_unique_quote ON invoices (quote_id)
+WHERE
+    status != 'void';
+
+CREATE INDEX idx_invoices_status ON invoices (status);
+
+CREATE TRIGGER set_invoices_updated_at
+BEFORE UPDATE ON invoices
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TABLE invoice_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    invoice_id UUID NOT NULL REFERENCES invoices (id) ON DELETE CASCADE,
+    service_id UUID REFERENCES services (id) ON DELETE SET NULL,
+    description TEXT NOT NULL,
+    method TEXT NOT NULL,
+    quantity DOUBLE PRECISION NOT NULL,
+    unit_label TEXT,
+    amount BIGINT NOT NULL,
+    lines JSONB NOT NULL DEFAULT '[]'::JSONB,
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_invoice_items_invoice_id ON invoice_items (invoice_id, sort_order);
+
+CREATE TRIGGER set_invoice_items_updated_at
+BEFORE UPDATE ON invoice_items
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- constrains
+ALTER TABLE invoices
+ADD CONSTRAINT invoices_valid_status CHECK (status IN ('draft', 'sent', 'paid', 'void'));
+
+ALTER TABLE invoices
+ADD CONSTRAINT invoices_valid_tax_rate CHECK (tax_rate BETWEEN 0 AND 10000);
+
+ALTER TABLE invoices
+ADD CONSTRAINT invoices_non_negative_amounts CHECK (
+    subtotal >= 0
+    AND discount_amount >= 0
+    AND tax_amount >= 0
+    AND total >= 0
+);
@@ -0,0 +1,74 @@
+CREATE TABLE reviews (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    booking_id UUID NOT NULL REFERENCES bookings (id) ON DELETE CASCADE,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    provider_id TEXT NOT NULL,
+    customer_id TEXT NOT NULL,
+    rating SMALLINT NOT NULL,
+    comment TEXT,
+    reply TEXT,
+    replied_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX reviews_unique_booking ON reviews (booking_id);
+
+CREATE INDEX idx_reviews_service_id ON reviews (service_id, created_at);
+
+CREATE TRIGGER set_reviews_updated_at
+BEFORE UPDATE ON reviews
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- constrains
+ALTER TABLE reviews
+ADD CONSTRAINT reviews_valid_rating CHECK (rating BETWEEN 1 AND 5);
+
+-- aggregates live on the service so listings can sort and filter by rating without a join
+ALTER TABLE services
+ADD COLUMN rating_average DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+ALTER TABLE services
+ADD COLUMN rating_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_services_rating_average ON services (rating_average);
+
+CREATE OR REPLACE FUNCTION refresh_service_rating(target_service_id UUID)
+RETURNS VOID AS $$
+BEGIN
+    UPDATE services
+    SET
+        rating_count = stats.review_count,
+        rating_average = stats.review_average
+    FROM (
+        SELECT
+            COUNT(*) AS review_count,
+            COALESCE(AVG(rating), 0) AS review_average
+        FROM
+            reviews
+        WHERE
+            service_id = target_service_id
+    ) AS stats
+    WHERE
+        services.id = target_service_id;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION trigger_refresh_service_rating()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        PERFORM refresh_service_rating(OLD.service_id);
+    END IF;
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        PERFORM refresh_service_rating(NEW.service_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER refresh_service_rating_on_review
+AFTER INSERT OR DELETE OR UPDATE OF rating, service_id ON reviews
+FOR EACH ROW
+EXECUTE FUNCTION trigger_refresh_service_rating();
@@ -0,0 +1,84 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- weighted document for a service: name (A), description (B), category name (C)
+CREATE OR REPLACE FUNCTION service_search_vector(
+    service_name TEXT,
+    service_description TEXT,
+    category_name TEXT
+)
+RETURNS TSVECTOR
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT
+        setweight(to_tsvector('simple', COALESCE(service_name, '')), 'A')
+        || setweight(to_tsvector('simple', COALESCE(service_description, '')), 'B')
+        || setweight(to_tsvector('simple', COALESCE(category_name, '')), 'C')
+$$;
+
+-- generated columns cannot read the category name, so the document lives beside the service
+-- and is kept current by triggers on both tables
+CREATE TABLE service_search_documents (
+    service_id UUID PRIMARY KEY REFERENCES services (id) ON DELETE CASCADE,
+    search_vector TSVECTOR NOT NULL
+);
+
+CREATE INDEX idx_service_search_documents_vector ON service_search_documents USING GIN (search_vector);
+
+CREATE INDEX idx_services_name_trgm ON services USING GIN (name gin_trgm_ops);
+
+CREATE INDEX idx_services_categories_search ON services_categories USING GIN (
+    to_tsvector('simple', name || ' ' || COALESCE(description, ''))
+);
+
+CREATE INDEX idx_services_categories_name_trgm ON services_categories USING GIN (name gin_trgm_ops);
+
+CREATE OR REPLACE FUNCTION trigger_refresh_service_search_document()
+RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO service_search_documents (service_id, search_vector)
+    VALUES (
+        NEW.id,
+        service_search_vector(
+            NEW.name,
+            NEW.description,
+            (SELECT name FROM services_categories WHERE id = NEW.category_id)
+        )
+    )
+    ON CONFLICT (service_id) DO UPDATE
+    SET search_vector = EXCLUDED.search_vector;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER refresh_service_search_document
+AFTER INSERT OR UPDATE OF name, description, category_id ON services
+FOR EACH ROW
+EXECUTE FUNCTION trigger_refresh_service_search_document();
+
+CREATE OR REPLACE FUNCTION trigger_refresh_category_search_documents()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE service_search_documents d
+    SET search_vector = service_search_vector(s.name, s.description, NEW.name)
+    FROM services s
+    WHERE
+        s.id = d.service_id
+        AND s.category_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER refresh_category_search_documents
+AFTER UPDATE OF name ON services_categories
+FOR EACH ROW
+EXECUTE FUNCTION trigger_refresh_category_search_documents();
+
+-- backfill existing services
+INSERT INTO service_search_documents (service_id, search_vector)
+SELECT
+    s.id,
+    service_search_vector(s.name, s.description, c.name)
+FROM
+    services s
+    LEFT JOIN services_categories c ON c.id = s.category_id;
@@ -0,0 +1,52 @@
+CREATE EXTENSION IF NOT EXISTS cube;
+
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+-- where a provider works: either a circle around a point or an explicit list of postal codes
+CREATE TABLE service_areas (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    radius_km DOUBLE PRECISION,
+    postal_codes TEXT[]
+);
+
+CREATE INDEX idx_service_areas_user_id ON service_areas (user_id);
+
+CREATE INDEX idx_service_areas_location ON service_areas USING GIST (ll_to_earth(latitude, longitude))
+WHERE
+    kind = 'radius';
+
+CREATE INDEX idx_service_areas_postal_codes ON service_areas USING GIN (postal_codes)
+WHERE
+    kind = 'postal_codes';
+
+CREATE TRIGGER set_service_areas_updated_at
+BEFORE UPDATE ON service_areas
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- constrains
+ALTER TABLE service_areas
+ADD CONSTRAINT service_areas_valid_kind CHECK (
+    (
+        kind = 'radius'
+        AND latitude BETWEEN -90 AND 90
+        AND longitude BETWEEN -180 AND 180
+        AND radius_km > 0
+        AND radius_km <= 500
+        AND postal_codes IS NULL
+    )
+    OR (
+        kind = 'postal_codes'
+        AND cardinality(postal_codes) > 0
+        AND latitude IS NULL
+        AND longitude IS NULL
+        AND radius_km IS NULL
+    )
+);
@@ -0,0 +1,23 @@
+-- public identity a provider exposes to customers; the handle is the storefront URL segment
+CREATE TABLE storefronts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    user_id TEXT NOT NULL,
+    handle TEXT NOT NULL,
+    display_name TEXT NOT NULL,
+    bio TEXT
+);
+
+CREATE UNIQUE INDEX storefronts_unique_user_id ON storefronts (user_id);
+
+CREATE UNIQUE INDEX storefronts_unique_handle ON storefronts (handle);
+
+CREATE TRIGGER set_storefronts_updated_at
+BEFORE UPDATE ON storefronts
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- constrains
+ALTER TABLE storefronts
+ADD CONSTRAINT storefronts_valid_handle CHECK (handle ~ '^[a-z0-9][a-z0-9-]{2,39}$');
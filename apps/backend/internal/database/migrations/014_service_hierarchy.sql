@@ -0,0 +1,34 @@
+-- no_self_parent only catches direct self references; walk the new parent's ancestors to reject longer loops
+CREATE OR REPLACE FUNCTION prevent_service_cycle()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.parent_service_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    IF EXISTS (
+        WITH RECURSIVE ancestors AS (
+            SELECT id, parent_service_id, 1 AS depth
+            FROM services
+            WHERE id = NEW.parent_service_id
+            UNION ALL
+            SELECT p.id, p.parent_service_id, a.depth + 1
+            FROM services p
+            JOIN ancestors a ON p.id = a.parent_service_id
+            WHERE a.depth < 64
+        )
+        SELECT 1 FROM ancestors WHERE id = NEW.id
+    ) THEN
+        RAISE EXCEPTION 'service % cannot be moved under its own descendant', NEW.id
+            USING ERRCODE = 'check_violation', TABLE = 'services', CONSTRAINT = 'no_service_cycle';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER services_prevent_cycle
+BEFORE UPDATE OF parent_service_id ON services
+FOR EACH ROW
+WHEN (NEW.parent_service_id IS DISTINCT FROM OLD.parent_service_id)
+EXECUTE FUNCTION prevent_service_cycle();
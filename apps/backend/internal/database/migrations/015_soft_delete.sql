@@ -0,0 +1,41 @@
+-- trashed rows keep deleted_at until the purge job removes them for good
+ALTER TABLE services
+ADD COLUMN deleted_at TIMESTAMPTZ;
+
+ALTER TABLE services_categories
+ADD COLUMN deleted_at TIMESTAMPTZ;
+
+ALTER TABLE services_attachment
+ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_services_deleted_at ON services (deleted_at)
+WHERE
+    deleted_at IS NOT NULL;
+
+CREATE INDEX idx_services_categories_deleted_at ON services_categories (deleted_at)
+WHERE
+    deleted_at IS NOT NULL;
+
+CREATE INDEX idx_services_attachment_deleted_at ON services_attachment (deleted_at)
+WHERE
+    deleted_at IS NOT NULL;
+
+-- names only need to be unique among live rows, so a trashed service does not block reusing its name
+DROP INDEX services_unique_name;
+
+CREATE UNIQUE INDEX services_unique_name ON services (user_id, name)
+WHERE
+    deleted_at IS NULL;
+
+DROP INDEX services_categories_unique_name;
+
+CREATE UNIQUE INDEX services_categories_unique_name ON services_categories (user_id, name)
+WHERE
+    deleted_at IS NULL;
+
+-- purging a trashed parent takes its (equally trashed) subtree with it
+ALTER TABLE services
+DROP CONSTRAINT services_parent_service_id_fkey;
+
+ALTER TABLE services
+ADD CONSTRAINT services_parent_service_id_fkey FOREIGN KEY (parent_service_id) REFERENCES services (id) ON DELETE CASCADE;
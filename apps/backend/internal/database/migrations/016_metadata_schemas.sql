@@ -0,0 +1,18 @@
+-- services always carry a metadata object so it can be filtered on without NULL checks
+UPDATE services
+SET
+    metadata = '{}'::JSONB
+WHERE
+    metadata IS NULL
+    OR jsonb_typeof(metadata) <> 'object';
+
+ALTER TABLE services
+ALTER COLUMN metadata SET DEFAULT '{}'::JSONB,
+ALTER COLUMN metadata SET NOT NULL,
+ADD CONSTRAINT services_metadata_object CHECK (jsonb_typeof(metadata) = 'object');
+
+CREATE INDEX idx_services_metadata ON services USING GIN (metadata);
+
+-- a JSON Schema describing the metadata of services in the category, checked when services are written
+ALTER TABLE services_categories
+ADD COLUMN metadata_schema JSONB;
@@ -0,0 +1,52 @@
+-- append-only history of catalog changes, written in the same transaction as the change itself
+CREATE TABLE audit_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- owner of the changed entity; actor_id is who made the change ("system" for background jobs)
+    user_id TEXT NOT NULL,
+    actor_id TEXT NOT NULL,
+    request_id TEXT,
+    entity_type TEXT NOT NULL CHECK (entity_type IN ('service', 'category', 'attachment')),
+    entity_id UUID NOT NULL,
+    action TEXT NOT NULL,
+    -- {"field": {"before": ..., "after": ...}} for every field that changed
+    changes JSONB NOT NULL DEFAULT '{}'::JSONB
+);
+
+CREATE INDEX idx_audit_events_user_id_created_at ON audit_events (user_id, created_at DESC);
+
+CREATE INDEX idx_audit_events_entity ON audit_events (entity_type, entity_id, created_at DESC);
+
+CREATE INDEX idx_audit_events_request_id ON audit_events (request_id)
+WHERE
+    request_id IS NOT NULL;
+
+-- audit_diff keeps the top-level keys whose values differ between two camelCased rows;
+-- updatedAt changes on every write and carries no information of its own
+CREATE OR REPLACE FUNCTION audit_diff(before JSONB, after JSONB)
+RETURNS JSONB AS $$
+    SELECT
+        COALESCE(
+            jsonb_object_agg(key, jsonb_build_object('before', b.value, 'after', a.value)),
+            '{}'::JSONB
+        )
+    FROM
+        jsonb_each(COALESCE(before, '{}'::JSONB)) b
+        FULL JOIN jsonb_each(COALESCE(after, '{}'::JSONB)) a USING (key)
+    WHERE
+        b.value IS DISTINCT FROM a.value
+        AND key <> 'updatedAt';
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION prevent_audit_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only'
+        USING ERRCODE = 'insufficient_privilege', TABLE = 'audit_events';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+BEFORE UPDATE OR DELETE ON audit_events
+FOR EACH ROW
+EXECUTE FUNCTION prevent_audit_event_change();
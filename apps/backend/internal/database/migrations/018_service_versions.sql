@@ -0,0 +1,42 @@
+-- immutable snapshots of a service, one per change to its own fields
+CREATE TABLE service_versions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    version INTEGER NOT NULL CHECK (version > 0),
+    actor_id TEXT NOT NULL,
+    request_id TEXT,
+    -- set when this version was created by restoring an earlier one
+    restored_from INTEGER,
+    -- the camelCased service row as it was after the change
+    snapshot JSONB NOT NULL
+);
+
+CREATE UNIQUE INDEX service_versions_unique_version ON service_versions (service_id, version);
+
+-- every existing service starts its history at version 1
+INSERT INTO
+    service_versions (service_id, user_id, version, actor_id, snapshot)
+SELECT
+    s.id,
+    s.user_id,
+    1,
+    'system',
+    camel(s)
+FROM
+    services s;
+
+-- versions are only ever added; they go away with their service when it is purged
+CREATE OR REPLACE FUNCTION prevent_service_version_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'service_versions is immutable'
+        USING ERRCODE = 'insufficient_privilege', TABLE = 'service_versions';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER service_versions_immutable
+BEFORE UPDATE ON service_versions
+FOR EACH ROW
+EXECUTE FUNCTION prevent_service_version_change();
@@ -0,0 +1,26 @@
+-- long-lived credentials for machine-to-machine access; only a hash of the key is stored
+CREATE TABLE api_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    -- owner of the data the key can reach: a user or an organization
+    user_id TEXT NOT NULL,
+    created_by TEXT NOT NULL,
+    name TEXT NOT NULL,
+    -- first characters of the key, shown so keys can be told apart
+    prefix TEXT NOT NULL,
+    key_hash TEXT NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    last_used_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX api_keys_unique_key_hash ON api_keys (key_hash);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys (user_id, created_at DESC);
+
+CREATE TRIGGER set_api_keys_updated_at
+BEFORE UPDATE ON api_keys
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,19 @@
+-- local copy of Clerk user profiles, kept in sync by the Clerk webhook
+CREATE TABLE users (
+    -- the Clerk user id, as found in user_id columns elsewhere
+    id TEXT PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    email TEXT,
+    username TEXT,
+    first_name TEXT,
+    last_name TEXT,
+    image_url TEXT,
+    -- Clerk's own updated_at; webhooks may arrive out of order, so older versions are ignored
+    clerk_updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TRIGGER set_users_updated_at
+BEFORE UPDATE ON users
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,19 @@
+-- bookings and their reviews are history shared with customers, so deleting a service must not
+-- take them along: a service that has bookings can be trashed, but the row itself is kept
+ALTER TABLE bookings
+DROP CONSTRAINT bookings_service_id_fkey;
+
+ALTER TABLE bookings
+ADD CONSTRAINT bookings_service_id_fkey FOREIGN KEY (service_id) REFERENCES services (id) ON DELETE RESTRICT;
+
+ALTER TABLE reviews
+DROP CONSTRAINT reviews_service_id_fkey;
+
+ALTER TABLE reviews
+ADD CONSTRAINT reviews_service_id_fkey FOREIGN KEY (service_id) REFERENCES services (id) ON DELETE RESTRICT;
+
+ALTER TABLE reviews
+DROP CONSTRAINT reviews_booking_id_fkey;
+
+ALTER TABLE reviews
+ADD CONSTRAINT reviews_booking_id_fkey FOREIGN KEY (booking_id) REFERENCES bookings (id) ON DELETE RESTRICT;
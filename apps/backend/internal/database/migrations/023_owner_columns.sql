@@ -0,0 +1,41 @@
+-- Since organizations were introduced, the user_id of provider-owned rows holds the owning account:
+-- the Clerk organization id (org_...) for data created inside an organization, otherwise the Clerk
+-- user id (user_...). The column keeps its name so existing rows need no backfill; customer-side
+-- columns always hold the signed-in user.
+COMMENT ON COLUMN services_categories.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN services.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN availability_settings.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN availability_rules.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN availability_overrides.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN quotes.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN invoice_sequences.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN invoices.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN service_areas.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN storefronts.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN audit_events.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN service_versions.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN api_keys.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN webhook_endpoints.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN webhook_deliveries.user_id IS 'Owning account: Clerk organization id or user id';
+
+COMMENT ON COLUMN bookings.provider_id IS 'Account owning the booked service: Clerk organization id or user id';
+
+COMMENT ON COLUMN bookings.customer_id IS 'Clerk user id of the customer';
+
+COMMENT ON COLUMN reviews.provider_id IS 'Account owning the reviewed service: Clerk organization id or user id';
+
+COMMENT ON COLUMN reviews.customer_id IS 'Clerk user id of the customer';
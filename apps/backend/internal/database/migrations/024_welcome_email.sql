@@ -0,0 +1,10 @@
+-- set once the welcome email is queued, so a webhook retried after a failed enqueue still sends it
+ALTER TABLE users
+ADD COLUMN welcome_enqueued_at TIMESTAMPTZ;
+
+-- profiles synced before the column existed already had their email queued on insert
+UPDATE users
+SET
+    welcome_enqueued_at=created_at
+WHERE
+    email IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Clerk users whose profile was deleted; a redelivered or late user.created/user.updated webhook
+-- must not bring them back, since Clerk never reuses a user id
+CREATE TABLE deleted_users (
+    id TEXT PRIMARY KEY,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/apikey"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type APIKeyHandler struct {
+	Handler
+	apiKeyService *service.APIKeyService
+}
+
+func NewAPIKeyHandler(s *server.Server, apiKeyService *service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		Handler:       NewHandler(s),
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (h *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *apikey.CreateAPIKeyPayload) (*apikey.CreatedAPIKey, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.apiKeyService.CreateAPIKey(c, userID, payload)
+		},
+		http.StatusCreated,
+		&apikey.CreateAPIKeyPayload{},
+	)(c)
+}
+
+func (h *APIKeyHandler) GetAPIKeys(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *apikey.GetAPIKeysPayload) ([]apikey.APIKey, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.apiKeyService.GetAPIKeys(c, userID)
+		},
+		http.StatusOK,
+		&apikey.GetAPIKeysPayload{},
+	)(c)
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *apikey.RevokeAPIKeyPayload) (*apikey.APIKey, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.apiKeyService.RevokeAPIKey(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&apikey.RevokeAPIKeyPayload{},
+	)(c)
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	serviceModel "github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+type AttachmentHandler struct {
+	Handler
+	attachmentService *service.AttachmentService
+}
+
+func NewAttachmentHandler(s *server.Server, attachmentService *service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		Handler:           NewHandler(s),
+		attachmentService: attachmentService,
+	}
+}
+
+func (h *AttachmentHandler) UploadAttachment(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.UploadServiceAttachmentPayload) (*serviceModel.ServiceAttachment, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.attachmentService.UploadAttachment(c, userID, middleware.GetUserID(c), payload)
+		},
+		http.StatusCreated,
+		&serviceModel.UploadServiceAttachmentPayload{},
+	)(c)
+}
+
+func (h *AttachmentHandler) GetServiceAttachments(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.GetServiceAttachmentsPayload) ([]serviceModel.ServiceAttachment, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.attachmentService.GetServiceAttachments(c, userID, payload.ServiceID)
+		},
+		http.StatusOK,
+		&serviceModel.GetServiceAttachmentsPayload{},
+	)(c)
+}
+
+func (h *AttachmentHandler) GetAttachmentDownloadURL(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.GetAttachmentDownloadURLPayload) (*serviceModel.AttachmentDownloadURL, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.attachmentService.GetAttachmentDownloadURL(c, userID, payload.ServiceID, payload.AttachmentID)
+		},
+		http.StatusOK,
+		&serviceModel.GetAttachmentDownloadURLPayload{},
+	)(c)
+}
+
+func (h *AttachmentHandler) DeleteAttachment(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.DeleteServiceAttachmentPayload) error {
+			userID := middleware.GetOwnerID(c)
+			return h.attachmentService.DeleteAttachment(c, userID, payload.ServiceID, payload.AttachmentID)
+		},
+		http.StatusNoContent,
+		&serviceModel.DeleteServiceAttachmentPayload{},
+	)(c)
+}
+
+func (h *AttachmentHandler) RestoreAttachment(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.RestoreServiceAttachmentPayload) (*serviceModel.ServiceAttachment, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.attachmentService.RestoreAttachment(c, userID, payload.ServiceID, payload.AttachmentID)
+		},
+		http.StatusOK,
+		&serviceModel.RestoreServiceAttachmentPayload{},
+	)(c)
+}
+
+// DownloadAttachment streams a blob addressed by a signed URL issued by the local storage driver
+func (h *AttachmentHandler) DownloadAttachment(c echo.Context) error {
+	query := &serviceModel.DownloadAttachmentQuery{}
+	if err := validation.BindAndValidate(c, query); err != nil {
+		return err
+	}
+
+	obj, err := h.attachmentService.OpenSignedDownload(c, query)
+	if err != nil {
+		return err
+	}
+	defer obj.Body.Close()
+
+	c.Response().Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", query.Filename))
+	c.Response().Header().Set("Cache-Control", "private, no-store")
+
+	return c.Stream(http.StatusOK, obj.ContentType, obj.Body)
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type AuditHandler struct {
+	Handler
+	auditService *service.AuditService
+}
+
+func NewAuditHandler(s *server.Server, auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		Handler:      NewHandler(s),
+		auditService: auditService,
+	}
+}
+
+func (h *AuditHandler) GetAuditEvents(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *audit.GetAuditEventsQuery) (*model.PaginatedResponse[audit.Event], error) {
+			userID := middleware.GetOwnerID(c)
+			return h.auditService.GetAuditEvents(c, userID, query)
+		},
+		http.StatusOK,
+		&audit.GetAuditEventsQuery{},
+	)(c)
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/availability"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type AvailabilityHandler struct {
+	Handler
+	availabilityService *service.AvailabilityService
+}
+
+func NewAvailabilityHandler(s *server.Server, availabilityService *service.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		Handler:             NewHandler(s),
+		availabilityService: availabilityService,
+	}
+}
+
+func (h *AvailabilityHandler) GetSchedule(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *availability.GetSchedulePayload) (*availability.Schedule, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.availabilityService.GetSchedule(c, userID)
+		},
+		http.StatusOK,
+		&availability.GetSchedulePayload{},
+	)(c)
+}
+
+func (h *AvailabilityHandler) UpdateScheduleRules(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *availability.UpdateScheduleRulesPayload) (*availability.Schedule, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.availabilityService.UpdateScheduleRules(c, userID, payload)
+		},
+		http.StatusOK,
+		&availability.UpdateScheduleRulesPayload{},
+	)(c)
+}
+
+func (h *AvailabilityHandler) SetOverride(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *availability.SetOverridePayload) ([]availability.Override, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.availabilityService.SetOverride(c, userID, payload)
+		},
+		http.StatusOK,
+		&availability.SetOverridePayload{},
+	)(c)
+}
+
+func (h *AvailabilityHandler) DeleteOverride(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, payload *availability.DeleteOverridePayload) error {
+			userID := middleware.GetOwnerID(c)
+			return h.availabilityService.DeleteOverride(c, userID, payload)
+		},
+		http.StatusNoContent,
+		&availability.DeleteOverridePayload{},
+	)(c)
+}
+
+func (h *AvailabilityHandler) GetServiceSlots(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *availability.GetServiceSlotsQuery) (*availability.ServiceSlots, error) {
+			return h.availabilityService.GetServiceSlots(c, query)
+		},
+		http.StatusOK,
+		&availability.GetServiceSlotsQuery{},
+	)(c)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"mime"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -68,16 +69,17 @@ func (h NoContentResponseHandler) AddAttributes(txn *newrelic.Transaction, resul
 	// http.status_code is already set by tracing middleware
 }
 
-// FileResponseHandler handles file responses
+// FileResponseHandler handles file responses. The filename is resolved when the response is
+// written, after the request has been bound and validated.
 type FileResponseHandler struct {
 	status      int
-	filename    string
+	filename    func() string
 	contentType string
 }
 
 func (h FileResponseHandler) Handle(c echo.Context, result interface{}) error {
 	data := result.([]byte)
-	c.Response().Header().Set("Content-Disposition", "attachment; filename="+h.filename)
+	c.Response().Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": h.filename()}))
 	return c.Blob(h.status, h.contentType, data)
 }
 
@@ -88,9 +90,10 @@ func (h FileResponseHandler) GetOperation() string {
 func (h FileResponseHandler) AddAttributes(txn *newrelic.Transaction, result interface{}) {
 	if txn != nil {
 		// http.status_code is already set by tracing middleware
-		txn.AddAttribute("file.name", h.filename)
 		txn.AddAttribute("file.content_type", h.contentType)
 		if data, ok := result.([]byte); ok {
+			// the request is only bound once there is a result, so the name is known from here on
+			txn.AddAttribute("file.name", h.filename())
 			txn.AddAttribute("file.size_bytes", len(data))
 		}
 	}
@@ -125,9 +128,7 @@ func handleRequest[Req validation.Validatable](
 
 	// Add file-specific fields to logger if it's a file handler
 	if fileHandler, ok := responseHandler.(FileResponseHandler); ok {
-		loggerBuilder = loggerBuilder.
-			Str("filename", fileHandler.filename).
-			Str("content_type", fileHandler.contentType)
+		loggerBuilder = loggerBuilder.Str("content_type", fileHandler.contentType)
 	}
 
 	logger := loggerBuilder.Logger()
@@ -136,6 +137,11 @@ func handleRequest[Req validation.Validatable](
 
 	logger.Info().Msg("handling request")
 
+	// API keys only reach routes that declare a permission
+	if err := middleware.RequireScope(c); err != nil {
+		return err
+	}
+
 	// Validation with observability
 	validationStart := time.Now()
 	if err := validation.BindAndValidate(c, req); err != nil {
@@ -225,7 +231,7 @@ func HandleFile[Req validation.Validatable](
 	handler HandlerFunc[Req, []byte],
 	status int,
 	req Req,
-	filename string,
+	filename func(req Req) string,
 	contentType string,
 ) echo.HandlerFunc {
 	return func(c echo.Context) error {
@@ -233,7 +239,7 @@ func HandleFile[Req validation.Validatable](
 			return handler(c, req)
 		}, FileResponseHandler{
 			status:      status,
-			filename:    filename,
+			filename:    func() string { return filename(req) },
 			contentType: contentType,
 		})
 	}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/booking"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type BookingHandler struct {
+	Handler
+	bookingService *service.BookingService
+}
+
+func NewBookingHandler(s *server.Server, bookingService *service.BookingService) *BookingHandler {
+	return &BookingHandler{
+		Handler:        NewHandler(s),
+		bookingService: bookingService,
+	}
+}
+
+func (h *BookingHandler) CreateBooking(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *booking.CreateBookingPayload) (*booking.Booking, error) {
+			userID := middleware.GetUserID(c)
+			return h.bookingService.CreateBooking(c, userID, payload)
+		},
+		http.StatusCreated,
+		&booking.CreateBookingPayload{},
+	)(c)
+}
+
+func (h *BookingHandler) GetBookings(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *booking.GetBookingsQuery) (*model.PaginatedResponse[booking.Booking], error) {
+			userID := middleware.GetUserID(c)
+			ownerID := middleware.GetOwnerID(c)
+			return h.bookingService.GetBookings(c, userID, ownerID, query)
+		},
+		http.StatusOK,
+		&booking.GetBookingsQuery{},
+	)(c)
+}
+
+func (h *BookingHandler) GetBookingByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *booking.GetBookingByIDPayload) (*booking.Booking, error) {
+			userID := middleware.GetUserID(c)
+			ownerID := middleware.GetOwnerID(c)
+			return h.bookingService.GetBookingByID(c, userID, ownerID, payload.ID)
+		},
+		http.StatusOK,
+		&booking.GetBookingByIDPayload{},
+	)(c)
+}
+
+func (h *BookingHandler) ConfirmBooking(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *booking.ConfirmBookingPayload) (*booking.Booking, error) {
+			userID := middleware.GetUserID(c)
+			ownerID := middleware.GetOwnerID(c)
+			return h.bookingService.ConfirmBooking(c, userID, ownerID, payload)
+		},
+		http.StatusOK,
+		&booking.ConfirmBookingPayload{},
+	)(c)
+}
+
+func (h *BookingHandler) DeclineBooking(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *booking.DeclineBookingPayload) (*booking.Booking, error) {
+			userID := middleware.GetUserID(c)
+			ownerID := middleware.GetOwnerID(c)
+			return h.bookingService.DeclineBooking(c, userID, ownerID, payload)
+		},
+		http.StatusOK,
+		&booking.DeclineBookingPayload{},
+	)(c)
+}
+
+func (h *BookingHandler) CancelBooking(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *booking.CancelBookingPayload) (*booking.Booking, error) {
+			userID := middleware.GetUserID(c)
+			ownerID := middleware.GetOwnerID(c)
+			return h.bookingService.CancelBooking(c, userID, ownerID, payload)
+		},
+		http.StatusOK,
+		&booking.CancelBookingPayload{},
+	)(c)
+}
+
+func (h *BookingHandler) RescheduleBooking(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *booking.RescheduleBookingPayload) (*booking.Booking, error) {
+			userID := middleware.GetUserID(c)
+			ownerID := middleware.GetOwnerID(c)
+			return h.bookingService.RescheduleBooking(c, userID, ownerID, payload)
+		},
+		http.StatusOK,
+		&booking.RescheduleBookingPayload{},
+	)(c)
+}
+
+func (h *BookingHandler) CompleteBooking(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *booking.CompleteBookingPayload) (*booking.Booking, error) {
+			userID := middleware.GetUserID(c)
+			ownerID := middleware.GetOwnerID(c)
+			return h.bookingService.CompleteBooking(c, userID, ownerID, payload)
+		},
+		http.StatusOK,
+		&booking.CompleteBookingPayload{},
+	)(c)
+}
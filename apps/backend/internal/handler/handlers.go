@@ -6,13 +6,45 @@ import (
 )
 
 type Handlers struct {
-	Health  *HealthHandler
-	OpenAPI *OpenAPIHandler
+	Health       *HealthHandler
+	OpenAPI      *OpenAPIHandler
+	Service      *ServiceHandler
+	Category     *CategoryHandler
+	Attachment   *AttachmentHandler
+	Booking      *BookingHandler
+	Availability *AvailabilityHandler
+	Quote        *QuoteHandler
+	Invoice      *InvoiceHandler
+	Review       *ReviewHandler
+	Search       *SearchHandler
+	ServiceArea  *ServiceAreaHandler
+	Storefront   *StorefrontHandler
+	Trash        *TrashHandler
+	Audit        *AuditHandler
+	APIKey       *APIKeyHandler
+	Webhook      *WebhookHandler
+	User         *UserHandler
 }
 
 func NewHandlers(s *server.Server, services *service.Services) *Handlers {
 	return &Handlers{
-		Health:  NewHealthHandler(s),
-		OpenAPI: NewOpenAPIHandler(s),
+		Health:       NewHealthHandler(s),
+		OpenAPI:      NewOpenAPIHandler(s),
+		Service:      NewServiceHandler(s, services.Service),
+		Category:     NewCategoryHandler(s, services.Category),
+		Attachment:   NewAttachmentHandler(s, services.Attachment),
+		Booking:      NewBookingHandler(s, services.Booking),
+		Availability: NewAvailabilityHandler(s, services.Availability),
+		Quote:        NewQuoteHandler(s, services.Quote),
+		Invoice:      NewInvoiceHandler(s, services.Invoice),
+		Review:       NewReviewHandler(s, services.Review),
+		Search:       NewSearchHandler(s, services.Search),
+		ServiceArea:  NewServiceAreaHandler(s, services.ServiceArea),
+		Storefront:   NewStorefrontHandler(s, services.Storefront),
+		Trash:        NewTrashHandler(s, services.Trash),
+		Audit:        NewAuditHandler(s, services.Audit),
+		APIKey:       NewAPIKeyHandler(s, services.APIKey),
+		Webhook:      NewWebhookHandler(s, services.Webhook),
+		User:         NewUserHandler(s, services.User),
 	}
 }
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/invoice"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type InvoiceHandler struct {
+	Handler
+	invoiceService *service.InvoiceService
+}
+
+func NewInvoiceHandler(s *server.Server, invoiceService *service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{
+		Handler:        NewHandler(s),
+		invoiceService: invoiceService,
+	}
+}
+
+func (h *InvoiceHandler) CreateInvoice(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *invoice.CreateInvoicePayload) (*invoice.PopulatedInvoice, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.invoiceService.CreateInvoice(c, userID, payload)
+		},
+		http.StatusCreated,
+		&invoice.CreateInvoicePayload{},
+	)(c)
+}
+
+func (h *InvoiceHandler) GetInvoices(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *invoice.GetInvoicesQuery) (*model.PaginatedResponse[invoice.Invoice], error) {
+			userID := middleware.GetOwnerID(c)
+			return h.invoiceService.GetInvoices(c, userID, query)
+		},
+		http.StatusOK,
+		&invoice.GetInvoicesQuery{},
+	)(c)
+}
+
+func (h *InvoiceHandler) GetInvoiceByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *invoice.GetInvoiceByIDPayload) (*invoice.PopulatedInvoice, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.invoiceService.GetInvoiceByID(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&invoice.GetInvoiceByIDPayload{},
+	)(c)
+}
+
+func (h *InvoiceHandler) GetInvoicePDF(c echo.Context) error {
+	return HandleFile(
+		h.Handler,
+		func(c echo.Context, payload *invoice.GetInvoiceByIDPayload) ([]byte, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.invoiceService.GetInvoicePDF(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&invoice.GetInvoiceByIDPayload{},
+		func(payload *invoice.GetInvoiceByIDPayload) string {
+			return "invoice-" + payload.ID.String() + ".pdf"
+		},
+		"application/pdf",
+	)(c)
+}
+
+func (h *InvoiceHandler) SendInvoice(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *invoice.UpdateInvoiceStatusPayload) (*invoice.Invoice, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.invoiceService.SendInvoice(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&invoice.UpdateInvoiceStatusPayload{},
+	)(c)
+}
+
+func (h *InvoiceHandler) MarkInvoicePaid(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *invoice.UpdateInvoiceStatusPayload) (*invoice.Invoice, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.invoiceService.MarkInvoicePaid(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&invoice.UpdateInvoiceStatusPayload{},
+	)(c)
+}
+
+func (h *InvoiceHandler) VoidInvoice(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *invoice.UpdateInvoiceStatusPayload) (*invoice.Invoice, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.invoiceService.VoidInvoice(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&invoice.UpdateInvoiceStatusPayload{},
+	)(c)
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/quote"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type QuoteHandler struct {
+	Handler
+	quoteService *service.QuoteService
+}
+
+func NewQuoteHandler(s *server.Server, quoteService *service.QuoteService) *QuoteHandler {
+	return &QuoteHandler{
+		Handler:      NewHandler(s),
+		quoteService: quoteService,
+	}
+}
+
+func (h *QuoteHandler) CreateQuote(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *quote.CreateQuotePayload) (*quote.PopulatedQuote, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.quoteService.CreateQuote(c, userID, payload)
+		},
+		http.StatusCreated,
+		&quote.CreateQuotePayload{},
+	)(c)
+}
+
+func (h *QuoteHandler) GetQuotes(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *quote.GetQuotesQuery) (*model.PaginatedResponse[quote.Quote], error) {
+			userID := middleware.GetOwnerID(c)
+			return h.quoteService.GetQuotes(c, userID, query)
+		},
+		http.StatusOK,
+		&quote.GetQuotesQuery{},
+	)(c)
+}
+
+func (h *QuoteHandler) GetQuoteByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *quote.GetQuoteByIDPayload) (*quote.PopulatedQuote, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.quoteService.GetQuoteByID(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&quote.GetQuoteByIDPayload{},
+	)(c)
+}
+
+func (h *QuoteHandler) WithdrawQuote(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *quote.WithdrawQuotePayload) (*quote.Quote, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.quoteService.WithdrawQuote(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&quote.WithdrawQuotePayload{},
+	)(c)
+}
+
+func (h *QuoteHandler) GetSharedQuote(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *quote.GetSharedQuotePayload) (*quote.PopulatedQuote, error) {
+			return h.quoteService.GetSharedQuote(c, payload.Token)
+		},
+		http.StatusOK,
+		&quote.GetSharedQuotePayload{},
+	)(c)
+}
+
+func (h *QuoteHandler) AcceptSharedQuote(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *quote.RespondToQuotePayload) (*quote.Quote, error) {
+			return h.quoteService.AcceptSharedQuote(c, payload)
+		},
+		http.StatusOK,
+		&quote.RespondToQuotePayload{},
+	)(c)
+}
+
+func (h *QuoteHandler) RejectSharedQuote(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *quote.RespondToQuotePayload) (*quote.Quote, error) {
+			return h.quoteService.RejectSharedQuote(c, payload)
+		},
+		http.StatusOK,
+		&quote.RespondToQuotePayload{},
+	)(c)
+}
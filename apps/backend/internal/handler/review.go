@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/review"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type ReviewHandler struct {
+	Handler
+	reviewService *service.ReviewService
+}
+
+func NewReviewHandler(s *server.Server, reviewService *service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		Handler:       NewHandler(s),
+		reviewService: reviewService,
+	}
+}
+
+func (h *ReviewHandler) CreateReview(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *review.CreateReviewPayload) (*review.Review, error) {
+			userID := middleware.GetUserID(c)
+			return h.reviewService.CreateReview(c, userID, payload)
+		},
+		http.StatusCreated,
+		&review.CreateReviewPayload{},
+	)(c)
+}
+
+func (h *ReviewHandler) GetServiceReviews(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *review.GetServiceReviewsQuery) (*model.PaginatedResponse[review.Review], error) {
+			return h.reviewService.GetServiceReviews(c, query)
+		},
+		http.StatusOK,
+		&review.GetServiceReviewsQuery{},
+	)(c)
+}
+
+func (h *ReviewHandler) UpdateReview(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *review.UpdateReviewPayload) (*review.Review, error) {
+			userID := middleware.GetUserID(c)
+			return h.reviewService.UpdateReview(c, userID, payload)
+		},
+		http.StatusOK,
+		&review.UpdateReviewPayload{},
+	)(c)
+}
+
+func (h *ReviewHandler) DeleteReview(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, payload *review.DeleteReviewPayload) error {
+			userID := middleware.GetUserID(c)
+			return h.reviewService.DeleteReview(c, userID, payload.ID)
+		},
+		http.StatusNoContent,
+		&review.DeleteReviewPayload{},
+	)(c)
+}
+
+func (h *ReviewHandler) ReplyToReview(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *review.ReplyToReviewPayload) (*review.Review, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.reviewService.ReplyToReview(c, userID, payload)
+		},
+		http.StatusOK,
+		&review.ReplyToReviewPayload{},
+	)(c)
+}
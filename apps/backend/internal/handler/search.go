@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/search"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type SearchHandler struct {
+	Handler
+	searchService *service.SearchService
+}
+
+func NewSearchHandler(s *server.Server, searchService *service.SearchService) *SearchHandler {
+	return &SearchHandler{
+		Handler:       NewHandler(s),
+		searchService: searchService,
+	}
+}
+
+func (h *SearchHandler) Search(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *search.SearchQuery) (*search.Results, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.searchService.Search(c, userID, query)
+		},
+		http.StatusOK,
+		&search.SearchQuery{},
+	)(c)
+}
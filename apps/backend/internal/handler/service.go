@@ -0,0 +1,214 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/lib/servicefile"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	serviceModel "github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type ServiceHandler struct {
+	Handler
+	serviceService *service.ServiceService
+}
+
+func NewServiceHandler(s *server.Server, serviceService *service.ServiceService) *ServiceHandler {
+	return &ServiceHandler{
+		Handler:        NewHandler(s),
+		serviceService: serviceService,
+	}
+}
+
+func (h *ServiceHandler) CreateService(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.CreateServicePayload) (*serviceModel.Service, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.CreateService(c, userID, payload)
+		},
+		http.StatusCreated,
+		&serviceModel.CreateServicePayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) GetServiceByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.GetServiceByIDPayload) (*serviceModel.PopulatedService, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.GetServiceByID(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&serviceModel.GetServiceByIDPayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) GetServices(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *serviceModel.GetServicesQuery) (*model.PaginatedResponse[serviceModel.PopulatedService], error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.GetServices(c, userID, query)
+		},
+		http.StatusOK,
+		&serviceModel.GetServicesQuery{},
+	)(c)
+}
+
+func (h *ServiceHandler) UpdateService(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.UpdateServicePayload) (*serviceModel.Service, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.UpdateService(c, userID, payload)
+		},
+		http.StatusOK,
+		&serviceModel.UpdateServicePayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) DeleteService(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.DeleteServiceByIDPayload) error {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.DeleteService(c, userID, payload.ID)
+		},
+		http.StatusNoContent,
+		&serviceModel.DeleteServiceByIDPayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) RestoreService(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.RestoreServicePayload) (*serviceModel.Service, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.RestoreService(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&serviceModel.RestoreServicePayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) ReorderServices(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.ReorderServicesPayload) ([]serviceModel.Service, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.ReorderServices(c, userID, payload)
+		},
+		http.StatusOK,
+		&serviceModel.ReorderServicesPayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) MoveService(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.MoveServicePayload) (*serviceModel.Service, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.MoveService(c, userID, payload)
+		},
+		http.StatusOK,
+		&serviceModel.MoveServicePayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) GetServiceTree(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.GetServiceTreePayload) (*serviceModel.TreeNode, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.GetServiceTree(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&serviceModel.GetServiceTreePayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) GetServicePrice(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *serviceModel.GetServicePriceQuery) (*serviceModel.Price, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.GetServicePrice(c, userID, query)
+		},
+		http.StatusOK,
+		&serviceModel.GetServicePriceQuery{},
+	)(c)
+}
+
+func (h *ServiceHandler) ImportServices(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.ImportServicesPayload) (*serviceModel.ImportResult, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.ImportServices(c, userID, payload)
+		},
+		http.StatusOK,
+		&serviceModel.ImportServicesPayload{},
+	)(c)
+}
+
+func (h *ServiceHandler) ExportServices(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = servicefile.FormatCSV
+	}
+
+	return HandleFile(
+		h.Handler,
+		func(c echo.Context, query *serviceModel.ExportServicesQuery) ([]byte, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.ExportServices(c, userID, query)
+		},
+		http.StatusOK,
+		&serviceModel.ExportServicesQuery{},
+		func(query *serviceModel.ExportServicesQuery) string {
+			return "services." + *query.Format
+		},
+		servicefile.ContentType(format),
+	)(c)
+}
+
+func (h *ServiceHandler) GetServiceVersions(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *serviceModel.GetServiceVersionsQuery) (*model.PaginatedResponse[serviceModel.Version], error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.GetServiceVersions(c, userID, query)
+		},
+		http.StatusOK,
+		&serviceModel.GetServiceVersionsQuery{},
+	)(c)
+}
+
+func (h *ServiceHandler) DiffServiceVersions(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *serviceModel.DiffServiceVersionsQuery) (*serviceModel.VersionDiff, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.DiffServiceVersions(c, userID, query)
+		},
+		http.StatusOK,
+		&serviceModel.DiffServiceVersionsQuery{},
+	)(c)
+}
+
+func (h *ServiceHandler) RestoreServiceVersion(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *serviceModel.RestoreServiceVersionPayload) (*serviceModel.Service, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceService.RestoreServiceVersion(c, userID, payload.ID, payload.Version)
+		},
+		http.StatusOK,
+		&serviceModel.RestoreServiceVersionPayload{},
+	)(c)
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/servicearea"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type ServiceAreaHandler struct {
+	Handler
+	serviceAreaService *service.ServiceAreaService
+}
+
+func NewServiceAreaHandler(s *server.Server, serviceAreaService *service.ServiceAreaService) *ServiceAreaHandler {
+	return &ServiceAreaHandler{
+		Handler:            NewHandler(s),
+		serviceAreaService: serviceAreaService,
+	}
+}
+
+func (h *ServiceAreaHandler) CreateServiceArea(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *servicearea.CreateServiceAreaPayload) (*servicearea.ServiceArea, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceAreaService.CreateServiceArea(c, userID, payload)
+		},
+		http.StatusCreated,
+		&servicearea.CreateServiceAreaPayload{},
+	)(c)
+}
+
+func (h *ServiceAreaHandler) GetServiceAreas(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *servicearea.GetServiceAreasPayload) ([]servicearea.ServiceArea, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceAreaService.GetServiceAreas(c, userID)
+		},
+		http.StatusOK,
+		&servicearea.GetServiceAreasPayload{},
+	)(c)
+}
+
+func (h *ServiceAreaHandler) DeleteServiceArea(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, payload *servicearea.DeleteServiceAreaPayload) error {
+			userID := middleware.GetOwnerID(c)
+			return h.serviceAreaService.DeleteServiceArea(c, userID, payload.ID)
+		},
+		http.StatusNoContent,
+		&servicearea.DeleteServiceAreaPayload{},
+	)(c)
+}
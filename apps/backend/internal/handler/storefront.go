@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/storefront"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type StorefrontHandler struct {
+	Handler
+	storefrontService *service.StorefrontService
+}
+
+func NewStorefrontHandler(s *server.Server, storefrontService *service.StorefrontService) *StorefrontHandler {
+	return &StorefrontHandler{
+		Handler:           NewHandler(s),
+		storefrontService: storefrontService,
+	}
+}
+
+func (h *StorefrontHandler) UpsertStorefront(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *storefront.UpsertStorefrontPayload) (*storefront.Storefront, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.storefrontService.UpsertStorefront(c, userID, payload)
+		},
+		http.StatusOK,
+		&storefront.UpsertStorefrontPayload{},
+	)(c)
+}
+
+func (h *StorefrontHandler) GetStorefront(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *storefront.GetStorefrontPayload) (*storefront.Storefront, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.storefrontService.GetStorefront(c, userID)
+		},
+		http.StatusOK,
+		&storefront.GetStorefrontPayload{},
+	)(c)
+}
+
+func (h *StorefrontHandler) GetPublicProvider(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *storefront.GetPublicProviderPayload) (*storefront.PublicProvider, error) {
+			return h.storefrontService.GetPublicProvider(c, payload.Handle)
+		},
+		http.StatusOK,
+		&storefront.GetPublicProviderPayload{},
+	)(c)
+}
+
+func (h *StorefrontHandler) GetPublicProviders(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *storefront.GetPublicProvidersQuery) (*model.PaginatedResponse[storefront.PublicProvider], error) {
+			return h.storefrontService.GetPublicProviders(c, query)
+		},
+		http.StatusOK,
+		&storefront.GetPublicProvidersQuery{},
+	)(c)
+}
+
+func (h *StorefrontHandler) GetPublicServices(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *storefront.GetPublicServicesQuery) (*model.PaginatedResponse[storefront.PublicPopulatedService], error) {
+			return h.storefrontService.GetPublicServices(c, query)
+		},
+		http.StatusOK,
+		&storefront.GetPublicServicesQuery{},
+	)(c)
+}
+
+func (h *StorefrontHandler) GetPublicService(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *storefront.GetPublicServicePayload) (*storefront.PublicPopulatedService, error) {
+			return h.storefrontService.GetPublicService(c, payload)
+		},
+		http.StatusOK,
+		&storefront.GetPublicServicePayload{},
+	)(c)
+}
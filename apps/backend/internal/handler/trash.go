@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/trash"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type TrashHandler struct {
+	Handler
+	trashService *service.TrashService
+}
+
+func NewTrashHandler(s *server.Server, trashService *service.TrashService) *TrashHandler {
+	return &TrashHandler{
+		Handler:      NewHandler(s),
+		trashService: trashService,
+	}
+}
+
+func (h *TrashHandler) GetTrash(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *trash.GetTrashPayload) (*trash.Trash, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.trashService.GetTrash(c, userID)
+		},
+		http.StatusOK,
+		&trash.GetTrashPayload{},
+	)(c)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+// clerkWebhookMaxBody bounds the webhook body read before its signature is checked
+const clerkWebhookMaxBody = 1 << 20
+
+type UserHandler struct {
+	Handler
+	userService *service.UserService
+}
+
+func NewUserHandler(s *server.Server, userService *service.UserService) *UserHandler {
+	return &UserHandler{
+		Handler:     NewHandler(s),
+		userService: userService,
+	}
+}
+
+// HandleClerkWebhook is called by Clerk rather than by users. The signature covers the raw body, so
+// it is read as is instead of being bound like the typed handlers do.
+func (h *UserHandler) HandleClerkWebhook(c echo.Context) error {
+	body, err := io.ReadAll(io.LimitReader(c.Request().Body, clerkWebhookMaxBody))
+	if err != nil {
+		return errs.NewBadRequestError("Failed to read webhook body", false, nil, nil, nil)
+	}
+
+	if err := h.userService.HandleClerkWebhook(c, body); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
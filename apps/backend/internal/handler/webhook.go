@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/webhook"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/service"
+)
+
+type WebhookHandler struct {
+	Handler
+	webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(s *server.Server, webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		Handler:        NewHandler(s),
+		webhookService: webhookService,
+	}
+}
+
+func (h *WebhookHandler) CreateEndpoint(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *webhook.CreateEndpointPayload) (*webhook.CreatedEndpoint, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.webhookService.CreateEndpoint(c, userID, payload)
+		},
+		http.StatusCreated,
+		&webhook.CreateEndpointPayload{},
+	)(c)
+}
+
+func (h *WebhookHandler) GetEndpoints(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *webhook.GetEndpointsPayload) ([]webhook.Endpoint, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.webhookService.GetEndpoints(c, userID)
+		},
+		http.StatusOK,
+		&webhook.GetEndpointsPayload{},
+	)(c)
+}
+
+func (h *WebhookHandler) GetEndpointByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *webhook.GetEndpointByIDPayload) (*webhook.Endpoint, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.webhookService.GetEndpointByID(c, userID, payload.ID)
+		},
+		http.StatusOK,
+		&webhook.GetEndpointByIDPayload{},
+	)(c)
+}
+
+func (h *WebhookHandler) UpdateEndpoint(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *webhook.UpdateEndpointPayload) (*webhook.Endpoint, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.webhookService.UpdateEndpoint(c, userID, payload)
+		},
+		http.StatusOK,
+		&webhook.UpdateEndpointPayload{},
+	)(c)
+}
+
+func (h *WebhookHandler) DeleteEndpoint(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, payload *webhook.DeleteEndpointPayload) error {
+			userID := middleware.GetOwnerID(c)
+			return h.webhookService.DeleteEndpoint(c, userID, payload.ID)
+		},
+		http.StatusNoContent,
+		&webhook.DeleteEndpointPayload{},
+	)(c)
+}
+
+func (h *WebhookHandler) GetDeliveries(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, query *webhook.GetDeliveriesQuery) (*model.PaginatedResponse[webhook.Delivery], error) {
+			userID := middleware.GetOwnerID(c)
+			return h.webhookService.GetDeliveries(c, userID, query)
+		},
+		http.StatusOK,
+		&webhook.GetDeliveriesQuery{},
+	)(c)
+}
+
+func (h *WebhookHandler) Redeliver(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *webhook.RedeliverPayload) (*webhook.Delivery, error) {
+			userID := middleware.GetOwnerID(c)
+			return h.webhookService.Redeliver(c, userID, payload)
+		},
+		http.StatusAccepted,
+		&webhook.RedeliverPayload{},
+	)(c)
+}
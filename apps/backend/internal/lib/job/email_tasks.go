@@ -16,7 +16,9 @@ type WelcomeEmailPayload struct {
 	FirstName string `json:"first_name"`
 }
 
-func NewWelcomeEmailTask(to, firstName string) (*asynq.Task, error) {
+// NewWelcomeEmailTask builds the welcome email for a user. The task id is derived from the user, so
+// enqueueing it again while the task is pending or retained fails with asynq.ErrTaskIDConflict.
+func NewWelcomeEmailTask(userID, to, firstName string) (*asynq.Task, error) {
 	payload, err := json.Marshal(WelcomeEmailPayload{
 		To:        to,
 		FirstName: firstName,
@@ -26,6 +28,8 @@ func NewWelcomeEmailTask(to, firstName string) (*asynq.Task, error) {
 	}
 
 	return asynq.NewTask(TaskWelcome, payload,
+		asynq.TaskID("welcome:"+userID),
+		asynq.Retention(24*time.Hour),
 		asynq.MaxRetry(3),
 		asynq.Queue("default"),
 		asynq.Timeout(30*time.Second)), nil
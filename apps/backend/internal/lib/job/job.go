@@ -1,15 +1,20 @@
 package job
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/hibiken/asynq"
 	"github.com/mukundaparajuli/fixr/internal/config"
 	"github.com/rs/zerolog"
 )
 
 type JobService struct {
-	Client *asynq.Client
-	server *asynq.Server
-	logger *zerolog.Logger
+	Client    *asynq.Client
+	server    *asynq.Server
+	mux       *asynq.ServeMux
+	scheduler *asynq.Scheduler
+	logger    *zerolog.Logger
 }
 
 func NewJobService(logger *zerolog.Logger, cfg *config.Config) *JobService {
@@ -30,31 +35,57 @@ func NewJobService(logger *zerolog.Logger, cfg *config.Config) *JobService {
 				"default":  3, // Default priority for most emails
 				"low":      1, // Lower priority for non-urgent emails
 			},
+			RetryDelayFunc: retryDelay,
 		},
 	)
 
+	// scheduler enqueues periodic tasks; the server above picks them up like any other job
+	scheduler := asynq.NewScheduler(asynq.RedisClientOpt{Addr: redisAddr}, nil)
+
 	return &JobService{
-		Client: client,
-		server: server,
-		logger: logger,
+		Client:    client,
+		server:    server,
+		mux:       asynq.NewServeMux(),
+		scheduler: scheduler,
+		logger:    logger,
 	}
 }
 
 func (j *JobService) Start() error {
 	// Register task handlers
-	mux := asynq.NewServeMux()
-	mux.HandleFunc(TaskWelcome, j.handleWelcomeEmailTask)
+	j.mux.HandleFunc(TaskWelcome, j.handleWelcomeEmailTask)
 
 	j.logger.Info().Msg("Starting background job server")
-	if err := j.server.Start(mux); err != nil {
+	if err := j.server.Start(j.mux); err != nil {
+		return err
+	}
+
+	if err := j.scheduler.Start(); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// RegisterHandler adds a handler for tasks owned by other packages, such as services that need
+// repositories the job package cannot import. Handlers must be registered before Start, or the
+// server may pick up tasks it has no handler for.
+func (j *JobService) RegisterHandler(taskType string, handler func(context.Context, *asynq.Task) error) {
+	j.mux.HandleFunc(taskType, handler)
+}
+
+// Schedule enqueues task on the given cron spec (e.g. "@hourly") for as long as the service runs
+func (j *JobService) Schedule(cronspec string, task *asynq.Task) error {
+	if _, err := j.scheduler.Register(cronspec, task); err != nil {
+		return fmt.Errorf("failed to schedule task %s: %w", task.Type(), err)
+	}
+
+	return nil
+}
+
 func (j *JobService) Stop() {
 	j.logger.Info().Msg("Stopping background job server")
+	j.scheduler.Shutdown()
 	j.server.Shutdown()
 	j.Client.Close()
 }
@@ -0,0 +1,20 @@
+package job
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskPurgeTrash = "maintenance:purge_trash"
+)
+
+func NewPurgeTrashTask() *asynq.Task {
+	// Unique keeps several app instances, each running the scheduler, from purging concurrently
+	return asynq.NewTask(TaskPurgeTrash, nil,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(5*time.Minute),
+		asynq.Unique(30*time.Minute))
+}
@@ -0,0 +1,55 @@
+package job
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskDeliverWebhook = "webhook:deliver"
+)
+
+// Webhook deliveries back off exponentially from webhookRetryBase up to webhookRetryMax, so a
+// receiver that is down gets retried for roughly a day before the delivery is marked failed
+const (
+	webhookMaxRetry  = 10
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = 6 * time.Hour
+)
+
+type DeliverWebhookPayload struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+func NewDeliverWebhookTask(deliveryID uuid.UUID) (*asynq.Task, error) {
+	payload, err := json.Marshal(DeliverWebhookPayload{
+		DeliveryID: deliveryID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskDeliverWebhook, payload,
+		asynq.MaxRetry(webhookMaxRetry),
+		asynq.Queue("default"),
+		asynq.Timeout(30*time.Second)), nil
+}
+
+// retryDelay backs webhook deliveries off exponentially with jitter; other tasks keep asynq's default
+func retryDelay(n int, err error, task *asynq.Task) time.Duration {
+	if task.Type() != TaskDeliverWebhook {
+		return asynq.DefaultRetryDelayFunc(n, err, task)
+	}
+
+	delay := webhookRetryMax
+	if n < 20 {
+		delay = min(webhookRetryBase<<n, webhookRetryMax)
+	}
+
+	// up to 10% jitter keeps retries for many endpoints from lining up
+	return delay + rand.N(delay/10+1)
+}
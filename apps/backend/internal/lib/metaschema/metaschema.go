@@ -0,0 +1,122 @@
+// Package metaschema compiles the JSON Schemas categories define for service metadata and reports
+// schema violations as field errors.
+package metaschema
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// MaxSchemaSize bounds the size of a stored schema in bytes
+const MaxSchemaSize = 16 * 1024
+
+// Compile parses and compiles a schema. Schemas must describe an object, since metadata is always one,
+// and may not reference other documents: no loader is configured, so remote $refs fail to compile.
+func Compile(raw []byte) (*jsonschema.Schema, error) {
+	if len(raw) > MaxSchemaSize {
+		return nil, fmt.Errorf("must not exceed %d bytes", MaxSchemaSize)
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("must be valid JSON: %w", err)
+	}
+	object, ok := doc.(map[string]any)
+	if !ok || object["type"] != "object" {
+		return nil, fmt.Errorf(`must be a schema with "type": "object"`)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.DefaultDraft(jsonschema.Draft2020)
+	compiler.AssertFormat()
+	if err := compiler.AddResource("metadata.json", doc); err != nil {
+		return nil, fmt.Errorf("must be a valid JSON Schema: %w", err)
+	}
+
+	schema, err := compiler.Compile("metadata.json")
+	if err != nil {
+		var invalid *jsonschema.SchemaValidationError
+		if errors.As(err, &invalid) {
+			if validationError, ok := invalid.Err.(*jsonschema.ValidationError); ok {
+				if leaves := leafErrors(validationError); len(leaves) > 0 {
+					return nil, fmt.Errorf("must be a valid JSON Schema: %s at %q", leaves[0].message, "/"+strings.Join(leaves[0].location, "/"))
+				}
+			}
+		}
+		return nil, fmt.Errorf("must be a valid JSON Schema: %w", err)
+	}
+	return schema, nil
+}
+
+// Validate checks metadata against a compiled schema. Each failing keyword becomes one field error
+// named after where it failed, e.g. "metadata.vehicleType" or "metadata" for the object itself.
+func Validate(schema *jsonschema.Schema, metadata map[string]any) []errs.FieldError {
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+
+	err := schema.Validate(metadata)
+	if err == nil {
+		return nil
+	}
+
+	validationError, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return []errs.FieldError{{Field: "metadata", Error: err.Error()}}
+	}
+
+	var fieldErrors []errs.FieldError
+	for _, leaf := range leafErrors(validationError) {
+		fieldErrors = append(fieldErrors, errs.FieldError{
+			Field: fieldName(leaf.location),
+			Error: leaf.message,
+		})
+	}
+	if len(fieldErrors) == 0 {
+		return []errs.FieldError{{Field: "metadata", Error: "does not match the category schema"}}
+	}
+	sort.SliceStable(fieldErrors, func(i, j int) bool { return fieldErrors[i].Field < fieldErrors[j].Field })
+
+	return fieldErrors
+}
+
+// fieldName turns an instance location such as ["vehicle", "type"] into "metadata.vehicle.type"
+func fieldName(location []string) string {
+	if len(location) == 0 {
+		return "metadata"
+	}
+	return "metadata." + strings.Join(location, ".")
+}
+
+var printer = message.NewPrinter(language.English)
+
+// leafError is a failing keyword together with the location in the instance where it failed
+type leafError struct {
+	location []string
+	message  string
+}
+
+// leafErrors walks the error tree down to the errors that say what is wrong, skipping the
+// combinators and $refs ("allOf failed", "validation failed") that only wrap them
+func leafErrors(validationError *jsonschema.ValidationError) []leafError {
+	if len(validationError.Causes) == 0 {
+		return []leafError{{
+			location: validationError.InstanceLocation,
+			message:  validationError.ErrorKind.LocalizedString(printer),
+		}}
+	}
+
+	var leaves []leafError
+	for _, cause := range validationError.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+	return leaves
+}
@@ -0,0 +1,117 @@
+package metaschema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const vehicleSchema = `{
+	"type": "object",
+	"properties": {
+		"vehicleType": {"type": "string", "enum": ["car", "van", "truck"]},
+		"seats": {"type": "integer", "minimum": 1},
+		"contact": {
+			"type": "object",
+			"properties": {"email": {"type": "string", "format": "email"}},
+			"required": ["email"]
+		}
+	},
+	"required": ["vehicleType"],
+	"additionalProperties": false
+}`
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{name: "object schema", raw: vehicleSchema},
+		{name: "empty object schema", raw: `{"type": "object"}`},
+		{name: "not JSON", raw: `{"type": `, wantErr: "must be valid JSON"},
+		{name: "array schema", raw: `{"type": "array"}`, wantErr: `must be a schema with "type": "object"`},
+		{name: "no type", raw: `{"properties": {}}`, wantErr: `must be a schema with "type": "object"`},
+		{name: "not an object", raw: `true`, wantErr: `must be a schema with "type": "object"`},
+		{name: "invalid keyword value", raw: `{"type": "object", "properties": {"seats": {"minimum": "one"}}}`, wantErr: "must be a valid JSON Schema"},
+		{name: "remote reference", raw: `{"type": "object", "$ref": "https://example.com/schema.json"}`, wantErr: "must be a valid JSON Schema"},
+		{name: "too large", raw: `{"type": "object", "description": "` + strings.Repeat("a", MaxSchemaSize) + `"}`, wantErr: "must not exceed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Compile([]byte(tt.raw))
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, schema)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile([]byte(vehicleSchema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		metadata   map[string]any
+		wantFields []string
+	}{
+		{name: "valid", metadata: map[string]any{"vehicleType": "van", "seats": 3}},
+		{name: "valid nested object", metadata: map[string]any{"vehicleType": "car", "contact": map[string]any{"email": "fleet@example.com"}}},
+		{name: "nil metadata misses required keys", metadata: nil, wantFields: []string{"metadata"}},
+		{name: "missing required key", metadata: map[string]any{"seats": 2}, wantFields: []string{"metadata"}},
+		{name: "value outside the enum", metadata: map[string]any{"vehicleType": "bike"}, wantFields: []string{"metadata.vehicleType"}},
+		{name: "wrong type", metadata: map[string]any{"vehicleType": "car", "seats": "two"}, wantFields: []string{"metadata.seats"}},
+		{name: "below the minimum", metadata: map[string]any{"vehicleType": "car", "seats": 0}, wantFields: []string{"metadata.seats"}},
+		{name: "unknown key", metadata: map[string]any{"vehicleType": "car", "color": "red"}, wantFields: []string{"metadata"}},
+		{name: "nested format", metadata: map[string]any{"vehicleType": "car", "contact": map[string]any{"email": "not an email"}}, wantFields: []string{"metadata.contact.email"}},
+		{name: "nested required key", metadata: map[string]any{"vehicleType": "car", "contact": map[string]any{}}, wantFields: []string{"metadata.contact"}},
+		{
+			name:       "several errors are sorted by field",
+			metadata:   map[string]any{"vehicleType": "bike", "seats": 0},
+			wantFields: []string{"metadata.seats", "metadata.vehicleType"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErrors := Validate(schema, tt.metadata)
+			if len(tt.wantFields) == 0 {
+				assert.Empty(t, fieldErrors)
+				return
+			}
+
+			fields := make([]string, 0, len(fieldErrors))
+			for _, fieldError := range fieldErrors {
+				fields = append(fields, fieldError.Field)
+				assert.NotEmpty(t, fieldError.Error)
+			}
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func TestValidateMessages(t *testing.T) {
+	schema, err := Compile([]byte(vehicleSchema))
+	require.NoError(t, err)
+
+	fieldErrors := Validate(schema, map[string]any{"seats": 2})
+	require.Len(t, fieldErrors, 1)
+	assert.Equal(t, errs.FieldError{Field: "metadata", Error: fieldErrors[0].Error}, fieldErrors[0])
+	assert.Contains(t, fieldErrors[0].Error, "vehicleType")
+}
+
+func TestFieldName(t *testing.T) {
+	assert.Equal(t, "metadata", fieldName(nil))
+	assert.Equal(t, "metadata.vehicleType", fieldName([]string{"vehicleType"}))
+	assert.Equal(t, "metadata.vehicle.type", fieldName([]string{"vehicle", "type"}))
+	assert.Equal(t, "metadata.axles.0", fieldName([]string{"axles", "0"}))
+}
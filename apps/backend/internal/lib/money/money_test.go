@@ -0,0 +1,94 @@
+package money
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNormalizesCurrency(t *testing.T) {
+	assert.Equal(t, Money{Amount: 100, Currency: "EUR"}, New(100, "eur"))
+	assert.Equal(t, Money{Amount: 0, Currency: "USD"}, Zero("usd"))
+}
+
+func TestAddSub(t *testing.T) {
+	sum, err := New(150, "USD").Add(New(250, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, New(400, "USD"), sum)
+
+	diff, err := New(150, "USD").Sub(New(250, "USD"))
+	require.NoError(t, err)
+	assert.Equal(t, New(-100, "USD"), diff)
+	assert.True(t, diff.IsNegative())
+
+	_, err = New(150, "USD").Add(New(250, "EUR"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = New(150, "USD").Sub(New(250, "EUR"))
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
+
+func TestTimes(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		quantity float64
+		want     int64
+	}{
+		{name: "whole quantity", amount: 1250, quantity: 3, want: 3750},
+		{name: "fractional quantity", amount: 4000, quantity: 1.5, want: 6000},
+		{name: "rounds half away from zero", amount: 5, quantity: 0.5, want: 3},
+		{name: "rounds negative half away from zero", amount: -5, quantity: 0.5, want: -3},
+		{name: "rounds down below half", amount: 1000, quantity: 0.3333, want: 333},
+		{name: "zero quantity", amount: 1250, quantity: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, New(tt.want, "USD"), New(tt.amount, "USD").Times(tt.quantity))
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	tests := []struct {
+		amount      int64
+		basisPoints int64
+		want        int64
+	}{
+		{amount: 10000, basisPoints: 1250, want: 1250},
+		{amount: 8500, basisPoints: 825, want: 701},
+		{amount: 199, basisPoints: 5000, want: 100},
+		{amount: 10000, basisPoints: 0, want: 0},
+		{amount: 10000, basisPoints: 10000, want: 10000},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, New(tt.want, "USD"), New(tt.amount, "USD").Percent(tt.basisPoints), "%d at %d bp", tt.amount, tt.basisPoints)
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: New(1250, "USD"), want: "12.50 USD"},
+		{money: New(5, "USD"), want: "0.05 USD"},
+		{money: New(-5, "USD"), want: "-0.05 USD"},
+		{money: New(-1250, "EUR"), want: "-12.50 EUR"},
+		{money: New(500, "JPY"), want: "500 JPY"},
+		{money: New(-500, "krw"), want: "-500 KRW"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.money.String())
+	}
+}
+
+func TestExponent(t *testing.T) {
+	assert.Equal(t, 2, Exponent("USD"))
+	assert.Equal(t, 0, Exponent("JPY"))
+	assert.Equal(t, 0, Exponent("jpy"))
+}
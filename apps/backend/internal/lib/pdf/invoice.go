@@ -0,0 +1,107 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/mukundaparajuli/fixr/internal/lib/money"
+	"github.com/mukundaparajuli/fixr/internal/model/invoice"
+)
+
+const (
+	pageMargin = 15.0
+	lineHeight = 7.0
+)
+
+// RenderInvoice lays an invoice out on A4 pages and returns the encoded PDF
+func RenderInvoice(inv *invoice.PopulatedInvoice) ([]byte, error) {
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.SetMargins(pageMargin, pageMargin, pageMargin)
+	doc.SetAutoPageBreak(true, pageMargin)
+	doc.SetTitle(inv.DisplayNumber(), true)
+	doc.AddPage()
+
+	// core fonts only cover cp1252, so translate free text before writing it
+	tr := doc.UnicodeTranslatorFromDescriptor("")
+	amount := func(v int64) string {
+		return money.New(v, inv.Currency).String()
+	}
+
+	doc.SetFont("Helvetica", "B", 20)
+	doc.CellFormat(0, 12, "Invoice "+inv.DisplayNumber(), "", 1, "L", false, 0, "")
+
+	doc.SetFont("Helvetica", "", 10)
+	doc.CellFormat(0, lineHeight, "Status: "+string(inv.Status), "", 1, "L", false, 0, "")
+	doc.CellFormat(0, lineHeight, "Issued: "+inv.CreatedAt.Format("2006-01-02"), "", 1, "L", false, 0, "")
+	if inv.DueAt != nil {
+		doc.CellFormat(0, lineHeight, "Due: "+inv.DueAt.Format("2006-01-02"), "", 1, "L", false, 0, "")
+	}
+	doc.Ln(4)
+
+	widths := []float64{90, 25, 30, 35}
+	doc.SetFont("Helvetica", "B", 10)
+	doc.SetFillColor(235, 235, 235)
+	for i, header := range []string{"Description", "Quantity", "Unit", "Amount"} {
+		align := "L"
+		if i > 0 {
+			align = "R"
+		}
+		doc.CellFormat(widths[i], lineHeight, header, "B", 0, align, true, 0, "")
+	}
+	doc.Ln(-1)
+
+	doc.SetFont("Helvetica", "", 10)
+	for _, item := range inv.Items {
+		unit := ""
+		if item.UnitLabel != nil {
+			unit = *item.UnitLabel
+		}
+		doc.CellFormat(widths[0], lineHeight, tr(item.Description), "", 0, "L", false, 0, "")
+		doc.CellFormat(widths[1], lineHeight, strconv.FormatFloat(item.Quantity, 'f', -1, 64), "", 0, "R", false, 0, "")
+		doc.CellFormat(widths[2], lineHeight, tr(unit), "", 0, "R", false, 0, "")
+		doc.CellFormat(widths[3], lineHeight, amount(item.Amount), "", 1, "R", false, 0, "")
+
+		// tiered prices are easier to check with their breakdown underneath
+		if len(item.Lines) > 1 {
+			doc.SetFont("Helvetica", "I", 8)
+			for _, line := range item.Lines {
+				text := fmt.Sprintf("    %s: %g x %s", line.Description, line.Quantity, line.UnitAmount)
+				doc.CellFormat(widths[0]+widths[1]+widths[2], 5, tr(text), "", 0, "L", false, 0, "")
+				doc.CellFormat(widths[3], 5, line.Amount.String(), "", 1, "R", false, 0, "")
+			}
+			doc.SetFont("Helvetica", "", 10)
+		}
+	}
+	doc.Ln(4)
+
+	labelWidth := widths[0] + widths[1] + widths[2]
+	totalRow := func(label string, value string) {
+		doc.CellFormat(labelWidth, lineHeight, label, "", 0, "R", false, 0, "")
+		doc.CellFormat(widths[3], lineHeight, value, "", 1, "R", false, 0, "")
+	}
+
+	totalRow("Subtotal", amount(inv.Subtotal))
+	if inv.DiscountAmount > 0 {
+		totalRow("Discount", "-"+amount(inv.DiscountAmount))
+	}
+	if inv.TaxRate > 0 {
+		totalRow(fmt.Sprintf("Tax (%s%%)", strconv.FormatFloat(float64(inv.TaxRate)/100, 'f', -1, 64)), amount(inv.TaxAmount))
+	}
+	doc.SetFont("Helvetica", "B", 11)
+	totalRow("Total", amount(inv.Total))
+
+	if inv.Notes != nil {
+		doc.Ln(6)
+		doc.SetFont("Helvetica", "", 9)
+		doc.MultiCell(0, 5, tr(*inv.Notes), "", "L", false)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render invoice %s: %w", inv.DisplayNumber(), err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,267 @@
+// Package servicefile reads and writes service catalogs as CSV or JSON files of service.ServiceRow.
+package servicefile
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// columns is the CSV header written on export; on import any subset in any order is accepted as long as name is present
+var columns = []string{
+	"name",
+	"description",
+	"status",
+	"rate",
+	"currency",
+	"method",
+	"unit_label",
+	"price_tiers",
+	"duration_minutes",
+	"category",
+	"parent",
+	"metadata",
+}
+
+func ContentType(format string) string {
+	if format == FormatJSON {
+		return "application/json"
+	}
+	return "text/csv"
+}
+
+// Decode parses a file into rows. Problems confined to a single row are returned as field errors
+// ("rows[2].rate") alongside the rows that did parse; a file that cannot be read at all returns an error.
+func Decode(format string, r io.Reader) ([]service.ServiceRow, []errs.FieldError, error) {
+	switch format {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatJSON:
+		return decodeJSON(r)
+	default:
+		return nil, nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func Encode(format string, rows []service.ServiceRow) ([]byte, error) {
+	switch format {
+	case FormatCSV:
+		return encodeCSV(rows)
+	case FormatJSON:
+		return json.MarshalIndent(rows, "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+func RowField(index int, field string) string {
+	if field == "" {
+		return fmt.Sprintf("rows[%d]", index)
+	}
+	return fmt.Sprintf("rows[%d].%s", index, field)
+}
+
+func decodeJSON(r io.Reader) ([]service.ServiceRow, []errs.FieldError, error) {
+	var raw []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, nil, fmt.Errorf("file must contain a JSON array of services: %w", err)
+	}
+
+	rows := make([]service.ServiceRow, 0, len(raw))
+	var fieldErrors []errs.FieldError
+	for i, message := range raw {
+		var row service.ServiceRow
+		if err := json.Unmarshal(message, &row); err != nil {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: RowField(i, ""), Error: err.Error()})
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, fieldErrors, nil
+}
+
+func decodeCSV(r io.Reader) ([]service.ServiceRow, []errs.FieldError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !isColumn(name) {
+			return nil, nil, fmt.Errorf("unknown CSV column %q, expected any of: %s", name, strings.Join(columns, ", "))
+		}
+		index[name] = i
+	}
+	if _, ok := index["name"]; !ok {
+		return nil, nil, fmt.Errorf("CSV header must include a name column")
+	}
+
+	var rows []service.ServiceRow
+	var fieldErrors []errs.FieldError
+	for i := 0; ; i++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		cell := func(column string) *string {
+			position, ok := index[column]
+			if !ok || position >= len(record) {
+				return nil
+			}
+			value := strings.TrimSpace(record[position])
+			if value == "" {
+				return nil
+			}
+			return &value
+		}
+		addError := func(column string, err error) {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: RowField(i, column), Error: err.Error()})
+		}
+
+		row := service.ServiceRow{
+			Description: cell("description"),
+			Currency:    cell("currency"),
+			UnitLabel:   cell("unit_label"),
+			Category:    cell("category"),
+			Parent:      cell("parent"),
+		}
+		if name := cell("name"); name != nil {
+			row.Name = *name
+		}
+		if status := cell("status"); status != nil {
+			value := service.Status(*status)
+			row.Status = &value
+		}
+		if method := cell("method"); method != nil {
+			value := service.Method(*method)
+			row.Method = &value
+		}
+		if rate := cell("rate"); rate != nil {
+			value, err := strconv.ParseInt(*rate, 10, 64)
+			if err != nil {
+				addError("rate", fmt.Errorf("must be a whole number of minor currency units"))
+			} else {
+				row.Rate = &value
+			}
+		}
+		if duration := cell("duration_minutes"); duration != nil {
+			value, err := strconv.Atoi(*duration)
+			if err != nil {
+				addError("duration_minutes", fmt.Errorf("must be a whole number"))
+			} else {
+				row.DurationMinutes = &value
+			}
+		}
+		if tiers := cell("price_tiers"); tiers != nil {
+			if err := json.Unmarshal([]byte(*tiers), &row.PriceTiers); err != nil {
+				addError("price_tiers", fmt.Errorf("must be a JSON array of tiers"))
+			}
+		}
+		if metadata := cell("metadata"); metadata != nil {
+			if err := json.Unmarshal([]byte(*metadata), &row.Metadata); err != nil {
+				addError("metadata", fmt.Errorf("must be a JSON object"))
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, fieldErrors, nil
+}
+
+func encodeCSV(rows []service.ServiceRow) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(columns); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, row := range rows {
+		tiers := ""
+		if len(row.PriceTiers) > 0 {
+			encoded, err := json.Marshal(row.PriceTiers)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode price tiers for %s: %w", row.Name, err)
+			}
+			tiers = string(encoded)
+		}
+		metadata := ""
+		if len(row.Metadata) > 0 {
+			encoded, err := json.Marshal(row.Metadata)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode metadata for %s: %w", row.Name, err)
+			}
+			metadata = string(encoded)
+		}
+
+		record := []string{
+			row.Name,
+			deref(row.Description),
+			deref((*string)(row.Status)),
+			formatInt(row.Rate),
+			deref(row.Currency),
+			deref((*string)(row.Method)),
+			deref(row.UnitLabel),
+			tiers,
+			formatInt(row.DurationMinutes),
+			deref(row.Category),
+			deref(row.Parent),
+			metadata,
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row for %s: %w", row.Name, err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func isColumn(name string) bool {
+	for _, column := range columns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+func deref(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func formatInt[T int | int64](value *T) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(int64(*value), 10)
+}
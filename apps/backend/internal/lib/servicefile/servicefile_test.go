@@ -0,0 +1,179 @@
+package servicefile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func sampleRows() []service.ServiceRow {
+	return []service.ServiceRow{
+		{
+			Name:            "Plumbing",
+			Description:     ptr("Pipes, taps and drains"),
+			Status:          ptr(service.Active),
+			Rate:            ptr(int64(4500)),
+			Currency:        ptr("USD"),
+			Method:          ptr(service.Hourly),
+			DurationMinutes: ptr(60),
+			Category:        ptr("Home"),
+			Metadata:        service.Metadata{"license": "PL-1", "emergency": true},
+		},
+		{
+			Name:      "Leak repair, kitchen",
+			Status:    ptr(service.Inactive),
+			Rate:      ptr(int64(0)),
+			Currency:  ptr("USD"),
+			Method:    ptr(service.Tiered),
+			UnitLabel: ptr("fixture"),
+			PriceTiers: []service.PriceTier{
+				{UpTo: ptr(2.0), UnitAmount: 3000},
+				{UnitAmount: 2500},
+			},
+			Parent: ptr("Plumbing"),
+		},
+		{
+			Name: `Say "hello"`,
+		},
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			encoded, err := Encode(format, sampleRows())
+			require.NoError(t, err)
+
+			decoded, fieldErrors, err := Decode(format, strings.NewReader(string(encoded)))
+			require.NoError(t, err)
+			assert.Empty(t, fieldErrors)
+			assert.Equal(t, sampleRows(), decoded)
+		})
+	}
+}
+
+func TestEncodeCSVHeader(t *testing.T) {
+	encoded, err := Encode(FormatCSV, nil)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join(columns, ",")+"\n", string(encoded))
+}
+
+func TestDecodeCSV(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		want       []service.ServiceRow
+		wantErrors []errs.FieldError
+	}{
+		{
+			name:  "subset of columns in any order",
+			input: "rate,NAME\n1000,Gutter cleaning\n",
+			want:  []service.ServiceRow{{Name: "Gutter cleaning", Rate: ptr(int64(1000))}},
+		},
+		{
+			name:  "byte order mark and padding",
+			input: "\ufeffname, currency\n  Roofing ,  eur\n",
+			want:  []service.ServiceRow{{Name: "Roofing", Currency: ptr("eur")}},
+		},
+		{
+			name:  "empty cells are unset",
+			input: "name,description,rate\nPainting,,\n",
+			want:  []service.ServiceRow{{Name: "Painting"}},
+		},
+		{
+			name:  "row level errors keep the other rows",
+			input: "name,rate,duration_minutes,price_tiers,metadata\nA,12.5,x,[,[]\nB,100,30,,\n",
+			want: []service.ServiceRow{
+				{Name: "A"},
+				{Name: "B", Rate: ptr(int64(100)), DurationMinutes: ptr(30)},
+			},
+			wantErrors: []errs.FieldError{
+				{Field: "rows[0].rate", Error: "must be a whole number of minor currency units"},
+				{Field: "rows[0].duration_minutes", Error: "must be a whole number"},
+				{Field: "rows[0].price_tiers", Error: "must be a JSON array of tiers"},
+				{Field: "rows[0].metadata", Error: "must be a JSON object"},
+			},
+		},
+		{
+			name:  "header only",
+			input: "name\n",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, fieldErrors, err := Decode(FormatCSV, strings.NewReader(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rows)
+			assert.Equal(t, tt.wantErrors, fieldErrors)
+		})
+	}
+}
+
+func TestDecodeCSVRejectsFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "empty file", input: "", wantErr: "failed to read CSV header"},
+		{name: "unknown column", input: "name,price\nA,1\n", wantErr: `unknown CSV column "price"`},
+		{name: "missing name column", input: "rate\n100\n", wantErr: "must include a name column"},
+		{name: "short record", input: "name,rate\nPainting\n", wantErr: "wrong number of fields"},
+		{name: "malformed quoting", input: "name\n\"unterminated\n", wantErr: "failed to read CSV"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := Decode(FormatCSV, strings.NewReader(tt.input))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	rows, fieldErrors, err := Decode(FormatJSON, strings.NewReader(`[
+		{"name": "Plumbing", "rate": 4500},
+		{"name": "Roofing", "rate": "cheap"},
+		{"name": "Painting", "unitLabel": "room"}
+	]`))
+	require.NoError(t, err)
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, service.ServiceRow{Name: "Plumbing", Rate: ptr(int64(4500))}, rows[0])
+	assert.Equal(t, service.ServiceRow{Name: "Painting", UnitLabel: ptr("room")}, rows[2])
+
+	require.Len(t, fieldErrors, 1)
+	assert.Equal(t, "rows[1]", fieldErrors[0].Field)
+
+	_, _, err = Decode(FormatJSON, strings.NewReader(`{"name": "Plumbing"}`))
+	assert.Error(t, err)
+}
+
+func TestUnsupportedFormat(t *testing.T) {
+	_, _, err := Decode("xml", strings.NewReader(""))
+	assert.Error(t, err)
+
+	_, err = Encode("xml", nil)
+	assert.Error(t, err)
+}
+
+func TestRowField(t *testing.T) {
+	assert.Equal(t, "rows[3]", RowField(3, ""))
+	assert.Equal(t, "rows[0].rate", RowField(0, "rate"))
+}
+
+func TestContentType(t *testing.T) {
+	assert.Equal(t, "text/csv", ContentType(FormatCSV))
+	assert.Equal(t, "application/json", ContentType(FormatJSON))
+}
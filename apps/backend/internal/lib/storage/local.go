@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mukundaparajuli/fixr/internal/config"
+)
+
+// LocalDownloadPath is the route that serves signed downloads for the local driver
+const LocalDownloadPath = "/api/v1/attachments/download"
+
+// ErrInvalidSignature is returned when a signed download URL is tampered with or expired
+var ErrInvalidSignature = errors.New("storage: invalid or expired signature")
+
+// LocalStorage stores blobs on the local filesystem and signs download URLs with HMAC-SHA256
+type LocalStorage struct {
+	root       string
+	publicURL  string
+	signingKey []byte
+}
+
+func NewLocalStorage(cfg config.LocalStorageConfig) (*LocalStorage, error) {
+	root, err := filepath.Abs(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local storage path %s: %w", cfg.Path, err)
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create local storage directory %s: %w", root, err)
+	}
+
+	return &LocalStorage{
+		root:       root,
+		publicURL:  strings.TrimRight(cfg.PublicURL, "/"),
+		signingKey: []byte(cfg.SigningKey),
+	}, nil
+}
+
+// path resolves key inside the storage root, rejecting keys that would escape it
+func (s *LocalStorage) path(key string) (string, error) {
+	p := filepath.Join(s.root, filepath.FromSlash(key))
+	if !strings.HasPrefix(p, s.root+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid storage key: %s", key)
+	}
+	return p, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return fmt.Errorf("failed to create directory for key %s: %w", key, err)
+	}
+
+	f, err := os.Create(p)
+	if err != nil {
+		return fmt.Errorf("failed to create file for key %s: %w", key, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, body); err != nil {
+		_ = os.Remove(p)
+		return fmt.Errorf("failed to write file for key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (*Object, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to open file for key %s: %w", key, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to stat file for key %s: %w", key, err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(p))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &Object{
+		Body:        f,
+		Size:        info.Size(),
+		ContentType: contentType,
+	}, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file for key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, filename string, expiry time.Duration) (string, error) {
+	expires := time.Now().Add(expiry).Unix()
+
+	query := url.Values{}
+	query.Set("key", key)
+	query.Set("filename", filename)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(key, filename, expires))
+
+	return s.publicURL + LocalDownloadPath + "?" + query.Encode(), nil
+}
+
+// Verify checks that a signature produced by SignedURL is authentic and not expired
+func (s *LocalStorage) Verify(key, filename string, expires int64, signature string) error {
+	if time.Now().Unix() > expires {
+		return ErrInvalidSignature
+	}
+
+	expected := s.sign(key, filename, expires)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) sign(key, filename string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key))
+	mac.Write([]byte{0})
+	mac.Write([]byte(filename))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mukundaparajuli/fixr/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalStorage(t *testing.T, signingKey string) *LocalStorage {
+	t.Helper()
+
+	s, err := NewLocalStorage(config.LocalStorageConfig{
+		Path:       t.TempDir(),
+		PublicURL:  "http://localhost:8080/",
+		SigningKey: signingKey,
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func TestLocalStoragePath(t *testing.T) {
+	s := newTestLocalStorage(t, "key")
+
+	tests := []struct {
+		name    string
+		key     string
+		want    string
+		wantErr bool
+	}{
+		{name: "nested key", key: "services/abc/file.pdf", want: "services/abc/file.pdf"},
+		{name: "cleaned inside root", key: "services/../services/file.pdf", want: "services/file.pdf"},
+		{name: "absolute key stays inside root", key: "/etc/passwd", want: "etc/passwd"},
+		{name: "parent escape", key: "../secret", wantErr: true},
+		{name: "nested parent escape", key: "services/../../secret", wantErr: true},
+		{name: "root itself", key: "", wantErr: true},
+		{name: "dot", key: ".", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.path(tt.key)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(s.root, filepath.FromSlash(tt.want)), got)
+		})
+	}
+}
+
+func TestLocalStorageVerify(t *testing.T) {
+	s := newTestLocalStorage(t, "key")
+	future := time.Now().Add(time.Hour).Unix()
+	past := time.Now().Add(-time.Minute).Unix()
+	signature := s.sign("services/a.pdf", "a.pdf", future)
+
+	tests := []struct {
+		name      string
+		storage   *LocalStorage
+		key       string
+		filename  string
+		expires   int64
+		signature string
+		wantErr   bool
+	}{
+		{name: "valid", storage: s, key: "services/a.pdf", filename: "a.pdf", expires: future, signature: signature},
+		{name: "expired", storage: s, key: "services/a.pdf", filename: "a.pdf", expires: past, signature: s.sign("services/a.pdf", "a.pdf", past), wantErr: true},
+		{name: "extended expiry", storage: s, key: "services/a.pdf", filename: "a.pdf", expires: future + 60, signature: signature, wantErr: true},
+		{name: "other key", storage: s, key: "services/b.pdf", filename: "a.pdf", expires: future, signature: signature, wantErr: true},
+		{name: "other filename", storage: s, key: "services/a.pdf", filename: "b.pdf", expires: future, signature: signature, wantErr: true},
+		{name: "tampered signature", storage: s, key: "services/a.pdf", filename: "a.pdf", expires: future, signature: strings.Repeat("0", len(signature)), wantErr: true},
+		{name: "empty signature", storage: s, key: "services/a.pdf", filename: "a.pdf", expires: future, signature: "", wantErr: true},
+		{name: "other signing key", storage: newTestLocalStorage(t, "other"), key: "services/a.pdf", filename: "a.pdf", expires: future, signature: signature, wantErr: true},
+		// the separators keep the boundary between key and filename from moving
+		{name: "shifted boundary", storage: s, key: "services/a.pdfa", filename: ".pdf", expires: future, signature: signature, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.storage.Verify(tt.key, tt.filename, tt.expires, tt.signature)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSignature)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLocalStorageSignedURL(t *testing.T) {
+	s := newTestLocalStorage(t, "key")
+
+	signed, err := s.SignedURL(context.Background(), "services/a b.pdf", "a b.pdf", time.Minute)
+	require.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "localhost:8080", u.Host)
+	assert.Equal(t, LocalDownloadPath, u.Path)
+
+	query := u.Query()
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	require.NoError(t, err)
+	assert.NoError(t, s.Verify(query.Get("key"), query.Get("filename"), expires, query.Get("signature")))
+}
+
+func TestLocalStorageRoundTrip(t *testing.T) {
+	s := newTestLocalStorage(t, "key")
+	ctx := context.Background()
+
+	require.NoError(t, s.Put(ctx, "services/a.txt", strings.NewReader("hello"), 5, "text/plain"))
+
+	obj, err := s.Get(ctx, "services/a.txt")
+	require.NoError(t, err)
+	body, err := io.ReadAll(obj.Body)
+	require.NoError(t, obj.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+	assert.Equal(t, int64(5), obj.Size)
+	assert.Equal(t, "text/plain; charset=utf-8", obj.ContentType)
+
+	require.NoError(t, s.Delete(ctx, "services/a.txt"))
+	require.NoError(t, s.Delete(ctx, "services/a.txt"), "deleting a missing key is not an error")
+
+	_, err = s.Get(ctx, "services/a.txt")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+}
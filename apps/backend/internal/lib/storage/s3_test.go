@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mukundaparajuli/fixr/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+const (
+	minioUser     = "minioadmin"
+	minioPassword = "minioadmin"
+)
+
+// setupMinIO starts a MinIO container as the S3 stand-in and returns its endpoint
+func setupMinIO(t *testing.T) string {
+	t.Helper()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "minio/minio:latest",
+			ExposedPorts: []string{"9000/tcp"},
+			Env: map[string]string{
+				"MINIO_ROOT_USER":     minioUser,
+				"MINIO_ROOT_PASSWORD": minioPassword,
+			},
+			Cmd:        []string{"server", "/data"},
+			WaitingFor: wait.ForHTTP("/minio/health/live").WithPort("9000/tcp").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	require.NoError(t, err, "failed to start minio container")
+
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate minio container: %v", err)
+		}
+	})
+
+	endpoint, err := container.PortEndpoint(ctx, "9000/tcp", "")
+	require.NoError(t, err, "failed to get minio endpoint")
+	return endpoint
+}
+
+func TestS3Storage(t *testing.T) {
+	endpoint := setupMinIO(t)
+	ctx := context.Background()
+
+	s, err := NewS3Storage(ctx, config.S3StorageConfig{
+		Endpoint:        endpoint,
+		Region:          "us-east-1",
+		Bucket:          "fixr-test",
+		AccessKeyID:     minioUser,
+		SecretAccessKey: minioPassword,
+	})
+	require.NoError(t, err, "the bucket is created on first use")
+
+	require.NoError(t, s.Put(ctx, "services/a.txt", strings.NewReader("hello"), 5, "text/plain"))
+
+	obj, err := s.Get(ctx, "services/a.txt")
+	require.NoError(t, err)
+	body, err := io.ReadAll(obj.Body)
+	require.NoError(t, obj.Body.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(body))
+	assert.Equal(t, int64(5), obj.Size)
+	assert.Equal(t, "text/plain", obj.ContentType)
+
+	signed, err := s.SignedURL(ctx, "services/a.txt", "a.txt", time.Minute)
+	require.NoError(t, err)
+	resp, err := http.Get(signed)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `attachment; filename="a.txt"`, resp.Header.Get("Content-Disposition"))
+
+	require.NoError(t, s.Delete(ctx, "services/a.txt"))
+	_, err = s.Get(ctx, "services/a.txt")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mukundaparajuli/fixr/internal/config"
+)
+
+// ErrObjectNotFound is returned when the requested key does not exist in the store
+var ErrObjectNotFound = errors.New("storage: object not found")
+
+// Object describes a stored blob returned by Get
+type Object struct {
+	Body        io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+// Storage is the abstraction every blob store backend implements
+type Storage interface {
+	// Put stores the contents of body under key
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key. The caller must close Object.Body
+	Get(ctx context.Context, key string) (*Object, error)
+	// Delete removes the object stored under key. Deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that grants read access to key until expiry elapses
+	SignedURL(ctx context.Context, key string, filename string, expiry time.Duration) (string, error)
+}
+
+// New creates the storage backend selected by cfg.Driver
+func New(ctx context.Context, cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "local":
+		return NewLocalStorage(cfg.Local)
+	case "s3":
+		return NewS3Storage(ctx, cfg.S3)
+	default:
+		return nil, fmt.Errorf("unsupported storage driver: %s", cfg.Driver)
+	}
+}
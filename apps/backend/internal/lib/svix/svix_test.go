@@ -0,0 +1,195 @@
+package svix
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+
+func sign(t *testing.T, secret string, id string, timestamp time.Time, body string) string {
+	t.Helper()
+
+	key, err := base64.StdEncoding.DecodeString(secret[len(secretPrefix):])
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + strconv.FormatInt(timestamp.Unix(), 10) + "." + body))
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func headers(id string, timestamp time.Time, signature string) http.Header {
+	header := http.Header{}
+	if id != "" {
+		header.Set(HeaderID, id)
+	}
+	if !timestamp.IsZero() {
+		header.Set(HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	}
+	if signature != "" {
+		header.Set(HeaderSignature, signature)
+	}
+	return header
+}
+
+func TestVerifyKnownSignature(t *testing.T) {
+	// the example from the Svix documentation
+	verifier, err := NewVerifier(testSecret)
+	require.NoError(t, err)
+
+	header := http.Header{}
+	header.Set(HeaderID, "msg_p5jXN8AQM9LWM0D4loKWxJek")
+	header.Set(HeaderTimestamp, "1614265330")
+	header.Set(HeaderSignature, "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=")
+
+	assert.NoError(t, verifier.Verify(header, []byte(`{"test": 2432232314}`), time.Unix(1614265330, 0)))
+}
+
+func TestVerify(t *testing.T) {
+	verifier, err := NewVerifier(testSecret)
+	require.NoError(t, err)
+
+	const id = "msg_1"
+	const body = `{"type":"user.created"}`
+	now := time.Unix(1_700_000_000, 0)
+	otherSecret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("another signing secret"))
+
+	tests := []struct {
+		name    string
+		header  http.Header
+		body    string
+		wantErr error
+	}{
+		{
+			name:   "valid signature",
+			header: headers(id, now, sign(t, testSecret, id, now, body)),
+			body:   body,
+		},
+		{
+			name:   "one of several signatures matches",
+			header: headers(id, now, sign(t, otherSecret, id, now, body)+" v2,ignored "+sign(t, testSecret, id, now, body)),
+			body:   body,
+		},
+		{
+			name:   "timestamp within the tolerance",
+			header: headers(id, now.Add(-Tolerance+time.Second), sign(t, testSecret, id, now.Add(-Tolerance+time.Second), body)),
+			body:   body,
+		},
+		{
+			name:    "signed with another secret",
+			header:  headers(id, now, sign(t, otherSecret, id, now, body)),
+			body:    body,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "tampered body",
+			header:  headers(id, now, sign(t, testSecret, id, now, body)),
+			body:    `{"type":"user.deleted"}`,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "tampered id",
+			header:  headers("msg_2", now, sign(t, testSecret, id, now, body)),
+			body:    body,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "unsupported version",
+			header:  headers(id, now, "v2"+sign(t, testSecret, id, now, body)[2:]),
+			body:    body,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "malformed signature",
+			header:  headers(id, now, "v1,not base64!"),
+			body:    body,
+			wantErr: ErrInvalidSignature,
+		},
+		{
+			name:    "expired timestamp",
+			header:  headers(id, now.Add(-Tolerance-time.Second), sign(t, testSecret, id, now.Add(-Tolerance-time.Second), body)),
+			body:    body,
+			wantErr: ErrInvalidTimestamp,
+		},
+		{
+			name:    "future timestamp",
+			header:  headers(id, now.Add(Tolerance+time.Second), sign(t, testSecret, id, now.Add(Tolerance+time.Second), body)),
+			body:    body,
+			wantErr: ErrInvalidTimestamp,
+		},
+		{
+			name: "non numeric timestamp",
+			header: http.Header{
+				http.CanonicalHeaderKey(HeaderID):        {id},
+				http.CanonicalHeaderKey(HeaderTimestamp): {"yesterday"},
+				http.CanonicalHeaderKey(HeaderSignature): {sign(t, testSecret, id, now, body)},
+			},
+			body:    body,
+			wantErr: ErrInvalidTimestamp,
+		},
+		{
+			name:    "missing id",
+			header:  headers("", now, sign(t, testSecret, id, now, body)),
+			body:    body,
+			wantErr: ErrMissingHeaders,
+		},
+		{
+			name:    "missing timestamp",
+			header:  headers(id, time.Time{}, sign(t, testSecret, id, now, body)),
+			body:    body,
+			wantErr: ErrMissingHeaders,
+		},
+		{
+			name:    "missing signature",
+			header:  headers(id, now, ""),
+			body:    body,
+			wantErr: ErrMissingHeaders,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifier.Verify(tt.header, []byte(tt.body), now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewVerifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		wantErr bool
+	}{
+		{name: "with prefix", secret: testSecret},
+		{name: "without prefix", secret: testSecret[len(secretPrefix):]},
+		{name: "empty", secret: "", wantErr: true},
+		{name: "prefix only", secret: secretPrefix, wantErr: true},
+		{name: "not base64", secret: "whsec_not base64!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := NewVerifier(tt.secret)
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, verifier)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, verifier)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/mukundaparajuli/fixr/internal/model/apikey"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+)
+
+// APIKeyAuthenticator accepts "Authorization: Bearer fx_..." API keys. A key acts for the account
+// that owns it, as its creator, with its scopes as permissions.
+type APIKeyAuthenticator struct {
+	apiKeyRepo *repository.APIKeyRepository
+}
+
+func NewAPIKeyAuthenticator(apiKeyRepo *repository.APIKeyRepository) *APIKeyAuthenticator {
+	return &APIKeyAuthenticator{apiKeyRepo: apiKeyRepo}
+}
+
+func (a *APIKeyAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := bearerToken(r)
+	if !apikey.IsKey(token) {
+		return nil, ErrMissingToken
+	}
+
+	key, err := a.apiKeyRepo.GetActiveAPIKeyByHash(r.Context(), apikey.Hash(token))
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate api key: %w", err)
+	}
+
+	permissions := make([]string, 0, len(key.Scopes))
+	for _, scope := range key.Scopes {
+		permissions = append(permissions, "org:"+scope)
+	}
+
+	identity := &Identity{
+		UserID:      key.CreatedBy,
+		Permissions: permissions,
+		APIKeyID:    key.ID.String(),
+	}
+
+	// keys of an organization act within it; personal keys are owned by their creator
+	if key.UserID != key.CreatedBy {
+		identity.OrgID = key.UserID
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,156 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+	"github.com/clerk/clerk-sdk-go/v2/jwt"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/config"
+)
+
+// ErrMissingToken is returned by an Authenticator when the request carries no bearer token
+var ErrMissingToken = errors.New("auth: missing bearer token")
+
+// Authenticator verifies the bearer token of a request and returns the caller it identifies
+type Authenticator interface {
+	Authenticate(r *http.Request) (*Identity, error)
+}
+
+// NewAuthenticator creates the authenticator selected by cfg.Provider
+func NewAuthenticator(cfg config.AuthConfig) Authenticator {
+	switch cfg.Provider {
+	case config.AuthProviderJWKS:
+		return NewJWKSAuthenticator(cfg.JWKS)
+	default:
+		return NewClerkAuthenticator(cfg.SecretKey)
+	}
+}
+
+// bearerToken returns the token of an "Authorization: Bearer <token>" header
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get(echo.HeaderAuthorization)
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// clerkKeyTTL is how long the Clerk key set is trusted before it is fetched again
+const clerkKeyTTL = time.Hour
+
+// ClerkAuthenticator verifies Clerk session tokens. It holds its own Clerk client instead of
+// relying on the SDK's package-level secret key.
+type ClerkAuthenticator struct {
+	jwksClient *jwks.Client
+
+	// fetchMu serializes key set fetches; mu guards the cache and is never held across a fetch,
+	// so tokens signed with a cached key are verified while a fetch is in flight
+	fetchMu     sync.Mutex
+	mu          sync.Mutex
+	keys        map[string]*clerk.JSONWebKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+func NewClerkAuthenticator(secretKey string) *ClerkAuthenticator {
+	return &ClerkAuthenticator{
+		jwksClient: jwks.NewClient(&clerk.ClientConfig{
+			BackendConfig: clerk.BackendConfig{Key: clerk.String(secretKey)},
+		}),
+		keys: map[string]*clerk.JSONWebKey{},
+	}
+}
+
+func (a *ClerkAuthenticator) Authenticate(r *http.Request) (*Identity, error) {
+	token := bearerToken(r)
+	if token == "" {
+		return nil, ErrMissingToken
+	}
+
+	decoded, err := jwt.Decode(r.Context(), &jwt.DecodeParams{Token: token})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode session token: %w", err)
+	}
+
+	key, err := a.signingKey(r.Context(), decoded.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := jwt.Verify(r.Context(), &jwt.VerifyParams{Token: token, JWK: key})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify session token: %w", err)
+	}
+
+	return &Identity{
+		UserID:      claims.Subject,
+		OrgID:       claims.ActiveOrganizationID,
+		Role:        claims.ActiveOrganizationRole,
+		Permissions: claims.ActiveOrganizationPermissions,
+	}, nil
+}
+
+// signingKey returns the Clerk key with the given ID from the cached key set. The set is fetched
+// again once it expires, or early when a token names a key it does not contain, which is how
+// rotated keys are picked up. Fetches happen at most once per jwksMinRefresh, so tokens with made-up
+// key IDs cannot make every request call Clerk; while Clerk is unreachable the expired set is used.
+func (a *ClerkAuthenticator) signingKey(ctx context.Context, keyID string) (*clerk.JSONWebKey, error) {
+	key, refresh := a.cachedKey(keyID)
+	if refresh {
+		a.fetchMu.Lock()
+		defer a.fetchMu.Unlock()
+
+		// another request may have fetched the set while this one waited
+		if key, refresh = a.cachedKey(keyID); refresh {
+			a.mu.Lock()
+			a.attemptedAt = time.Now()
+			a.mu.Unlock()
+
+			set, err := a.jwksClient.Get(ctx, &jwks.GetParams{})
+			if err != nil && key == nil {
+				return nil, fmt.Errorf("failed to fetch clerk key set for kid=%s: %w", keyID, err)
+			}
+
+			if err == nil {
+				keys := make(map[string]*clerk.JSONWebKey, len(set.Keys))
+				for _, k := range set.Keys {
+					if k != nil {
+						keys[k.KeyID] = k
+					}
+				}
+
+				a.mu.Lock()
+				a.keys, a.fetchedAt = keys, time.Now()
+				a.mu.Unlock()
+
+				key = keys[keyID]
+			}
+		}
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("no clerk signing key kid=%s", keyID)
+	}
+	return key, nil
+}
+
+// cachedKey looks the key up in the cached set and reports whether the set is due to be fetched
+func (a *ClerkAuthenticator) cachedKey(keyID string) (*clerk.JSONWebKey, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	key := a.keys[keyID]
+	if time.Since(a.attemptedAt) < jwksMinRefresh {
+		return key, false
+	}
+	return key, key == nil || time.Since(a.fetchedAt) > clerkKeyTTL
+}
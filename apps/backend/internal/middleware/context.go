@@ -11,9 +11,13 @@ import (
 )
 
 const (
-	UserIDKey   = "user_id"
-	UserRoleKey = "user_role"
-	LoggerKey   = "logger"
+	UserIDKey      = "user_id"
+	UserRoleKey    = "user_role"
+	OrgIDKey       = "org_id"
+	PermissionsKey = "permissions"
+	APIKeyIDKey    = "api_key_id"
+	APIKeyScopeKey = "api_key_scope"
+	LoggerKey      = "logger"
 )
 
 type ContextEnhancer struct {
@@ -87,6 +91,49 @@ func GetUserID(c echo.Context) string {
 	return ""
 }
 
+// GetOrgID returns the caller's active organization, or "" when they act on their personal account
+func GetOrgID(c echo.Context) string {
+	if orgID, ok := c.Get(OrgIDKey).(string); ok {
+		return orgID
+	}
+	return ""
+}
+
+// GetOwnerID returns the account whose data a request works on: the active organization when
+// there is one, otherwise the caller. Provider-owned rows keyed by user_id (services, categories,
+// availability, storefronts, ...) store this owner, so the column holds either a Clerk organization
+// id or a user id (see migration 023) and an organization's catalog is shared by all of its members.
+// Use it only for provider-side data; a customer booking or reviewing acts as themselves, see GetUserID.
+func GetOwnerID(c echo.Context) string {
+	if orgID := GetOrgID(c); orgID != "" {
+		return orgID
+	}
+	return GetUserID(c)
+}
+
+func GetUserRole(c echo.Context) string {
+	if userRole, ok := c.Get(UserRoleKey).(string); ok {
+		return userRole
+	}
+	return ""
+}
+
+// GetPermissions returns the caller's Clerk organization permissions, e.g. "org:services:write"
+func GetPermissions(c echo.Context) []string {
+	if permissions, ok := c.Get(PermissionsKey).([]string); ok {
+		return permissions
+	}
+	return nil
+}
+
+// GetAPIKeyID returns the API key the caller authenticated with, or "" for a session
+func GetAPIKeyID(c echo.Context) string {
+	if keyID, ok := c.Get(APIKeyIDKey).(string); ok {
+		return keyID
+	}
+	return ""
+}
+
 func GetLogger(c echo.Context) *zerolog.Logger {
 	if logger, ok := c.Get(LoggerKey).(*zerolog.Logger); ok {
 		return logger
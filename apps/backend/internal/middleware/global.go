@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -28,6 +29,16 @@ func (global *GlobalMiddlewares) CORS() echo.MiddlewareFunc {
 	})
 }
 
+// multipartOverhead leaves room for the boundaries and part headers around an uploaded file
+const multipartOverhead = 64 << 10
+
+// UploadBodyLimit rejects uploads larger than storage.max_upload_size while they are read, before
+// the multipart form is parsed; the services only see the file size once the whole body is in
+func (global *GlobalMiddlewares) UploadBodyLimit() echo.MiddlewareFunc {
+	limit := global.server.Config.Storage.MaxUploadSize + multipartOverhead
+	return middleware.BodyLimit(strconv.FormatInt(limit, 10))
+}
+
 func (global *GlobalMiddlewares) RequestLogger() echo.MiddlewareFunc {
 	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
 		LogURI:     true,
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+)
+
+// Role is a Clerk organization role, as found in the session's org_role claim
+type Role string
+
+const (
+	RoleOwner  Role = "org:owner"
+	RoleAdmin  Role = "org:admin"
+	RoleMember Role = "org:member"
+	RoleViewer Role = "org:viewer"
+)
+
+// roleRanks orders the roles; a role may do everything the roles below it can.
+// Roles not listed here (e.g. custom roles) rank below viewer and are refused by RequireRole.
+var roleRanks = map[Role]int{
+	RoleViewer: 1,
+	RoleMember: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+// HasRole reports whether the caller holds at least the given role in their active organization.
+// Callers without an active organization work on their own account and hold every role; API keys
+// hold no role and are limited to their scopes.
+func HasRole(c echo.Context, role Role) bool {
+	if GetAPIKeyID(c) != "" {
+		return false
+	}
+
+	if GetOrgID(c) == "" {
+		return true
+	}
+	return roleRanks[Role(GetUserRole(c))] >= roleRanks[role]
+}
+
+// RequireRole refuses requests from organization members below the given role. It reads the
+// claims stored by RequireAuth, so it must be registered after it.
+func (auth *AuthMiddleware) RequireRole(role Role) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !HasRole(c, role) {
+				GetLogger(c).Warn().
+					Str("function", "RequireRole").
+					Str("request_id", GetRequestID(c)).
+					Str("org_id", GetOrgID(c)).
+					Str("user_role", GetUserRole(c)).
+					Str("required_role", string(role)).
+					Msg("organization role too low")
+				return errs.NewForbiddenError("You do not have permission to perform this action", false)
+			}
+
+			return next(c)
+		}
+	}
+}
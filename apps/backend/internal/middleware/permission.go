@@ -0,0 +1,177 @@
+package middleware
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+)
+
+// Permission is an action on a resource, written "resource:action". In Clerk it is configured as
+// the custom organization permission "org:resource:action".
+type Permission string
+
+const (
+	PermServicesRead      Permission = "services:read"
+	PermServicesWrite     Permission = "services:write"
+	PermServicesDelete    Permission = "services:delete"
+	PermServicesImport    Permission = "services:import"
+	PermCategoriesRead    Permission = "categories:read"
+	PermCategoriesWrite   Permission = "categories:write"
+	PermCategoriesDelete  Permission = "categories:delete"
+	PermAttachmentsWrite  Permission = "attachments:write"
+	PermAttachmentsDelete Permission = "attachments:delete"
+	PermTrashRead         Permission = "trash:read"
+	PermAuditRead         Permission = "audit:read"
+	PermBookingsWrite     Permission = "bookings:write"
+	PermQuotesWrite       Permission = "quotes:write"
+	PermInvoicesWrite     Permission = "invoices:write"
+	PermReviewsReply      Permission = "reviews:reply"
+	PermAvailabilityWrite Permission = "availability:write"
+	PermServiceAreasWrite Permission = "service_areas:write"
+	PermStorefrontWrite   Permission = "storefront:write"
+	PermAPIKeysManage     Permission = "api_keys:manage"
+	PermWebhooksManage    Permission = "webhooks:manage"
+)
+
+// permissionRegistry lists every permission a route may require, with the lowest built-in role
+// that holds it. Organizations that only use the built-in roles need no Clerk permission setup;
+// custom roles get exactly the permissions assigned to them in Clerk.
+var permissionRegistry = map[Permission]Role{
+	PermServicesRead:      RoleViewer,
+	PermServicesWrite:     RoleMember,
+	PermServicesDelete:    RoleAdmin,
+	PermServicesImport:    RoleAdmin,
+	PermCategoriesRead:    RoleViewer,
+	PermCategoriesWrite:   RoleMember,
+	PermCategoriesDelete:  RoleAdmin,
+	PermAttachmentsWrite:  RoleMember,
+	PermAttachmentsDelete: RoleAdmin,
+	PermTrashRead:         RoleAdmin,
+	PermAuditRead:         RoleAdmin,
+	PermBookingsWrite:     RoleMember,
+	PermQuotesWrite:       RoleMember,
+	PermInvoicesWrite:     RoleMember,
+	PermReviewsReply:      RoleMember,
+	PermAvailabilityWrite: RoleMember,
+	PermServiceAreasWrite: RoleMember,
+	PermStorefrontWrite:   RoleAdmin,
+	PermAPIKeysManage:     RoleAdmin,
+	PermWebhooksManage:    RoleAdmin,
+}
+
+// IsRegistered reports whether the permission is in the registry
+func IsRegistered(permission Permission) bool {
+	_, ok := permissionRegistry[permission]
+	return ok
+}
+
+// Permissions returns every registered permission
+func Permissions() []Permission {
+	permissions := make([]Permission, 0, len(permissionRegistry))
+	for permission := range permissionRegistry {
+		permissions = append(permissions, permission)
+	}
+	slices.Sort(permissions)
+	return permissions
+}
+
+// HasPermission reports whether the caller may perform the action. API keys hold exactly the
+// scopes they were created with. Otherwise, callers without an active organization hold every
+// permission on their own account.
+func HasPermission(c echo.Context, permission Permission) bool {
+	if GetAPIKeyID(c) != "" {
+		return slices.Contains(GetPermissions(c), "org:"+string(permission))
+	}
+
+	if GetOrgID(c) == "" {
+		return true
+	}
+
+	if slices.Contains(GetPermissions(c), "org:"+string(permission)) {
+		return true
+	}
+
+	role, ok := permissionRegistry[permission]
+	return ok && HasRole(c, role)
+}
+
+// RequirePermission refuses requests from callers without the given permission. It reads the
+// claims stored by RequireAuth, so it must be registered after it. Requiring a permission missing
+// from the registry is a programming error and panics when the routes are registered.
+func (auth *AuthMiddleware) RequirePermission(permission Permission) echo.MiddlewareFunc {
+	if _, ok := permissionRegistry[permission]; !ok {
+		panic(fmt.Sprintf("permission %q is not registered", permission))
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !HasPermission(c, permission) {
+				GetLogger(c).Warn().
+					Str("function", "RequirePermission").
+					Str("request_id", GetRequestID(c)).
+					Str("user_id", GetUserID(c)).
+					Str("org_id", GetOrgID(c)).
+					Str("user_role", GetUserRole(c)).
+					Str("permission", string(permission)).
+					Msg("permission denied")
+				return errs.NewForbiddenError("You do not have permission to perform this action", false)
+			}
+
+			c.Set(APIKeyScopeKey, permission)
+			return next(c)
+		}
+	}
+}
+
+// RequireAPIKeyScope lets API keys holding the given scope reach a route that sessions may use
+// without a permission, such as reading the catalog. Sessions pass through unchecked.
+func (auth *AuthMiddleware) RequireAPIKeyScope(permission Permission) echo.MiddlewareFunc {
+	if _, ok := permissionRegistry[permission]; !ok {
+		panic(fmt.Sprintf("permission %q is not registered", permission))
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if GetAPIKeyID(c) == "" {
+				return next(c)
+			}
+
+			if !HasPermission(c, permission) {
+				GetLogger(c).Warn().
+					Str("function", "RequireAPIKeyScope").
+					Str("request_id", GetRequestID(c)).
+					Str("user_id", GetUserID(c)).
+					Str("api_key_id", GetAPIKeyID(c)).
+					Str("permission", string(permission)).
+					Msg("api key scope missing")
+				return errs.NewForbiddenError("You do not have permission to perform this action", false)
+			}
+
+			c.Set(APIKeyScopeKey, permission)
+			return next(c)
+		}
+	}
+}
+
+// RequireScope refuses API-key callers on routes that declare no permission. A key acts as the
+// user who created it, so it may only reach routes whose permission RequirePermission or
+// RequireAPIKeyScope has checked against its scopes. Sessions are not affected.
+func RequireScope(c echo.Context) error {
+	if GetAPIKeyID(c) == "" {
+		return nil
+	}
+
+	if _, ok := c.Get(APIKeyScopeKey).(Permission); ok {
+		return nil
+	}
+
+	GetLogger(c).Warn().
+		Str("function", "RequireScope").
+		Str("request_id", GetRequestID(c)).
+		Str("user_id", GetUserID(c)).
+		Str("api_key_id", GetAPIKeyID(c)).
+		Msg("api key used on a route without a permission")
+	return errs.NewForbiddenError("API keys cannot be used for this action", false)
+}
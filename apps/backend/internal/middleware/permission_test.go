@@ -0,0 +1,320 @@
+package middleware_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/config"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	testhelpers "github.com/mukundaparajuli/fixr/internal/testing"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var permissionCases = []struct {
+	name       string
+	identity   middleware.Identity
+	permission middleware.Permission
+	want       bool
+}{
+	{
+		name:       "personal account holds every permission",
+		identity:   middleware.Identity{UserID: "user_1"},
+		permission: middleware.PermServicesDelete,
+		want:       true,
+	},
+	{
+		name:       "member holds member permissions",
+		identity:   middleware.Identity{UserID: "user_1", OrgID: "org_1", Role: string(middleware.RoleMember)},
+		permission: middleware.PermServicesWrite,
+		want:       true,
+	},
+	{
+		name:       "member lacks admin permissions",
+		identity:   middleware.Identity{UserID: "user_1", OrgID: "org_1", Role: string(middleware.RoleMember)},
+		permission: middleware.PermServicesDelete,
+		want:       false,
+	},
+	{
+		name:       "admin holds admin permissions",
+		identity:   middleware.Identity{UserID: "user_1", OrgID: "org_1", Role: string(middleware.RoleAdmin)},
+		permission: middleware.PermServicesDelete,
+		want:       true,
+	},
+	{
+		name:       "owner holds admin permissions",
+		identity:   middleware.Identity{UserID: "user_1", OrgID: "org_1", Role: string(middleware.RoleOwner)},
+		permission: middleware.PermWebhooksManage,
+		want:       true,
+	},
+	{
+		name:       "viewer lacks member permissions",
+		identity:   middleware.Identity{UserID: "user_1", OrgID: "org_1", Role: string(middleware.RoleViewer)},
+		permission: middleware.PermServicesWrite,
+		want:       false,
+	},
+	{
+		name:       "member may reply to reviews",
+		identity:   middleware.Identity{UserID: "user_1", OrgID: "org_1", Role: string(middleware.RoleMember)},
+		permission: middleware.PermReviewsReply,
+		want:       true,
+	},
+	{
+		name:       "viewer may not reply to reviews",
+		identity:   middleware.Identity{UserID: "user_1", OrgID: "org_1", Role: string(middleware.RoleViewer)},
+		permission: middleware.PermReviewsReply,
+		want:       false,
+	},
+	{
+		name: "custom role holds its assigned permissions",
+		identity: middleware.Identity{
+			UserID:      "user_1",
+			OrgID:       "org_1",
+			Role:        "org:dispatcher",
+			Permissions: []string{"org:services:delete"},
+		},
+		permission: middleware.PermServicesDelete,
+		want:       true,
+	},
+	{
+		name: "custom role lacks unassigned permissions",
+		identity: middleware.Identity{
+			UserID:      "user_1",
+			OrgID:       "org_1",
+			Role:        "org:dispatcher",
+			Permissions: []string{"org:services:delete"},
+		},
+		permission: middleware.PermServicesWrite,
+		want:       false,
+	},
+	{
+		name: "api key holds its scopes",
+		identity: middleware.Identity{
+			UserID:      "user_1",
+			OrgID:       "org_1",
+			Permissions: []string{"org:services:write"},
+			APIKeyID:    "key_1",
+		},
+		permission: middleware.PermServicesWrite,
+		want:       true,
+	},
+	{
+		name: "api key lacks other scopes",
+		identity: middleware.Identity{
+			UserID:      "user_1",
+			OrgID:       "org_1",
+			Permissions: []string{"org:services:write"},
+			APIKeyID:    "key_1",
+		},
+		permission: middleware.PermServicesDelete,
+		want:       false,
+	},
+	{
+		name: "personal api key is limited to its scopes",
+		identity: middleware.Identity{
+			UserID:      "user_1",
+			Permissions: []string{"org:services:write"},
+			APIKeyID:    "key_1",
+		},
+		permission: middleware.PermServicesDelete,
+		want:       false,
+	},
+}
+
+func TestHasPermission(t *testing.T) {
+	e := echo.New()
+
+	for _, tt := range permissionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testhelpers.NewAuthContext(e, http.MethodGet, "/", nil, tt.identity)
+			assert.Equal(t, tt.want, middleware.HasPermission(c, tt.permission))
+		})
+	}
+}
+
+func newTestServer() *server.Server {
+	logger := zerolog.Nop()
+	return &server.Server{
+		Logger: &logger,
+		Config: &config.Config{Auth: config.AuthConfig{Provider: config.AuthProviderClerk, SecretKey: "sk_test"}},
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	auth := testhelpers.NewFakeAuthMiddleware(newTestServer())
+	e := echo.New()
+
+	for _, tt := range permissionCases {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testhelpers.WithFakeClaims(httptest.NewRequest(http.MethodPost, "/", nil), tt.identity)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			called := false
+			handler := auth.RequireAuth(auth.RequirePermission(tt.permission)(func(c echo.Context) error {
+				called = true
+				assert.Equal(t, tt.identity.UserID, middleware.GetUserID(c))
+				return c.NoContent(http.StatusNoContent)
+			}))
+
+			err := handler(c)
+			assert.Equal(t, tt.want, called)
+			if tt.want {
+				require.NoError(t, err)
+				assert.Equal(t, http.StatusNoContent, rec.Code)
+				return
+			}
+
+			var httpErr *errs.HTTPError
+			require.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, http.StatusForbidden, httpErr.Status)
+		})
+	}
+}
+
+func TestRequireAuthRejects(t *testing.T) {
+	e := echo.New()
+	fakeRequest := testhelpers.WithFakeClaims(httptest.NewRequest(http.MethodGet, "/", nil), middleware.Identity{UserID: "user_1"})
+
+	tests := []struct {
+		name string
+		auth *middleware.AuthMiddleware
+		req  *http.Request
+	}{
+		{
+			name: "missing token",
+			auth: testhelpers.NewFakeAuthMiddleware(newTestServer()),
+			req:  httptest.NewRequest(http.MethodGet, "/", nil),
+		},
+		{
+			name: "fake token without the fake authenticator",
+			auth: middleware.NewAuthMiddleware(newTestServer()),
+			req:  fakeRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := e.NewContext(tt.req, httptest.NewRecorder())
+
+			err := tt.auth.RequireAuth(func(c echo.Context) error {
+				t.Fatal("handler must not run")
+				return nil
+			})(c)
+
+			var httpErr *errs.HTTPError
+			require.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, http.StatusUnauthorized, httpErr.Status)
+		})
+	}
+}
+
+func TestRequirePermissionPanicsOnUnregisteredPermission(t *testing.T) {
+	assert.Panics(t, func() {
+		(&middleware.AuthMiddleware{}).RequirePermission("services:teleport")
+	})
+}
+
+func TestHasRole(t *testing.T) {
+	e := echo.New()
+
+	tests := []struct {
+		name     string
+		identity middleware.Identity
+		role     middleware.Role
+		want     bool
+	}{
+		{name: "personal account", identity: middleware.Identity{UserID: "user_1"}, role: middleware.RoleOwner, want: true},
+		{name: "admin above member", identity: middleware.Identity{OrgID: "org_1", Role: string(middleware.RoleAdmin)}, role: middleware.RoleMember, want: true},
+		{name: "member below admin", identity: middleware.Identity{OrgID: "org_1", Role: string(middleware.RoleMember)}, role: middleware.RoleAdmin, want: false},
+		{name: "custom role below viewer", identity: middleware.Identity{OrgID: "org_1", Role: "org:dispatcher"}, role: middleware.RoleViewer, want: false},
+		{name: "api key holds no role", identity: middleware.Identity{UserID: "user_1", APIKeyID: "key_1"}, role: middleware.RoleViewer, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := testhelpers.NewAuthContext(e, http.MethodGet, "/", nil, tt.identity)
+			assert.Equal(t, tt.want, middleware.HasRole(c, tt.role))
+		})
+	}
+}
+
+func TestAPIKeysNeedADeclaredScope(t *testing.T) {
+	auth := testhelpers.NewFakeAuthMiddleware(newTestServer())
+	e := echo.New()
+
+	session := middleware.Identity{UserID: "user_1", OrgID: "org_1", Role: string(middleware.RoleViewer)}
+	readKey := middleware.Identity{UserID: "user_1", OrgID: "org_1", Permissions: []string{"org:services:read"}, APIKeyID: "key_1"}
+	writeKey := middleware.Identity{UserID: "user_1", OrgID: "org_1", Permissions: []string{"org:services:write"}, APIKeyID: "key_1"}
+
+	tests := []struct {
+		name       string
+		identity   middleware.Identity
+		middleware []echo.MiddlewareFunc
+		want       bool
+	}{
+		{name: "session on a route without a permission", identity: session, want: true},
+		{name: "api key on a route without a permission", identity: writeKey, want: false},
+		{
+			name:       "api key holding the route permission",
+			identity:   writeKey,
+			middleware: []echo.MiddlewareFunc{auth.RequirePermission(middleware.PermServicesWrite)},
+			want:       true,
+		},
+		{
+			name:       "session on a route with an api key scope",
+			identity:   session,
+			middleware: []echo.MiddlewareFunc{auth.RequireAPIKeyScope(middleware.PermServicesRead)},
+			want:       true,
+		},
+		{
+			name:       "api key holding the route scope",
+			identity:   readKey,
+			middleware: []echo.MiddlewareFunc{auth.RequireAPIKeyScope(middleware.PermServicesRead)},
+			want:       true,
+		},
+		{
+			name:       "api key lacking the route scope",
+			identity:   writeKey,
+			middleware: []echo.MiddlewareFunc{auth.RequireAPIKeyScope(middleware.PermServicesRead)},
+			want:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := testhelpers.WithFakeClaims(httptest.NewRequest(http.MethodGet, "/", nil), tt.identity)
+			c := e.NewContext(req, httptest.NewRecorder())
+
+			// RequireScope runs where the handlers start, after the route middleware
+			called := false
+			handler := func(c echo.Context) error {
+				if err := middleware.RequireScope(c); err != nil {
+					return err
+				}
+				called = true
+				return nil
+			}
+			for i := len(tt.middleware) - 1; i >= 0; i-- {
+				handler = tt.middleware[i](handler)
+			}
+
+			err := auth.RequireAuth(handler)(c)
+			assert.Equal(t, tt.want, called)
+			if tt.want {
+				require.NoError(t, err)
+				return
+			}
+
+			var httpErr *errs.HTTPError
+			require.True(t, errors.As(err, &httpErr))
+			assert.Equal(t, http.StatusForbidden, httpErr.Status)
+		})
+	}
+}
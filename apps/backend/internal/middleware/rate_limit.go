@@ -1,7 +1,12 @@
 package middleware
 
 import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/mukundaparajuli/fixr/internal/server"
+	"golang.org/x/time/rate"
 )
 
 type RateLimitMiddleware struct {
@@ -21,3 +26,28 @@ func (r *RateLimitMiddleware) RecordRateLimitHit(endpoint string) {
 		})
 	}
 }
+
+// Limit returns a per-IP rate limiter allowing limit requests per second with the given burst.
+// Each call gets its own store, so a route group limited this way is counted separately from the global limiter.
+func (r *RateLimitMiddleware) Limit(limit rate.Limit, burst int) echo.MiddlewareFunc {
+	return echoMiddleware.RateLimiterWithConfig(echoMiddleware.RateLimiterConfig{
+		Store: echoMiddleware.NewRateLimiterMemoryStoreWithConfig(echoMiddleware.RateLimiterMemoryStoreConfig{
+			Rate:  limit,
+			Burst: burst,
+		}),
+		DenyHandler: func(c echo.Context, identifier string, err error) error {
+			// Record rate limit hit metrics
+			r.RecordRateLimitHit(c.Path())
+
+			r.server.Logger.Warn().
+				Str("request_id", GetRequestID(c)).
+				Str("identifier", identifier).
+				Str("path", c.Path()).
+				Str("method", c.Request().Method).
+				Str("ip", c.RealIP()).
+				Msg("rate limit exceeded")
+
+			return echo.NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded")
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mukundaparajuli/fixr/internal/model"
+)
+
+// Prefix starts every API key, which is how RequireAuth tells keys apart from session tokens
+const Prefix = "fx_"
+
+// displayPrefixLength is how much of a key is kept in clear to identify it in listings
+const displayPrefixLength = len(Prefix) + 8
+
+type APIKey struct {
+	model.Base
+	UserID     string     `json:"userId" db:"user_id"`
+	CreatedBy  string     `json:"createdBy" db:"created_by"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	KeyHash    string     `json:"-" db:"key_hash"`
+	Scopes     []string   `json:"scopes" db:"scopes"`
+	LastUsedAt *time.Time `json:"lastUsedAt" db:"last_used_at"`
+	ExpiresAt  *time.Time `json:"expiresAt" db:"expires_at"`
+	RevokedAt  *time.Time `json:"revokedAt" db:"revoked_at"`
+}
+
+// CreatedAPIKey is returned once, when the key is created; the plain key is never stored
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+// Generate returns a new random key and the prefix stored to identify it
+func Generate() (key string, prefix string, err error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	key = Prefix + base64.RawURLEncoding.EncodeToString(secret)
+	return key, key[:displayPrefixLength], nil
+}
+
+// Hash returns the value stored for a key. Keys are random and long, so a plain SHA-256 suffices.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsKey reports whether a bearer token looks like an API key
+func IsKey(token string) bool {
+	return strings.HasPrefix(token, Prefix)
+}
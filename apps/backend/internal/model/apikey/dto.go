@@ -0,0 +1,57 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+// --------------------------------------------------------------------------
+
+// CreateAPIKeyPayload creates a key limited to the given permissions, e.g. "services:write".
+// Reading the owner's data needs no scope.
+type CreateAPIKeyPayload struct {
+	Name      string     `json:"name" validate:"required,min=1,max=100"`
+	Scopes    []string   `json:"scopes" validate:"max=50,dive,required"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+func (p *CreateAPIKeyPayload) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	if p.ExpiresAt != nil && !p.ExpiresAt.After(time.Now()) {
+		return validation.CustomValidationErrors{
+			{Field: "expiresAt", Message: "must be in the future"},
+		}
+	}
+
+	if p.Scopes == nil {
+		p.Scopes = []string{}
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type RevokeAPIKeyPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *RevokeAPIKeyPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetAPIKeysPayload struct{}
+
+func (p *GetAPIKeysPayload) Validate() error {
+	return nil
+}
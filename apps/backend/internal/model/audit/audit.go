@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model"
+)
+
+type EntityType string
+
+const (
+	EntityService    EntityType = "service"
+	EntityCategory   EntityType = "category"
+	EntityAttachment EntityType = "attachment"
+)
+
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionUpdated   Action = "updated"
+	ActionMoved     Action = "moved"
+	ActionReordered Action = "reordered"
+	ActionDeleted   Action = "deleted"
+	ActionRestored  Action = "restored"
+	ActionPurged    Action = "purged"
+	ActionReverted  Action = "reverted"
+)
+
+// Change is the value of one field before and after a mutation; creates have a nil Before and
+// purges a nil After
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Event records one mutation of a service, category or attachment. Events are append-only.
+type Event struct {
+	model.BaseWithId
+	model.BaseWithCreatedAt
+	UserID     string            `json:"userId" db:"user_id"`
+	ActorID    string            `json:"actorId" db:"actor_id"`
+	RequestID  *string           `json:"requestId" db:"request_id"`
+	EntityType EntityType        `json:"entityType" db:"entity_type"`
+	EntityID   uuid.UUID         `json:"entityId" db:"entity_id"`
+	Action     Action            `json:"action" db:"action"`
+	Changes    map[string]Change `json:"changes" db:"changes"`
+}
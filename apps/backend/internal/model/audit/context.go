@@ -0,0 +1,27 @@
+package audit
+
+import "context"
+
+// SystemActor is recorded for changes made outside a request, such as the trash purge job
+const SystemActor = "system"
+
+// Actor identifies who made a change and in which request
+type Actor struct {
+	ID        string
+	RequestID string
+}
+
+type actorKey struct{}
+
+// WithActor attaches the actor to a request context so repositories can attribute their writes
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor attached by WithActor, or SystemActor when there is none
+func ActorFromContext(ctx context.Context) Actor {
+	if actor, ok := ctx.Value(actorKey{}).(Actor); ok && actor.ID != "" {
+		return actor
+	}
+	return Actor{ID: SystemActor}
+}
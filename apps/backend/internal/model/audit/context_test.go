@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorFromContext(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want Actor
+	}{
+		{name: "no actor", ctx: context.Background(), want: Actor{ID: SystemActor}},
+		{name: "actor without an id", ctx: WithActor(context.Background(), Actor{RequestID: "req_1"}), want: Actor{ID: SystemActor}},
+		{name: "actor", ctx: WithActor(context.Background(), Actor{ID: "user_1", RequestID: "req_1"}), want: Actor{ID: "user_1", RequestID: "req_1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ActorFromContext(tt.ctx))
+		})
+	}
+}
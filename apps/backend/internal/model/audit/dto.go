@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// --------------------------------------------------------------------------
+
+type GetAuditEventsQuery struct {
+	Page       *int        `query:"page" validate:"omitempty,min=1"`
+	Limit      *int        `query:"limit" validate:"omitempty,min=1,max=100"`
+	Order      *string     `query:"order" validate:"omitempty,oneof=asc desc"`
+	EntityType *EntityType `query:"entityType" validate:"omitempty,oneof=service category attachment"`
+	EntityID   *uuid.UUID  `query:"entityId" validate:"omitempty,uuid"`
+	Action     *Action     `query:"action" validate:"omitempty,oneof=created updated moved reordered deleted restored purged reverted"`
+	ActorID    *string     `query:"actorId" validate:"omitempty,min=1"`
+	RequestID  *string     `query:"requestId" validate:"omitempty,min=1"`
+}
+
+func (q *GetAuditEventsQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+	if q.Order == nil {
+		defaultOrder := "desc"
+		q.Order = &defaultOrder
+	}
+
+	return nil
+}
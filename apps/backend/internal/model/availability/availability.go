@@ -0,0 +1,109 @@
+package availability
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mukundaparajuli/fixr/internal/model"
+)
+
+// TimeOfDay is a wall clock time stored as minutes since local midnight and exchanged as "HH:MM".
+// 24:00 is allowed so a window can run until the end of the day.
+type TimeOfDay int
+
+const endOfDay TimeOfDay = 24 * 60
+
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+
+	hours, errHours := strconv.Atoi(s[:2])
+	minutes, errMinutes := strconv.Atoi(s[3:])
+	if errHours != nil || errMinutes != nil || minutes < 0 || minutes > 59 {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+
+	t := TimeOfDay(hours*60 + minutes)
+	if t < 0 || t > endOfDay {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", s)
+	}
+
+	return t, nil
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
+
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	parsed, err := ParseTimeOfDay(s)
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
+
+// on returns the instant at which t occurs on the given calendar day in loc
+func (t TimeOfDay) on(day time.Time, loc *time.Location) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(t)/60, int(t)%60, 0, 0, loc)
+}
+
+type Settings struct {
+	model.BaseWithCreatedAt
+	model.BaseWithUpdatedAt
+	UserID   string `json:"userId" db:"user_id"`
+	Timezone string `json:"timezone" db:"timezone"`
+}
+
+type Rule struct {
+	model.Base
+	UserID      string       `json:"userId" db:"user_id"`
+	Weekday     time.Weekday `json:"weekday" db:"weekday"`
+	StartMinute TimeOfDay    `json:"startTime" db:"start_minute"`
+	EndMinute   TimeOfDay    `json:"endTime" db:"end_minute"`
+}
+
+type Override struct {
+	model.Base
+	UserID      string     `json:"userId" db:"user_id"`
+	Date        time.Time  `json:"date" db:"date"`
+	StartMinute *TimeOfDay `json:"startTime" db:"start_minute"`
+	EndMinute   *TimeOfDay `json:"endTime" db:"end_minute"`
+}
+
+// IsBlackout reports whether the override takes the whole day off
+func (o *Override) IsBlackout() bool {
+	return o.StartMinute == nil || o.EndMinute == nil
+}
+
+type Schedule struct {
+	Timezone  string     `json:"timezone"`
+	Rules     []Rule     `json:"rules"`
+	Overrides []Override `json:"overrides"`
+}
+
+type Slot struct {
+	StartsAt time.Time `json:"startsAt"`
+	EndsAt   time.Time `json:"endsAt"`
+}
+
+type ServiceSlots struct {
+	ServiceID       string `json:"serviceId"`
+	Timezone        string `json:"timezone"`
+	DurationMinutes int    `json:"durationMinutes"`
+	Slots           []Slot `json:"slots"`
+}
@@ -0,0 +1,99 @@
+package availability
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+// MaxSlotRangeDays bounds how many days a single slot lookup may cover
+const MaxSlotRangeDays = 31
+
+type WindowPayload struct {
+	StartTime TimeOfDay `json:"startTime"`
+	EndTime   TimeOfDay `json:"endTime" validate:"gtfield=StartTime"`
+}
+
+type RulePayload struct {
+	Weekday   time.Weekday `json:"weekday" validate:"min=0,max=6"`
+	StartTime TimeOfDay    `json:"startTime"`
+	EndTime   TimeOfDay    `json:"endTime" validate:"gtfield=StartTime"`
+}
+
+// --------------------------------------------------------------------------
+
+type UpdateScheduleRulesPayload struct {
+	Timezone string        `json:"timezone" validate:"required,timezone"`
+	Rules    []RulePayload `json:"rules" validate:"dive"`
+}
+
+func (p *UpdateScheduleRulesPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetSchedulePayload struct{}
+
+func (p *GetSchedulePayload) Validate() error {
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+// SetOverridePayload replaces every override on Date. An empty window list blacks the day out.
+type SetOverridePayload struct {
+	Date    string          `param:"date" validate:"required,datetime=2006-01-02"`
+	Windows []WindowPayload `json:"windows" validate:"dive"`
+}
+
+func (p *SetOverridePayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type DeleteOverridePayload struct {
+	Date string `param:"date" validate:"required,datetime=2006-01-02"`
+}
+
+func (p *DeleteOverridePayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetServiceSlotsQuery struct {
+	ServiceID uuid.UUID `param:"id" validate:"required,uuid"`
+	From      string    `query:"from" validate:"required,datetime=2006-01-02"`
+	To        string    `query:"to" validate:"required,datetime=2006-01-02"`
+}
+
+func (q *GetServiceSlotsQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	from, _ := time.Parse(time.DateOnly, q.From)
+	to, _ := time.Parse(time.DateOnly, q.To)
+
+	if to.Before(from) {
+		return validation.CustomValidationErrors{
+			{Field: "to", Message: "must not be before from"},
+		}
+	}
+
+	if to.Sub(from) >= MaxSlotRangeDays*24*time.Hour {
+		return validation.CustomValidationErrors{
+			{Field: "to", Message: "range must not exceed 31 days"},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package availability
+
+import (
+	"time"
+)
+
+type window struct {
+	start TimeOfDay
+	end   TimeOfDay
+}
+
+// ComputeSlots lays out back-to-back slots of the given duration inside the provider's open
+// windows for every calendar day in [from, to] (interpreted in loc). Date overrides replace
+// the weekly rules for their day, slots starting before now are dropped, and so is every
+// slot overlapping a busy interval.
+func ComputeSlots(
+	loc *time.Location,
+	rules []Rule,
+	overrides []Override,
+	busy []Slot,
+	duration time.Duration,
+	from time.Time,
+	to time.Time,
+	now time.Time,
+) []Slot {
+	weekly := make(map[time.Weekday][]window)
+	for _, r := range rules {
+		weekly[r.Weekday] = append(weekly[r.Weekday], window{start: r.StartMinute, end: r.EndMinute})
+	}
+
+	blackouts := make(map[string]bool)
+	for _, o := range overrides {
+		if o.IsBlackout() {
+			blackouts[o.Date.Format(time.DateOnly)] = true
+		}
+	}
+
+	// a present key, even with no windows, means the weekly rules don't apply that day
+	byDate := make(map[string][]window)
+	for _, o := range overrides {
+		key := o.Date.Format(time.DateOnly)
+		if blackouts[key] {
+			byDate[key] = nil
+			continue
+		}
+		byDate[key] = append(byDate[key], window{start: *o.StartMinute, end: *o.EndMinute})
+	}
+
+	slots := []Slot{}
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	last := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, loc)
+
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		windows, overridden := byDate[day.Format(time.DateOnly)]
+		if !overridden {
+			windows = weekly[day.Weekday()]
+		}
+
+		for _, w := range windows {
+			windowEnd := w.end.on(day, loc)
+			for start := w.start.on(day, loc); !start.Add(duration).After(windowEnd); start = start.Add(duration) {
+				end := start.Add(duration)
+				if start.Before(now) || overlapsAny(busy, start, end) {
+					continue
+				}
+				slots = append(slots, Slot{StartsAt: start.UTC(), EndsAt: end.UTC()})
+			}
+		}
+	}
+
+	return slots
+}
+
+func overlapsAny(busy []Slot, start time.Time, end time.Time) bool {
+	for _, b := range busy {
+		if start.Before(b.EndsAt) && b.StartsAt.Before(end) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package availability
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func tod(t *testing.T, s string) *TimeOfDay {
+	t.Helper()
+	parsed, err := ParseTimeOfDay(s)
+	require.NoError(t, err)
+	return &parsed
+}
+
+func rule(t *testing.T, weekday time.Weekday, start, end string) Rule {
+	return Rule{Weekday: weekday, StartMinute: *tod(t, start), EndMinute: *tod(t, end)}
+}
+
+func override(t *testing.T, date string, start, end string) Override {
+	d, err := time.Parse(time.DateOnly, date)
+	require.NoError(t, err)
+
+	o := Override{Date: d}
+	if start != "" {
+		o.StartMinute, o.EndMinute = tod(t, start), tod(t, end)
+	}
+	return o
+}
+
+func utc(t *testing.T, s string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339, s)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestComputeSlots(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2025-03-03 is a Monday in EST (UTC-5); DST starts on Sunday 2025-03-09
+	monday := utc(t, "2025-03-03T05:00:00Z")
+	past := utc(t, "2025-01-01T00:00:00Z")
+
+	tests := []struct {
+		name      string
+		rules     []Rule
+		overrides []Override
+		busy      []Slot
+		duration  time.Duration
+		from      time.Time
+		to        time.Time
+		now       time.Time
+		want      []string
+	}{
+		{
+			name:     "weekly window",
+			rules:    []Rule{rule(t, time.Monday, "09:00", "11:00")},
+			duration: time.Hour,
+			from:     monday, to: monday, now: past,
+			want: []string{"2025-03-03T14:00:00Z", "2025-03-03T15:00:00Z"},
+		},
+		{
+			name:     "partial slot at the end of a window is dropped",
+			rules:    []Rule{rule(t, time.Monday, "09:00", "10:30")},
+			duration: time.Hour,
+			from:     monday, to: monday, now: past,
+			want: []string{"2025-03-03T14:00:00Z"},
+		},
+		{
+			name:     "several windows on one day",
+			rules:    []Rule{rule(t, time.Monday, "09:00", "10:00"), rule(t, time.Monday, "13:00", "14:00")},
+			duration: time.Hour,
+			from:     monday, to: monday, now: past,
+			want: []string{"2025-03-03T14:00:00Z", "2025-03-03T18:00:00Z"},
+		},
+		{
+			name:     "day without rules",
+			rules:    []Rule{rule(t, time.Tuesday, "09:00", "11:00")},
+			duration: time.Hour,
+			from:     monday, to: monday, now: past,
+			want: []string{},
+		},
+		{
+			name:     "busy interval removes overlapping slots",
+			rules:    []Rule{rule(t, time.Monday, "09:00", "12:00")},
+			busy:     []Slot{{StartsAt: utc(t, "2025-03-03T14:30:00Z"), EndsAt: utc(t, "2025-03-03T15:00:00Z")}},
+			duration: time.Hour,
+			from:     monday, to: monday, now: past,
+			want: []string{"2025-03-03T15:00:00Z", "2025-03-03T16:00:00Z"},
+		},
+		{
+			name:     "busy interval touching a slot does not overlap it",
+			rules:    []Rule{rule(t, time.Monday, "09:00", "11:00")},
+			busy:     []Slot{{StartsAt: utc(t, "2025-03-03T13:00:00Z"), EndsAt: utc(t, "2025-03-03T14:00:00Z")}},
+			duration: time.Hour,
+			from:     monday, to: monday, now: past,
+			want: []string{"2025-03-03T14:00:00Z", "2025-03-03T15:00:00Z"},
+		},
+		{
+			name:     "slots starting before now are dropped",
+			rules:    []Rule{rule(t, time.Monday, "09:00", "11:00")},
+			duration: time.Hour,
+			from:     monday, to: monday, now: utc(t, "2025-03-03T14:30:00Z"),
+			want: []string{"2025-03-03T15:00:00Z"},
+		},
+		{
+			name:      "override replaces the weekly rules",
+			rules:     []Rule{rule(t, time.Monday, "09:00", "11:00")},
+			overrides: []Override{override(t, "2025-03-03", "13:00", "14:00")},
+			duration:  time.Hour,
+			from:      monday, to: monday, now: past,
+			want: []string{"2025-03-03T18:00:00Z"},
+		},
+		{
+			name:      "blackout override closes the day",
+			rules:     []Rule{rule(t, time.Monday, "09:00", "11:00")},
+			overrides: []Override{override(t, "2025-03-03", "", "")},
+			duration:  time.Hour,
+			from:      monday, to: monday, now: past,
+			want: []string{},
+		},
+		{
+			name:      "blackout wins over a window on the same day",
+			rules:     []Rule{rule(t, time.Monday, "09:00", "11:00")},
+			overrides: []Override{override(t, "2025-03-03", "13:00", "14:00"), override(t, "2025-03-03", "", "")},
+			duration:  time.Hour,
+			from:      monday, to: monday, now: past,
+			want: []string{},
+		},
+		{
+			name:     "window until the end of the day",
+			rules:    []Rule{rule(t, time.Monday, "23:00", "24:00")},
+			duration: time.Hour,
+			from:     monday, to: monday, now: past,
+			want: []string{"2025-03-04T04:00:00Z"},
+		},
+		{
+			name:     "rules follow local time across the DST change",
+			rules:    []Rule{rule(t, time.Monday, "09:00", "10:00")},
+			duration: time.Hour,
+			from:     monday, to: utc(t, "2025-03-10T12:00:00Z"), now: past,
+			want: []string{"2025-03-03T14:00:00Z", "2025-03-10T13:00:00Z"},
+		},
+		{
+			name:     "nonexistent wall clock time on the DST day",
+			rules:    []Rule{rule(t, time.Sunday, "01:00", "04:00")},
+			duration: time.Hour,
+			from:     utc(t, "2025-03-09T12:00:00Z"), to: utc(t, "2025-03-09T12:00:00Z"), now: past,
+			want: []string{"2025-03-09T06:00:00Z", "2025-03-09T07:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slots := ComputeSlots(loc, tt.rules, tt.overrides, tt.busy, tt.duration, tt.from, tt.to, tt.now)
+
+			got := []string{}
+			for _, slot := range slots {
+				assert.Equal(t, tt.duration, slot.EndsAt.Sub(slot.StartsAt))
+				got = append(got, slot.StartsAt.Format(time.RFC3339))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    TimeOfDay
+		wantErr bool
+	}{
+		{in: "00:00", want: 0},
+		{in: "09:30", want: 570},
+		{in: "24:00", want: 1440},
+		{in: "24:01", wantErr: true},
+		{in: "12:60", wantErr: true},
+		{in: "9:30", wantErr: true},
+		{in: "ab:cd", wantErr: true},
+		{in: "-1:00", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseTimeOfDay(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.in, got.String())
+		})
+	}
+}
@@ -18,6 +18,11 @@ type BaseWithUpdatedAt struct {
 	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
 }
 
+// BaseWithDeletedAt marks a soft-deletable row; a non-nil DeletedAt means the row is in the trash
+type BaseWithDeletedAt struct {
+	DeletedAt *time.Time `json:"deletedAt" db:"deleted_at"`
+}
+
 type Base struct {
 	BaseWithId
 	BaseWithCreatedAt
@@ -0,0 +1,65 @@
+package booking
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusConfirmed Status = "confirmed"
+	StatusDeclined  Status = "declined"
+	StatusCancelled Status = "cancelled"
+	StatusCompleted Status = "completed"
+)
+
+// transitions lists, for every status, the statuses a booking may move to next.
+// Rescheduling sends a booking back to pending so the provider has to confirm the new slot.
+var transitions = map[Status][]Status{
+	StatusPending:   {StatusConfirmed, StatusDeclined, StatusCancelled, StatusPending},
+	StatusConfirmed: {StatusCompleted, StatusCancelled, StatusPending},
+	StatusDeclined:  {},
+	StatusCancelled: {},
+	StatusCompleted: {},
+}
+
+// CanTransitionTo reports whether a booking in status s may move to next
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal reports whether no further transitions are possible from s
+func (s Status) IsTerminal() bool {
+	return len(transitions[s]) == 0
+}
+
+type Booking struct {
+	model.Base
+	ServiceID    uuid.UUID `json:"serviceId" db:"service_id"`
+	ProviderID   string    `json:"providerId" db:"provider_id"`
+	CustomerID   string    `json:"customerId" db:"customer_id"`
+	Status       Status    `json:"status" db:"status"`
+	StartsAt     time.Time `json:"startsAt" db:"starts_at"`
+	EndsAt       time.Time `json:"endsAt" db:"ends_at"`
+	Notes        *string   `json:"notes" db:"notes"`
+	StatusReason *string   `json:"statusReason" db:"status_reason"`
+}
+
+// IsProvider reports whether userID owns the booked service
+func (b *Booking) IsProvider(userID string) bool {
+	return b.ProviderID == userID
+}
+
+// IsCustomer reports whether userID requested the booking
+func (b *Booking) IsCustomer(userID string) bool {
+	return b.CustomerID == userID
+}
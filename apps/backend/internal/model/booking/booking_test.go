@@ -0,0 +1,69 @@
+package booking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from Status
+		to   Status
+		want bool
+	}{
+		{from: StatusPending, to: StatusConfirmed, want: true},
+		{from: StatusPending, to: StatusDeclined, want: true},
+		{from: StatusPending, to: StatusCancelled, want: true},
+		{from: StatusPending, to: StatusPending, want: true},
+		{from: StatusPending, to: StatusCompleted, want: false},
+
+		{from: StatusConfirmed, to: StatusCompleted, want: true},
+		{from: StatusConfirmed, to: StatusCancelled, want: true},
+		{from: StatusConfirmed, to: StatusPending, want: true},
+		{from: StatusConfirmed, to: StatusDeclined, want: false},
+		{from: StatusConfirmed, to: StatusConfirmed, want: false},
+
+		{from: StatusDeclined, to: StatusPending, want: false},
+		{from: StatusDeclined, to: StatusConfirmed, want: false},
+		{from: StatusCancelled, to: StatusPending, want: false},
+		{from: StatusCancelled, to: StatusConfirmed, want: false},
+		{from: StatusCompleted, to: StatusCancelled, want: false},
+		{from: StatusCompleted, to: StatusPending, want: false},
+
+		{from: Status("unknown"), to: StatusPending, want: false},
+		{from: StatusPending, to: Status("unknown"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   bool
+	}{
+		{status: StatusPending, want: false},
+		{status: StatusConfirmed, want: false},
+		{status: StatusDeclined, want: true},
+		{status: StatusCancelled, want: true},
+		{status: StatusCompleted, want: true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.status.IsTerminal(), tt.status)
+	}
+}
+
+func TestParticipants(t *testing.T) {
+	b := Booking{ProviderID: "user_provider", CustomerID: "user_customer"}
+
+	assert.True(t, b.IsProvider("user_provider"))
+	assert.False(t, b.IsProvider("user_customer"))
+	assert.True(t, b.IsCustomer("user_customer"))
+	assert.False(t, b.IsCustomer("user_provider"))
+}
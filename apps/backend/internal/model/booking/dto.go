@@ -0,0 +1,128 @@
+package booking
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// --------------------------------------------------------------------------
+
+type CreateBookingPayload struct {
+	ServiceID uuid.UUID `json:"serviceId" validate:"required,uuid"`
+	StartsAt  time.Time `json:"startsAt" validate:"required"`
+	EndsAt    time.Time `json:"endsAt" validate:"required,gtfield=StartsAt"`
+	Notes     *string   `json:"notes" validate:"omitempty,max=1000"`
+}
+
+func (p *CreateBookingPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetBookingsQuery struct {
+	Page      *int       `query:"page" validate:"omitempty,min=1"`
+	Limit     *int       `query:"limit" validate:"omitempty,min=1,max=100"`
+	Role      *string    `query:"role" validate:"omitempty,oneof=provider customer"`
+	Status    *Status    `query:"status" validate:"omitempty,oneof=pending confirmed declined cancelled completed"`
+	ServiceID *uuid.UUID `query:"serviceId" validate:"omitempty,uuid"`
+	From      *time.Time `query:"from"`
+	To        *time.Time `query:"to"`
+	Order     *string    `query:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+func (q *GetBookingsQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+	if q.Order == nil {
+		defaultOrder := "asc"
+		q.Order = &defaultOrder
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetBookingByIDPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *GetBookingByIDPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type ConfirmBookingPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *ConfirmBookingPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type DeclineBookingPayload struct {
+	ID     uuid.UUID `param:"id" validate:"required,uuid"`
+	Reason *string   `json:"reason" validate:"omitempty,max=500"`
+}
+
+func (p *DeclineBookingPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type CancelBookingPayload struct {
+	ID     uuid.UUID `param:"id" validate:"required,uuid"`
+	Reason *string   `json:"reason" validate:"omitempty,max=500"`
+}
+
+func (p *CancelBookingPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type RescheduleBookingPayload struct {
+	ID       uuid.UUID `param:"id" validate:"required,uuid"`
+	StartsAt time.Time `json:"startsAt" validate:"required"`
+	EndsAt   time.Time `json:"endsAt" validate:"required,gtfield=StartsAt"`
+	Reason   *string   `json:"reason" validate:"omitempty,max=500"`
+}
+
+func (p *RescheduleBookingPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type CompleteBookingPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *CompleteBookingPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
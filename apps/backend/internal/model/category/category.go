@@ -1,11 +1,24 @@
 package category
 
-import "github.com/mukundaparajuli/fixr/internal/model"
+import (
+	"encoding/json"
+
+	"github.com/mukundaparajuli/fixr/internal/model"
+)
 
 type Category struct {
 	model.Base
+	model.BaseWithDeletedAt
 	UserID      string  `json:"userId" db:"user_id"`
 	Name        string  `json:"name" db:"name"`
 	Description *string `json:"description" db:"description"`
 	Color       string  `json:"color" db:"color"`
+	// MetadataSchema is a JSON Schema that the metadata of services in this category must satisfy
+	MetadataSchema json.RawMessage `json:"metadataSchema" db:"metadata_schema"`
+}
+
+type CategoryWithServiceCounts struct {
+	Category
+	ActiveServiceCount   int `json:"activeServiceCount" db:"active_service_count"`
+	InactiveServiceCount int `json:"inactiveServiceCount" db:"inactive_service_count"`
 }
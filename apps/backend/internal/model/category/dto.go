@@ -1,8 +1,12 @@
 package category
 
 import (
+	"encoding/json"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/lib/metaschema"
+	"github.com/mukundaparajuli/fixr/internal/validation"
 )
 
 // --------------------------------------------------------------------------
@@ -11,25 +15,40 @@ type CreateCategoryPayload struct {
 	Name        string  `json:"name" validate:"required,min=1,max=100"`
 	Description *string `json:"description" validate:"omitempty,max=255"`
 	Color       *string `json:"color" validate:"required,hexcolor"`
+	// MetadataSchema optionally describes the metadata of services in this category
+	MetadataSchema json.RawMessage `json:"metadataSchema"`
 }
 
 func (p *CreateCategoryPayload) Validate() error {
 	validate := validator.New()
-	return validate.Struct(p)
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+	return validateMetadataSchema(p.MetadataSchema)
 }
 
 // --------------------------------------------------------------------------
 
 type UpdateCategoryPayload struct {
-	ID          string  `param:"id" validate:"required,uuid"`
-	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
-	Description *string `json:"description" validate:"omitempty,max=255"`
-	Color       *string `json:"color" validate:"omitempty,hexcolor"`
+	ID          uuid.UUID `param:"id" validate:"required,uuid"`
+	Name        *string   `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string   `json:"description" validate:"omitempty,max=255"`
+	Color       *string   `json:"color" validate:"omitempty,hexcolor"`
+	// MetadataSchema replaces the schema when given; an explicit null removes it
+	MetadataSchema json.RawMessage `json:"metadataSchema"`
 }
 
 func (p *UpdateCategoryPayload) Validate() error {
 	validate := validator.New()
-	return validate.Struct(p)
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+	return validateMetadataSchema(p.MetadataSchema)
+}
+
+// ClearsMetadataSchema reports whether the update removes the schema
+func (p *UpdateCategoryPayload) ClearsMetadataSchema() bool {
+	return string(p.MetadataSchema) == "null"
 }
 
 // --------------------------------------------------------------------------
@@ -38,7 +57,7 @@ type GetCategoriesQuery struct {
 	Page   *int    `query:"page" validate:"omitempty,min=1"`
 	Limit  *int    `query:"limit" validate:"omitempty,min=1,max=100"`
 	Sort   *string `query:"sort" validate:"omitempty,oneof=created_at updated_at name"`
-	Order  *string `query:"order" validate:"omitempty,oneof = asc desc"`
+	Order  *string `query:"order" validate:"omitempty,oneof=asc desc"`
 	Search *string `query:"search" validate:"omitempty,min=1"`
 }
 
@@ -69,6 +88,17 @@ func (q *GetCategoriesQuery) Validate() error {
 
 // --------------------------------------------------------------------------
 
+type GetCategoryByIDPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *GetCategoryByIDPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
 type DeleteCategoryPayload struct {
 	ID uuid.UUID `param:"id" validate:"required,uuid"`
 }
@@ -77,3 +107,29 @@ func (p *DeleteCategoryPayload) Validate() error {
 	validate := validator.New()
 	return validate.Struct(p)
 }
+
+// --------------------------------------------------------------------------
+
+type RestoreCategoryPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *RestoreCategoryPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+// validateMetadataSchema compiles a schema given in a payload; absent and null schemas are accepted
+func validateMetadataSchema(schema json.RawMessage) error {
+	if len(schema) == 0 || string(schema) == "null" {
+		return nil
+	}
+	if _, err := metaschema.Compile(schema); err != nil {
+		return validation.CustomValidationErrors{
+			{Field: "metadataSchema", Message: err.Error()},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package invoice
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+// --------------------------------------------------------------------------
+
+// CreateInvoicePayload bills either a completed booking or an accepted quote
+type CreateInvoicePayload struct {
+	BookingID *uuid.UUID `json:"bookingId" validate:"omitempty,uuid"`
+	QuoteID   *uuid.UUID `json:"quoteId" validate:"omitempty,uuid"`
+	TaxRate   *int64     `json:"taxRate" validate:"omitempty,min=0,max=10000"`
+	Notes     *string    `json:"notes" validate:"omitempty,max=2000"`
+	DueInDays *int       `json:"dueInDays" validate:"omitempty,min=0,max=365"`
+}
+
+func (p *CreateInvoicePayload) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	if (p.BookingID == nil) == (p.QuoteID == nil) {
+		return validation.CustomValidationErrors{
+			{Field: "bookingId", Message: "exactly one of bookingId or quoteId is required"},
+		}
+	}
+
+	if p.QuoteID != nil && p.TaxRate != nil {
+		return validation.CustomValidationErrors{
+			{Field: "taxRate", Message: "is taken from the quote and cannot be set"},
+		}
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetInvoicesQuery struct {
+	Page   *int    `query:"page" validate:"omitempty,min=1"`
+	Limit  *int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status *Status `query:"status" validate:"omitempty,oneof=draft sent paid void"`
+}
+
+func (q *GetInvoicesQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetInvoiceByIDPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *GetInvoiceByIDPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type UpdateInvoiceStatusPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *UpdateInvoiceStatusPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
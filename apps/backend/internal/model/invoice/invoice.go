@@ -0,0 +1,79 @@
+package invoice
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+)
+
+type Status string
+
+const (
+	StatusDraft Status = "draft"
+	StatusSent  Status = "sent"
+	StatusPaid  Status = "paid"
+	StatusVoid  Status = "void"
+)
+
+// transitions lists, for each status, the statuses an invoice may move to next
+var transitions = map[Status][]Status{
+	StatusDraft: {StatusSent, StatusVoid},
+	StatusSent:  {StatusPaid, StatusVoid},
+}
+
+func (s Status) CanTransitionTo(next Status) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Invoice amounts are in the minor unit of Currency; TaxRate is basis points
+type Invoice struct {
+	model.Base
+	UserID         string     `json:"userId" db:"user_id"`
+	CustomerID     *string    `json:"customerId" db:"customer_id"`
+	Number         int64      `json:"number" db:"number"`
+	Status         Status     `json:"status" db:"status"`
+	BookingID      *uuid.UUID `json:"bookingId" db:"booking_id"`
+	QuoteID        *uuid.UUID `json:"quoteId" db:"quote_id"`
+	Currency       string     `json:"currency" db:"currency"`
+	Subtotal       int64      `json:"subtotal" db:"subtotal"`
+	DiscountAmount int64      `json:"discountAmount" db:"discount_amount"`
+	TaxRate        int64      `json:"taxRate" db:"tax_rate"`
+	TaxAmount      int64      `json:"taxAmount" db:"tax_amount"`
+	Total          int64      `json:"total" db:"total"`
+	Notes          *string    `json:"notes" db:"notes"`
+	DueAt          *time.Time `json:"dueAt" db:"due_at"`
+	SentAt         *time.Time `json:"sentAt" db:"sent_at"`
+	PaidAt         *time.Time `json:"paidAt" db:"paid_at"`
+	VoidedAt       *time.Time `json:"voidedAt" db:"voided_at"`
+}
+
+// DisplayNumber is the human facing invoice number, e.g. INV-000042
+func (i *Invoice) DisplayNumber() string {
+	return fmt.Sprintf("INV-%06d", i.Number)
+}
+
+type Item struct {
+	model.Base
+	InvoiceID   uuid.UUID           `json:"invoiceId" db:"invoice_id"`
+	ServiceID   *uuid.UUID          `json:"serviceId" db:"service_id"`
+	Description string              `json:"description" db:"description"`
+	Method      service.Method      `json:"method" db:"method"`
+	Quantity    float64             `json:"quantity" db:"quantity"`
+	UnitLabel   *string             `json:"unitLabel" db:"unit_label"`
+	Amount      int64               `json:"amount" db:"amount"`
+	Lines       []service.PriceLine `json:"lines" db:"lines"`
+	SortOrder   int                 `json:"sortOrder" db:"sort_order"`
+}
+
+type PopulatedInvoice struct {
+	Invoice
+	Items []Item `json:"items" db:"items"`
+}
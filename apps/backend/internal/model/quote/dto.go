@@ -0,0 +1,111 @@
+package quote
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// DefaultValidDays is how long a quote can be accepted when the provider doesn't say otherwise
+const DefaultValidDays = 30
+
+// --------------------------------------------------------------------------
+
+type ItemPayload struct {
+	ServiceID uuid.UUID `json:"serviceId" validate:"required,uuid"`
+	Quantity  float64   `json:"quantity" validate:"gt=0"`
+}
+
+type CreateQuotePayload struct {
+	RootServiceID  uuid.UUID     `json:"rootServiceId" validate:"required,uuid"`
+	Items          []ItemPayload `json:"items" validate:"required,min=1,max=100,dive"`
+	DiscountRate   *int64        `json:"discountRate" validate:"omitempty,min=0,max=10000"`
+	DiscountAmount *int64        `json:"discountAmount" validate:"omitempty,min=0"`
+	TaxRate        *int64        `json:"taxRate" validate:"omitempty,min=0,max=10000"`
+	Notes          *string       `json:"notes" validate:"omitempty,max=2000"`
+	ValidDays      *int          `json:"validDays" validate:"omitempty,min=1,max=365"`
+}
+
+func (p *CreateQuotePayload) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	if p.ValidDays == nil {
+		defaultValidDays := DefaultValidDays
+		p.ValidDays = &defaultValidDays
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetQuotesQuery struct {
+	Page   *int    `query:"page" validate:"omitempty,min=1"`
+	Limit  *int    `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status *Status `query:"status" validate:"omitempty,oneof=pending accepted rejected withdrawn"`
+}
+
+func (q *GetQuotesQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetQuoteByIDPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *GetQuoteByIDPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type WithdrawQuotePayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *WithdrawQuotePayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetSharedQuotePayload struct {
+	Token string `param:"token" validate:"required,max=64"`
+}
+
+func (p *GetSharedQuotePayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type RespondToQuotePayload struct {
+	Token string  `param:"token" validate:"required,max=64"`
+	Note  *string `json:"note" validate:"omitempty,max=1000"`
+}
+
+func (p *RespondToQuotePayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
@@ -0,0 +1,61 @@
+package quote
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusAccepted  Status = "accepted"
+	StatusRejected  Status = "rejected"
+	StatusWithdrawn Status = "withdrawn"
+)
+
+// Quote amounts are in the minor unit of Currency; rates are basis points
+type Quote struct {
+	model.Base
+	UserID         string     `json:"userId" db:"user_id"`
+	RootServiceID  *uuid.UUID `json:"rootServiceId" db:"root_service_id"`
+	Title          string     `json:"title" db:"title"`
+	Status         Status     `json:"status" db:"status"`
+	Currency       string     `json:"currency" db:"currency"`
+	Subtotal       int64      `json:"subtotal" db:"subtotal"`
+	DiscountRate   int64      `json:"discountRate" db:"discount_rate"`
+	DiscountAmount int64      `json:"discountAmount" db:"discount_amount"`
+	TaxRate        int64      `json:"taxRate" db:"tax_rate"`
+	TaxAmount      int64      `json:"taxAmount" db:"tax_amount"`
+	Total          int64      `json:"total" db:"total"`
+	Notes          *string    `json:"notes" db:"notes"`
+	ShareToken     string     `json:"shareToken" db:"share_token"`
+	ExpiresAt      time.Time  `json:"expiresAt" db:"expires_at"`
+	RespondedAt    *time.Time `json:"respondedAt" db:"responded_at"`
+	ResponseNote   *string    `json:"responseNote" db:"response_note"`
+}
+
+func (q *Quote) IsExpired(now time.Time) bool {
+	return !now.Before(q.ExpiresAt)
+}
+
+type Item struct {
+	model.Base
+	QuoteID     uuid.UUID           `json:"quoteId" db:"quote_id"`
+	ServiceID   *uuid.UUID          `json:"serviceId" db:"service_id"`
+	Description string              `json:"description" db:"description"`
+	Method      service.Method      `json:"method" db:"method"`
+	Quantity    float64             `json:"quantity" db:"quantity"`
+	UnitLabel   *string             `json:"unitLabel" db:"unit_label"`
+	Amount      int64               `json:"amount" db:"amount"`
+	Lines       []service.PriceLine `json:"lines" db:"lines"`
+	SortOrder   int                 `json:"sortOrder" db:"sort_order"`
+}
+
+type PopulatedQuote struct {
+	Quote
+	Items []Item `json:"items" db:"items"`
+}
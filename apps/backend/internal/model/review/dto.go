@@ -0,0 +1,92 @@
+package review
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// --------------------------------------------------------------------------
+
+type CreateReviewPayload struct {
+	BookingID uuid.UUID `param:"id" validate:"required,uuid"`
+	Rating    int       `json:"rating" validate:"required,min=1,max=5"`
+	Comment   *string   `json:"comment" validate:"omitempty,min=1,max=2000"`
+}
+
+func (p *CreateReviewPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type UpdateReviewPayload struct {
+	ID      uuid.UUID `param:"id" validate:"required,uuid"`
+	Rating  *int      `json:"rating" validate:"omitempty,min=1,max=5"`
+	Comment *string   `json:"comment" validate:"omitempty,min=1,max=2000"`
+}
+
+func (p *UpdateReviewPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type DeleteReviewPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *DeleteReviewPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type ReplyToReviewPayload struct {
+	ID    uuid.UUID `param:"id" validate:"required,uuid"`
+	Reply string    `json:"reply" validate:"required,min=1,max=2000"`
+}
+
+func (p *ReplyToReviewPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetServiceReviewsQuery struct {
+	ServiceID uuid.UUID `param:"id" validate:"required,uuid"`
+	Page      *int      `query:"page" validate:"omitempty,min=1"`
+	Limit     *int      `query:"limit" validate:"omitempty,min=1,max=100"`
+	Rating    *int      `query:"rating" validate:"omitempty,min=1,max=5"`
+	Sort      *string   `query:"sort" validate:"omitempty,oneof=created_at rating"`
+	Order     *string   `query:"order" validate:"omitempty,oneof=asc desc"`
+}
+
+func (q *GetServiceReviewsQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+	if q.Sort == nil {
+		defaultSort := "created_at"
+		q.Sort = &defaultSort
+	}
+	if q.Order == nil {
+		defaultOrder := "desc"
+		q.Order = &defaultOrder
+	}
+
+	return nil
+}
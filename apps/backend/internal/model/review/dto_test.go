@@ -0,0 +1,119 @@
+package review
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCreateReviewPayloadValidate(t *testing.T) {
+	bookingID := uuid.New()
+
+	tests := []struct {
+		name    string
+		payload CreateReviewPayload
+		wantErr bool
+	}{
+		{name: "minimum rating", payload: CreateReviewPayload{BookingID: bookingID, Rating: 1}},
+		{name: "maximum rating with comment", payload: CreateReviewPayload{BookingID: bookingID, Rating: 5, Comment: ptr("Great job")}},
+		{name: "missing rating", payload: CreateReviewPayload{BookingID: bookingID}, wantErr: true},
+		{name: "rating above five", payload: CreateReviewPayload{BookingID: bookingID, Rating: 6}, wantErr: true},
+		{name: "negative rating", payload: CreateReviewPayload{BookingID: bookingID, Rating: -1}, wantErr: true},
+		{name: "missing booking", payload: CreateReviewPayload{Rating: 4}, wantErr: true},
+		{name: "empty comment", payload: CreateReviewPayload{BookingID: bookingID, Rating: 4, Comment: ptr("")}, wantErr: true},
+		{name: "comment too long", payload: CreateReviewPayload{BookingID: bookingID, Rating: 4, Comment: ptr(strings.Repeat("a", 2001))}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateReviewPayloadValidate(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		payload UpdateReviewPayload
+		wantErr bool
+	}{
+		{name: "no changes", payload: UpdateReviewPayload{ID: id}},
+		{name: "new rating", payload: UpdateReviewPayload{ID: id, Rating: ptr(3)}},
+		{name: "rating zero", payload: UpdateReviewPayload{ID: id, Rating: ptr(0)}, wantErr: true},
+		{name: "rating above five", payload: UpdateReviewPayload{ID: id, Rating: ptr(6)}, wantErr: true},
+		{name: "missing id", payload: UpdateReviewPayload{Rating: ptr(3)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReplyToReviewPayloadValidate(t *testing.T) {
+	id := uuid.New()
+
+	assert.NoError(t, (&ReplyToReviewPayload{ID: id, Reply: "Thanks!"}).Validate())
+	assert.Error(t, (&ReplyToReviewPayload{ID: id}).Validate())
+	assert.Error(t, (&ReplyToReviewPayload{ID: id, Reply: strings.Repeat("a", 2001)}).Validate())
+}
+
+func TestGetServiceReviewsQueryDefaults(t *testing.T) {
+	q := GetServiceReviewsQuery{ServiceID: uuid.New()}
+	require.NoError(t, q.Validate())
+
+	assert.Equal(t, 1, *q.Page)
+	assert.Equal(t, 20, *q.Limit)
+	assert.Equal(t, "created_at", *q.Sort)
+	assert.Equal(t, "desc", *q.Order)
+	assert.Nil(t, q.Rating)
+}
+
+func TestGetServiceReviewsQueryValidate(t *testing.T) {
+	serviceID := uuid.New()
+
+	tests := []struct {
+		name    string
+		query   GetServiceReviewsQuery
+		wantErr bool
+	}{
+		{name: "rating filter", query: GetServiceReviewsQuery{ServiceID: serviceID, Rating: ptr(5)}},
+		{name: "sort by rating ascending", query: GetServiceReviewsQuery{ServiceID: serviceID, Sort: ptr("rating"), Order: ptr("asc")}},
+		{name: "rating filter out of range", query: GetServiceReviewsQuery{ServiceID: serviceID, Rating: ptr(0)}, wantErr: true},
+		{name: "unknown sort", query: GetServiceReviewsQuery{ServiceID: serviceID, Sort: ptr("customer_id")}, wantErr: true},
+		{name: "unknown order", query: GetServiceReviewsQuery{ServiceID: serviceID, Order: ptr("up")}, wantErr: true},
+		{name: "limit above maximum", query: GetServiceReviewsQuery{ServiceID: serviceID, Limit: ptr(101)}, wantErr: true},
+		{name: "page zero", query: GetServiceReviewsQuery{ServiceID: serviceID, Page: ptr(0)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package review
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model"
+)
+
+type Review struct {
+	model.Base
+	BookingID  uuid.UUID  `json:"bookingId" db:"booking_id"`
+	ServiceID  uuid.UUID  `json:"serviceId" db:"service_id"`
+	ProviderID string     `json:"providerId" db:"provider_id"`
+	CustomerID string     `json:"customerId" db:"customer_id"`
+	Rating     int        `json:"rating" db:"rating"`
+	Comment    *string    `json:"comment" db:"comment"`
+	Reply      *string    `json:"reply" db:"reply"`
+	RepliedAt  *time.Time `json:"repliedAt" db:"replied_at"`
+}
@@ -0,0 +1,40 @@
+package search
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+// --------------------------------------------------------------------------
+
+type SearchQuery struct {
+	Q      string          `query:"q" validate:"required,min=1,max=200"`
+	Status *service.Status `query:"status" validate:"omitempty,oneof=active inactive"`
+	Page   *int            `query:"page" validate:"omitempty,min=1"`
+	Limit  *int            `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+func (q *SearchQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if PrefixQuery(q.Q) == "" {
+		return validation.CustomValidationErrors{
+			{Field: "q", Message: "must contain at least one letter or digit"},
+		}
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+
+	return nil
+}
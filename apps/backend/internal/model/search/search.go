@@ -0,0 +1,46 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/category"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+)
+
+// maxTerms caps how many words of the input make it into the tsquery
+const maxTerms = 10
+
+var termPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+
+// PrefixQuery turns free text into a to_tsquery expression where every word matches as a
+// prefix, e.g. "plumb fix" becomes "plumb:* & fix:*". Only letters and digits survive, so the
+// result is always safe to hand to to_tsquery. It is empty when the input has no usable words.
+func PrefixQuery(input string) string {
+	terms := termPattern.FindAllString(strings.ToLower(input), maxTerms)
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+type ServiceHit struct {
+	service.Service
+	CategoryName  *string `json:"categoryName" db:"category_name"`
+	Rank          float64 `json:"rank" db:"rank"`
+	NameHighlight string  `json:"nameHighlight" db:"name_highlight"`
+	Snippet       *string `json:"snippet" db:"snippet"`
+}
+
+type CategoryHit struct {
+	category.Category
+	Rank          float64 `json:"rank" db:"rank"`
+	NameHighlight string  `json:"nameHighlight" db:"name_highlight"`
+}
+
+type Results struct {
+	Query      string                               `json:"query"`
+	Services   *model.PaginatedResponse[ServiceHit] `json:"services"`
+	Categories []CategoryHit                        `json:"categories"`
+}
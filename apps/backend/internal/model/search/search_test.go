@@ -0,0 +1,34 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "single word", input: "plumb", want: "plumb:*"},
+		{name: "several words", input: "plumb fix", want: "plumb:* & fix:*"},
+		{name: "lowercases", input: "Plumb FIX", want: "plumb:* & fix:*"},
+		{name: "collapses whitespace", input: "  plumb \t\n fix  ", want: "plumb:* & fix:*"},
+		{name: "keeps digits", input: "24h repair", want: "24h:* & repair:*"},
+		{name: "keeps non ascii letters", input: "Élagage Straße", want: "élagage:* & straße:*"},
+		{name: "splits on punctuation", input: "roof-repair, gutter", want: "roof:* & repair:* & gutter:*"},
+		{name: "strips tsquery operators", input: "plumb & !fix | (a:*) <->", want: "plumb:* & fix:* & a:*"},
+		{name: "strips quotes", input: `'plumb' "fix"`, want: "plumb:* & fix:*"},
+		{name: "empty input", input: "", want: ""},
+		{name: "only punctuation", input: "&|!():*'", want: ""},
+		{name: "caps the number of terms", input: "a b c d e f g h i j k l", want: "a:* & b:* & c:* & d:* & e:* & f:* & g:* & h:* & i:* & j:*"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, PrefixQuery(tt.input))
+		})
+	}
+}
@@ -1,16 +1,99 @@
 package service
 
 import (
+	"mime/multipart"
+	"time"
+
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/mukundaparajuli/fixr/internal/model"
 )
 
 type ServiceAttachment struct {
 	model.Base
+	model.BaseWithDeletedAt
 	Name        string     `json:"name" db:"name"`
 	ServiceID   *uuid.UUID `json:"serviceId" db:"service_id"`
 	UploadedBy  string     `json:"uploadedBy" db:"uploaded_by"`
-	DownloadKey string     `json:"donwloadKey" db:"downloadKey"`
+	DownloadKey string     `json:"downloadKey" db:"download_key"`
 	FileSize    *int64     `json:"fileSize" db:"file_size"`
 	MimeType    *string    `json:"mimeType" db:"mime_type"`
 }
+
+type AttachmentDownloadURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// --------------------------------------------------------------------------
+
+type UploadServiceAttachmentPayload struct {
+	ServiceID uuid.UUID             `param:"id" validate:"required,uuid"`
+	File      *multipart.FileHeader `form:"file" validate:"required"`
+}
+
+func (p *UploadServiceAttachmentPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetServiceAttachmentsPayload struct {
+	ServiceID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *GetServiceAttachmentsPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetAttachmentDownloadURLPayload struct {
+	ServiceID    uuid.UUID `param:"id" validate:"required,uuid"`
+	AttachmentID uuid.UUID `param:"attachmentId" validate:"required,uuid"`
+}
+
+func (p *GetAttachmentDownloadURLPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type DeleteServiceAttachmentPayload struct {
+	ServiceID    uuid.UUID `param:"id" validate:"required,uuid"`
+	AttachmentID uuid.UUID `param:"attachmentId" validate:"required,uuid"`
+}
+
+func (p *DeleteServiceAttachmentPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type RestoreServiceAttachmentPayload struct {
+	ServiceID    uuid.UUID `param:"id" validate:"required,uuid"`
+	AttachmentID uuid.UUID `param:"attachmentId" validate:"required,uuid"`
+}
+
+func (p *RestoreServiceAttachmentPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type DownloadAttachmentQuery struct {
+	Key       string `query:"key" validate:"required"`
+	Filename  string `query:"filename" validate:"required"`
+	Expires   int64  `query:"expires" validate:"required"`
+	Signature string `query:"signature" validate:"required,hexadecimal"`
+}
+
+func (q *DownloadAttachmentQuery) Validate() error {
+	validate := validator.New()
+	return validate.Struct(q)
+}
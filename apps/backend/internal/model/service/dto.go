@@ -1,36 +1,92 @@
 package service
 
 import (
+	"fmt"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/lib/money"
+	"github.com/mukundaparajuli/fixr/internal/validation"
 )
 
+// DefaultDurationMinutes is used for services created without an explicit duration
+const DefaultDurationMinutes = 60
+
 // --------------------------------------------------------------------------
 
 type CreateServicePayload struct {
-	Name            string     `json:"name" validate:"required,min=1,max=100"`
-	Description     *string    `json:"description" validate:"omitempty,min=1,max=255"`
-	Rate            *int       `json:"rate" validate:"min=1"`
-	Method          *Method    `json:"method" validate:"oneof=hourly"`
-	ParentServiceID *uuid.UUID `json:"parentServiceId" validate:"omitempty,uuid"`
-	CategoryID      *uuid.UUID `json:"categoryId" validate:"omitempty,uuid"`
-	Metadata        *Metadata  `json:"metadata"`
+	Name            string      `json:"name" validate:"required,min=1,max=100"`
+	Description     *string     `json:"description" validate:"omitempty,min=1,max=255"`
+	Status          *Status     `json:"status" validate:"omitempty,oneof=active inactive"`
+	Rate            *int64      `json:"rate" validate:"omitempty,min=0"`
+	Currency        *string     `json:"currency" validate:"omitempty,iso4217"`
+	Method          *Method     `json:"method" validate:"omitempty,oneof=hourly fixed per_unit tiered volume"`
+	UnitLabel       *string     `json:"unitLabel" validate:"omitempty,min=1,max=30"`
+	PriceTiers      []PriceTier `json:"priceTiers" validate:"omitempty,dive"`
+	DurationMinutes *int        `json:"durationMinutes" validate:"omitempty,min=1,max=1440"`
+	ParentServiceID *uuid.UUID  `json:"parentServiceId" validate:"omitempty,uuid"`
+	CategoryID      *uuid.UUID  `json:"categoryId" validate:"omitempty,uuid"`
+	Metadata        *Metadata   `json:"metadata" validate:"omitempty,max=50"`
 }
 
 func (p *CreateServicePayload) Validate() error {
 	validate := validator.New()
-	return validate.Struct(p)
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	if p.Status == nil {
+		defaultStatus := Active
+		p.Status = &defaultStatus
+	}
+	if p.Rate == nil {
+		defaultRate := int64(0)
+		p.Rate = &defaultRate
+	}
+	if p.Currency == nil {
+		defaultCurrency := money.DefaultCurrency
+		p.Currency = &defaultCurrency
+	}
+	if p.Method == nil {
+		defaultMethod := Hourly
+		p.Method = &defaultMethod
+	}
+	if p.DurationMinutes == nil {
+		defaultDuration := DefaultDurationMinutes
+		p.DurationMinutes = &defaultDuration
+	}
+	if p.Metadata == nil {
+		p.Metadata = &Metadata{}
+	}
+
+	pricing := Pricing{
+		Method:     *p.Method,
+		Rate:       money.New(*p.Rate, *p.Currency),
+		UnitLabel:  p.UnitLabel,
+		PriceTiers: p.PriceTiers,
+	}
+	return pricing.Validate()
 }
 
 // --------------------------------------------------------------------------
 
 type UpdateServicePayload struct {
-	ID              uuid.UUID  `param:"id" validate:"required,uuid"`
-	Name            *string    `json:"name" validate:"required,min=1,max=100"`
-	Description     *string    `json:"description" validate:"omitempty,min=1,max=255"`
-	ParentServiceID *uuid.UUID `json:"parentServiceId" validate:"omitempty,uuid"`
-	CategoryID      *uuid.UUID `json:"categoryId" validate:"omitempty,uuid"`
-	Metadata        *Metadata  `json:"metadata"`
+	ID              uuid.UUID   `param:"id" validate:"required,uuid"`
+	Name            *string     `json:"name" validate:"omitempty,min=1,max=100"`
+	Description     *string     `json:"description" validate:"omitempty,min=1,max=255"`
+	Status          *Status     `json:"status" validate:"omitempty,oneof=active inactive"`
+	Rate            *int64      `json:"rate" validate:"omitempty,min=0"`
+	Currency        *string     `json:"currency" validate:"omitempty,iso4217"`
+	Method          *Method     `json:"method" validate:"omitempty,oneof=hourly fixed per_unit tiered volume"`
+	UnitLabel       *string     `json:"unitLabel" validate:"omitempty,min=1,max=30"`
+	PriceTiers      []PriceTier `json:"priceTiers" validate:"omitempty,dive"`
+	DurationMinutes *int        `json:"durationMinutes" validate:"omitempty,min=1,max=1440"`
+	ParentServiceID *uuid.UUID  `json:"parentServiceId" validate:"omitempty,uuid"`
+	CategoryID      *uuid.UUID  `json:"categoryId" validate:"omitempty,uuid"`
+	Metadata        *Metadata   `json:"metadata" validate:"omitempty,max=50"`
 }
 
 func (p *UpdateServicePayload) Validate() error {
@@ -43,12 +99,37 @@ func (p *UpdateServicePayload) Validate() error {
 type GetServicesQuery struct {
 	Page            *int       `query:"page" validate:"omitempty,min=1"`
 	Limit           *int       `query:"limit" validate:"omitempty,min=1,max=100"`
-	Sort            *string    `query:"sort" validate:"omitempty,oneof=created_at updated_at name"`
-	Order           *string    `query:"order" validate:"omitempty,oneof = asc desc"`
+	Sort            *string    `query:"sort" validate:"omitempty,oneof=created_at updated_at name sort_order rating_average rating_count"`
+	Order           *string    `query:"order" validate:"omitempty,oneof=asc desc"`
 	Search          *string    `query:"search" validate:"omitempty,min=1"`
-	Status          *Status    `query:"status" validate:"omitempty, oneof = active inactive"`
-	ParentServiceID *uuid.UUID `query:"parentServiceId" validate:"omitempty, uuid"`
+	Status          *Status    `query:"status" validate:"omitempty,oneof=active inactive"`
+	ParentServiceID *uuid.UUID `query:"parentServiceId" validate:"omitempty,uuid"`
 	CategoryID      *uuid.UUID `query:"categoryId" validate:"omitempty,uuid"`
+	MinRating       *float64   `query:"minRating" validate:"omitempty,min=1,max=5"`
+	Lat             *float64   `query:"lat" validate:"required_with=Lng,omitempty,latitude"`
+	Lng             *float64   `query:"lng" validate:"required_with=Lat,omitempty,longitude"`
+	WithinKm        *float64   `query:"withinKm" validate:"excluded_without=Lat,omitempty,min=0,max=200"`
+	PostalCode      *string    `query:"postalCode" validate:"omitempty,min=2,max=12"`
+	// MetadataKeys keeps services whose metadata sets every listed key (?metadataKey=a&metadataKey=b)
+	MetadataKeys []string `query:"metadataKey" validate:"omitempty,max=10,dive,min=1,max=100"`
+	// Metadata keeps services whose metadata has the given values, as key:value pairs compared as text
+	Metadata []string `query:"metadata" validate:"omitempty,max=10,dive,min=2,max=200,contains=:"`
+}
+
+// MetadataFilter matches services whose metadata has Key set to Value
+type MetadataFilter struct {
+	Key   string
+	Value string
+}
+
+// MetadataFilters parses the metadata query into key/value pairs
+func (q *GetServicesQuery) MetadataFilters() []MetadataFilter {
+	filters := make([]MetadataFilter, 0, len(q.Metadata))
+	for _, pair := range q.Metadata {
+		key, value, _ := strings.Cut(pair, ":")
+		filters = append(filters, MetadataFilter{Key: key, Value: value})
+	}
+	return filters
 }
 
 func (q *GetServicesQuery) Validate() error {
@@ -75,6 +156,18 @@ func (q *GetServicesQuery) Validate() error {
 		defaultOrder := "desc"
 		q.Order = &defaultOrder
 	}
+	if q.Lat != nil && q.WithinKm == nil {
+		defaultWithinKm := 0.0
+		q.WithinKm = &defaultWithinKm
+	}
+
+	for i, filter := range q.MetadataFilters() {
+		if filter.Key == "" {
+			return validation.CustomValidationErrors{
+				{Field: fmt.Sprintf("metadata[%d]", i), Message: "must be in the form key:value"},
+			}
+		}
+	}
 
 	return nil
 }
@@ -100,3 +193,175 @@ func (p *DeleteServiceByIDPayload) Validate() error {
 	validate := validator.New()
 	return validate.Struct(p)
 }
+
+// --------------------------------------------------------------------------
+
+type RestoreServicePayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *RestoreServicePayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetServicePriceQuery struct {
+	ID       uuid.UUID `param:"id" validate:"required,uuid"`
+	Quantity *float64  `query:"quantity" validate:"omitempty,gt=0"`
+}
+
+func (q *GetServicePriceQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Quantity == nil {
+		defaultQuantity := 1.0
+		q.Quantity = &defaultQuantity
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+// ReorderServicesPayload lists every sibling under ParentServiceID (or every root service when nil) in
+// the order they should be displayed
+type ReorderServicesPayload struct {
+	ParentServiceID *uuid.UUID  `json:"parentServiceId" validate:"omitempty,uuid"`
+	ServiceIDs      []uuid.UUID `json:"serviceIds" validate:"required,min=1,max=1000,unique"`
+}
+
+func (p *ReorderServicesPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+// MoveServicePayload re-parents a service; a null parentServiceId moves it to the root
+type MoveServicePayload struct {
+	ID              uuid.UUID  `param:"id" validate:"required,uuid"`
+	ParentServiceID *uuid.UUID `json:"parentServiceId" validate:"omitempty,uuid"`
+}
+
+func (p *MoveServicePayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetServiceTreePayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *GetServiceTreePayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+// ImportServicesPayload is a multipart form; format defaults to the file extension and dryRun
+// validates the file without saving anything
+type ImportServicesPayload struct {
+	File   *multipart.FileHeader `form:"file" validate:"required"`
+	Format *string               `form:"format" validate:"omitempty,oneof=csv json"`
+	DryRun bool                  `form:"dryRun"`
+}
+
+func (p *ImportServicesPayload) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	// Fall back to the file extension when the format is not given explicitly
+	if p.Format == nil {
+		format := strings.TrimPrefix(strings.ToLower(filepath.Ext(p.File.Filename)), ".")
+		if format != "csv" && format != "json" {
+			return validation.CustomValidationErrors{
+				{Field: "format", Message: "is required when the file is not a .csv or .json file"},
+			}
+		}
+		p.Format = &format
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type ExportServicesQuery struct {
+	Format *string `query:"format" validate:"omitempty,oneof=csv json"`
+}
+
+func (q *ExportServicesQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Format == nil {
+		defaultFormat := "csv"
+		q.Format = &defaultFormat
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetServiceVersionsQuery struct {
+	ID    uuid.UUID `param:"id" validate:"required,uuid"`
+	Page  *int      `query:"page" validate:"omitempty,min=1"`
+	Limit *int      `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+func (q *GetServiceVersionsQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+// DiffServiceVersionsQuery compares two versions of a service; either may be the older one
+type DiffServiceVersionsQuery struct {
+	ID   uuid.UUID `param:"id" validate:"required,uuid"`
+	From int       `query:"from" validate:"required,min=1"`
+	To   int       `query:"to" validate:"required,min=1"`
+}
+
+func (q *DiffServiceVersionsQuery) Validate() error {
+	validate := validator.New()
+	return validate.Struct(q)
+}
+
+// --------------------------------------------------------------------------
+
+type RestoreServiceVersionPayload struct {
+	ID      uuid.UUID `param:"id" validate:"required,uuid"`
+	Version int       `param:"version" validate:"required,min=1"`
+}
+
+func (p *RestoreServiceVersionPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
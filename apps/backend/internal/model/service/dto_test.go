@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetServicesQueryLocation(t *testing.T) {
+	lat, lng := 51.5, -0.12
+	far, negative := 201.0, -1.0
+	near := 5.0
+	postalCode, tooShort := "SW1A 1AA", "1"
+
+	tests := []struct {
+		name    string
+		query   GetServicesQuery
+		wantErr bool
+	}{
+		{name: "no location", query: GetServicesQuery{}},
+		{name: "point", query: GetServicesQuery{Lat: &lat, Lng: &lng}},
+		{name: "point with distance", query: GetServicesQuery{Lat: &lat, Lng: &lng, WithinKm: &near}},
+		{name: "postal code", query: GetServicesQuery{PostalCode: &postalCode}},
+		{name: "latitude without longitude", query: GetServicesQuery{Lat: &lat}, wantErr: true},
+		{name: "longitude without latitude", query: GetServicesQuery{Lng: &lng}, wantErr: true},
+		{name: "distance without a point", query: GetServicesQuery{WithinKm: &near}, wantErr: true},
+		{name: "distance too large", query: GetServicesQuery{Lat: &lat, Lng: &lng, WithinKm: &far}, wantErr: true},
+		{name: "negative distance", query: GetServicesQuery{Lat: &lat, Lng: &lng, WithinKm: &negative}, wantErr: true},
+		{name: "postal code too short", query: GetServicesQuery{PostalCode: &tooShort}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetServicesQueryDefaultsDistance(t *testing.T) {
+	lat, lng := 51.5, -0.12
+
+	query := GetServicesQuery{Lat: &lat, Lng: &lng}
+	require.NoError(t, query.Validate())
+	require.NotNil(t, query.WithinKm)
+	assert.Equal(t, 0.0, *query.WithinKm)
+
+	query = GetServicesQuery{}
+	require.NoError(t, query.Validate())
+	assert.Nil(t, query.WithinKm)
+}
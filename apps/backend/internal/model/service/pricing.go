@@ -0,0 +1,217 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/mukundaparajuli/fixr/internal/lib/money"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+// PriceTier prices the units up to and including UpTo. The last tier leaves UpTo empty to cover everything above.
+type PriceTier struct {
+	UpTo       *float64 `json:"upTo" validate:"omitempty,gt=0"`
+	UnitAmount int64    `json:"unitAmount" validate:"min=0"`
+}
+
+type PriceLine struct {
+	Description string      `json:"description"`
+	Quantity    float64     `json:"quantity"`
+	UnitAmount  money.Money `json:"unitAmount"`
+	Amount      money.Money `json:"amount"`
+}
+
+type Price struct {
+	Method    Method      `json:"method"`
+	Quantity  float64     `json:"quantity"`
+	UnitLabel *string     `json:"unitLabel"`
+	Lines     []PriceLine `json:"lines"`
+	Total     money.Money `json:"total"`
+}
+
+// Pricing is everything needed to price a service, detached from storage
+type Pricing struct {
+	Method     Method
+	Rate       money.Money
+	UnitLabel  *string
+	PriceTiers []PriceTier
+}
+
+func (s *Service) Pricing() Pricing {
+	return Pricing{
+		Method:     s.Method,
+		Rate:       money.New(s.Rate, s.Currency),
+		UnitLabel:  s.UnitLabel,
+		PriceTiers: s.PriceTiers,
+	}
+}
+
+// Validate checks that the fields the method depends on are present and consistent
+func (p Pricing) Validate() error {
+	var errors validation.CustomValidationErrors
+
+	switch p.Method {
+	case PerUnit:
+		if p.UnitLabel == nil || *p.UnitLabel == "" {
+			errors = append(errors, validation.CustomValidationError{Field: "unitLabel", Message: "is required for per_unit pricing"})
+		}
+	case Tiered, Volume:
+		if len(p.PriceTiers) == 0 {
+			errors = append(errors, validation.CustomValidationError{Field: "priceTiers", Message: "is required for tiered and volume pricing"})
+			break
+		}
+		for i, tier := range p.PriceTiers {
+			last := i == len(p.PriceTiers)-1
+			if last && tier.UpTo != nil {
+				errors = append(errors, validation.CustomValidationError{Field: "priceTiers", Message: "last tier must not have an upper bound"})
+			}
+			if !last && tier.UpTo == nil {
+				errors = append(errors, validation.CustomValidationError{Field: "priceTiers", Message: "only the last tier may omit its upper bound"})
+			}
+			if i > 0 && tier.UpTo != nil && p.PriceTiers[i-1].UpTo != nil && *tier.UpTo <= *p.PriceTiers[i-1].UpTo {
+				errors = append(errors, validation.CustomValidationError{Field: "priceTiers", Message: "upper bounds must be strictly increasing"})
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors
+	}
+	return nil
+}
+
+// Quote prices the given quantity (hours for hourly, units otherwise; ignored for fixed).
+// Every endpoint that shows or stores a price goes through here.
+func (p Pricing) Quote(quantity float64) (*Price, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if quantity < 0 {
+		return nil, fmt.Errorf("quantity must not be negative, got %v", quantity)
+	}
+
+	price := &Price{
+		Method:    p.Method,
+		Quantity:  quantity,
+		UnitLabel: p.UnitLabel,
+		Lines:     []PriceLine{},
+	}
+
+	switch p.Method {
+	case Fixed:
+		price.Quantity = 1
+		price.Lines = append(price.Lines, PriceLine{Description: "Fixed price", Quantity: 1, UnitAmount: p.Rate, Amount: p.Rate})
+
+	case Hourly:
+		price.Lines = append(price.Lines, PriceLine{Description: "Hours", Quantity: quantity, UnitAmount: p.Rate, Amount: p.Rate.Times(quantity)})
+
+	case PerUnit:
+		price.Lines = append(price.Lines, PriceLine{Description: *p.UnitLabel, Quantity: quantity, UnitAmount: p.Rate, Amount: p.Rate.Times(quantity)})
+
+	case Tiered:
+		// graduated: each tier prices only the units that fall inside it
+		lower := 0.0
+		for _, tier := range p.PriceTiers {
+			if quantity <= lower {
+				break
+			}
+			upper := quantity
+			if tier.UpTo != nil && *tier.UpTo < quantity {
+				upper = *tier.UpTo
+			}
+			units := upper - lower
+			unitAmount := money.New(tier.UnitAmount, p.Rate.Currency)
+			price.Lines = append(price.Lines, PriceLine{
+				Description: tierDescription(lower, tier.UpTo),
+				Quantity:    units,
+				UnitAmount:  unitAmount,
+				Amount:      unitAmount.Times(units),
+			})
+			lower = upper
+		}
+
+	case Volume:
+		// volume: the tier the total quantity lands in prices every unit
+		lower := 0.0
+		for _, tier := range p.PriceTiers {
+			if tier.UpTo == nil || quantity <= *tier.UpTo {
+				unitAmount := money.New(tier.UnitAmount, p.Rate.Currency)
+				price.Lines = append(price.Lines, PriceLine{
+					Description: tierDescription(lower, tier.UpTo),
+					Quantity:    quantity,
+					UnitAmount:  unitAmount,
+					Amount:      unitAmount.Times(quantity),
+				})
+				break
+			}
+			lower = *tier.UpTo
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported pricing method %q", p.Method)
+	}
+
+	total := money.Zero(p.Rate.Currency)
+	for _, line := range price.Lines {
+		total.Amount += line.Amount.Amount
+	}
+	price.Total = total
+
+	return price, nil
+}
+
+func tierDescription(lower float64, upTo *float64) string {
+	if upTo == nil {
+		return fmt.Sprintf("Above %g", lower)
+	}
+	return fmt.Sprintf("%g to %g", lower, *upTo)
+}
+
+// Adjustments are applied on top of a subtotal. Rates are basis points (1250 = 12.5%).
+type Adjustments struct {
+	DiscountRate   int64
+	DiscountAmount int64
+	TaxRate        int64
+}
+
+type Totals struct {
+	Subtotal money.Money `json:"subtotal"`
+	Discount money.Money `json:"discount"`
+	Tax      money.Money `json:"tax"`
+	Total    money.Money `json:"total"`
+}
+
+// ComputeTotals sums the amounts, takes the discount off (never below zero) and taxes what remains
+func ComputeTotals(currency string, amounts []money.Money, adj Adjustments) (*Totals, error) {
+	subtotal := money.Zero(currency)
+	for _, amount := range amounts {
+		sum, err := subtotal.Add(amount)
+		if err != nil {
+			return nil, err
+		}
+		subtotal = sum
+	}
+
+	discount := subtotal.Percent(adj.DiscountRate)
+	discount.Amount += adj.DiscountAmount
+	if discount.Amount > subtotal.Amount {
+		discount.Amount = subtotal.Amount
+	}
+
+	taxable, err := subtotal.Sub(discount)
+	if err != nil {
+		return nil, err
+	}
+	tax := taxable.Percent(adj.TaxRate)
+
+	total, err := taxable.Add(tax)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Totals{
+		Subtotal: subtotal,
+		Discount: discount,
+		Tax:      tax,
+		Total:    total,
+	}, nil
+}
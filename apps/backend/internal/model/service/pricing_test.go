@@ -0,0 +1,234 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/mukundaparajuli/fixr/internal/lib/money"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func upTo(v float64) *float64 {
+	return &v
+}
+
+func label(s string) *string {
+	return &s
+}
+
+// tiers bill 100 per unit up to 10, 80 up to 20 and 50 above that
+var testTiers = []PriceTier{
+	{UpTo: upTo(10), UnitAmount: 100},
+	{UpTo: upTo(20), UnitAmount: 80},
+	{UnitAmount: 50},
+}
+
+func TestPricingValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pricing Pricing
+		wantErr bool
+	}{
+		{name: "fixed", pricing: Pricing{Method: Fixed}},
+		{name: "hourly", pricing: Pricing{Method: Hourly}},
+		{name: "per unit", pricing: Pricing{Method: PerUnit, UnitLabel: label("sq ft")}},
+		{name: "per unit without label", pricing: Pricing{Method: PerUnit}, wantErr: true},
+		{name: "per unit with empty label", pricing: Pricing{Method: PerUnit, UnitLabel: label("")}, wantErr: true},
+		{name: "tiered", pricing: Pricing{Method: Tiered, PriceTiers: testTiers}},
+		{name: "volume", pricing: Pricing{Method: Volume, PriceTiers: testTiers}},
+		{name: "tiered without tiers", pricing: Pricing{Method: Tiered}, wantErr: true},
+		{
+			name:    "bounded last tier",
+			pricing: Pricing{Method: Tiered, PriceTiers: []PriceTier{{UpTo: upTo(10), UnitAmount: 100}}},
+			wantErr: true,
+		},
+		{
+			name:    "unbounded middle tier",
+			pricing: Pricing{Method: Tiered, PriceTiers: []PriceTier{{UnitAmount: 100}, {UnitAmount: 50}}},
+			wantErr: true,
+		},
+		{
+			name: "decreasing bounds",
+			pricing: Pricing{Method: Volume, PriceTiers: []PriceTier{
+				{UpTo: upTo(20), UnitAmount: 100},
+				{UpTo: upTo(10), UnitAmount: 80},
+				{UnitAmount: 50},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.pricing.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPricingQuote(t *testing.T) {
+	tests := []struct {
+		name      string
+		pricing   Pricing
+		quantity  float64
+		wantTotal int64
+		wantLines []PriceLine
+	}{
+		{
+			name:      "fixed ignores the quantity",
+			pricing:   Pricing{Method: Fixed, Rate: money.New(15000, "USD")},
+			quantity:  7,
+			wantTotal: 15000,
+			wantLines: []PriceLine{{Description: "Fixed price", Quantity: 1, UnitAmount: money.New(15000, "USD"), Amount: money.New(15000, "USD")}},
+		},
+		{
+			name:      "hourly with partial hours",
+			pricing:   Pricing{Method: Hourly, Rate: money.New(4000, "USD")},
+			quantity:  1.5,
+			wantTotal: 6000,
+			wantLines: []PriceLine{{Description: "Hours", Quantity: 1.5, UnitAmount: money.New(4000, "USD"), Amount: money.New(6000, "USD")}},
+		},
+		{
+			name:      "hourly rounds to the minor unit",
+			pricing:   Pricing{Method: Hourly, Rate: money.New(999, "USD")},
+			quantity:  0.5,
+			wantTotal: 500,
+			wantLines: []PriceLine{{Description: "Hours", Quantity: 0.5, UnitAmount: money.New(999, "USD"), Amount: money.New(500, "USD")}},
+		},
+		{
+			name:      "per unit",
+			pricing:   Pricing{Method: PerUnit, Rate: money.New(250, "EUR"), UnitLabel: label("sq ft")},
+			quantity:  12,
+			wantTotal: 3000,
+			wantLines: []PriceLine{{Description: "sq ft", Quantity: 12, UnitAmount: money.New(250, "EUR"), Amount: money.New(3000, "EUR")}},
+		},
+		{
+			name:      "tiered inside the first tier",
+			pricing:   Pricing{Method: Tiered, Rate: money.New(0, "USD"), PriceTiers: testTiers},
+			quantity:  5,
+			wantTotal: 500,
+			wantLines: []PriceLine{{Description: "0 to 10", Quantity: 5, UnitAmount: money.New(100, "USD"), Amount: money.New(500, "USD")}},
+		},
+		{
+			name:      "tiered on a tier boundary",
+			pricing:   Pricing{Method: Tiered, Rate: money.New(0, "USD"), PriceTiers: testTiers},
+			quantity:  10,
+			wantTotal: 1000,
+			wantLines: []PriceLine{{Description: "0 to 10", Quantity: 10, UnitAmount: money.New(100, "USD"), Amount: money.New(1000, "USD")}},
+		},
+		{
+			name:      "tiered across every tier",
+			pricing:   Pricing{Method: Tiered, Rate: money.New(0, "USD"), PriceTiers: testTiers},
+			quantity:  25,
+			wantTotal: 2050,
+			wantLines: []PriceLine{
+				{Description: "0 to 10", Quantity: 10, UnitAmount: money.New(100, "USD"), Amount: money.New(1000, "USD")},
+				{Description: "10 to 20", Quantity: 10, UnitAmount: money.New(80, "USD"), Amount: money.New(800, "USD")},
+				{Description: "Above 20", Quantity: 5, UnitAmount: money.New(50, "USD"), Amount: money.New(250, "USD")},
+			},
+		},
+		{
+			name:      "tiered with nothing to price",
+			pricing:   Pricing{Method: Tiered, Rate: money.New(0, "USD"), PriceTiers: testTiers},
+			quantity:  0,
+			wantTotal: 0,
+			wantLines: []PriceLine{},
+		},
+		{
+			name:      "volume on a tier boundary uses that tier",
+			pricing:   Pricing{Method: Volume, Rate: money.New(0, "USD"), PriceTiers: testTiers},
+			quantity:  10,
+			wantTotal: 1000,
+			wantLines: []PriceLine{{Description: "0 to 10", Quantity: 10, UnitAmount: money.New(100, "USD"), Amount: money.New(1000, "USD")}},
+		},
+		{
+			name:      "volume prices every unit at the reached tier",
+			pricing:   Pricing{Method: Volume, Rate: money.New(0, "USD"), PriceTiers: testTiers},
+			quantity:  15,
+			wantTotal: 1200,
+			wantLines: []PriceLine{{Description: "10 to 20", Quantity: 15, UnitAmount: money.New(80, "USD"), Amount: money.New(1200, "USD")}},
+		},
+		{
+			name:      "volume above the last bound",
+			pricing:   Pricing{Method: Volume, Rate: money.New(0, "USD"), PriceTiers: testTiers},
+			quantity:  25,
+			wantTotal: 1250,
+			wantLines: []PriceLine{{Description: "Above 20", Quantity: 25, UnitAmount: money.New(50, "USD"), Amount: money.New(1250, "USD")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := tt.pricing.Quote(tt.quantity)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.pricing.Method, price.Method)
+			assert.Equal(t, tt.pricing.Rate.Currency, price.Total.Currency)
+			assert.Equal(t, tt.wantTotal, price.Total.Amount)
+			assert.Equal(t, tt.wantLines, price.Lines)
+		})
+	}
+}
+
+func TestPricingQuoteErrors(t *testing.T) {
+	_, err := Pricing{Method: Hourly, Rate: money.New(100, "USD")}.Quote(-1)
+	assert.Error(t, err, "negative quantity")
+
+	_, err = Pricing{Method: PerUnit, Rate: money.New(100, "USD")}.Quote(1)
+	assert.Error(t, err, "invalid pricing")
+
+	_, err = Pricing{Method: Method("barter"), Rate: money.New(100, "USD")}.Quote(1)
+	assert.Error(t, err, "unknown method")
+}
+
+func TestComputeTotals(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []int64
+		adj     Adjustments
+		want    Totals
+	}{
+		{
+			name:    "no adjustments",
+			amounts: []int64{6000, 4000},
+			want:    Totals{Subtotal: money.New(10000, "USD"), Discount: money.New(0, "USD"), Tax: money.New(0, "USD"), Total: money.New(10000, "USD")},
+		},
+		{
+			name:    "rate and fixed discount, then tax on the rest",
+			amounts: []int64{10000},
+			adj:     Adjustments{DiscountRate: 1000, DiscountAmount: 500, TaxRate: 825},
+			want:    Totals{Subtotal: money.New(10000, "USD"), Discount: money.New(1500, "USD"), Tax: money.New(701, "USD"), Total: money.New(9201, "USD")},
+		},
+		{
+			name:    "discount never exceeds the subtotal",
+			amounts: []int64{1000},
+			adj:     Adjustments{DiscountAmount: 5000, TaxRate: 1000},
+			want:    Totals{Subtotal: money.New(1000, "USD"), Discount: money.New(1000, "USD"), Tax: money.New(0, "USD"), Total: money.New(0, "USD")},
+		},
+		{
+			name: "no amounts",
+			adj:  Adjustments{TaxRate: 1000},
+			want: Totals{Subtotal: money.New(0, "USD"), Discount: money.New(0, "USD"), Tax: money.New(0, "USD"), Total: money.New(0, "USD")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amounts := []money.Money{}
+			for _, amount := range tt.amounts {
+				amounts = append(amounts, money.New(amount, "USD"))
+			}
+
+			totals, err := ComputeTotals("USD", amounts, tt.adj)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *totals)
+		})
+	}
+
+	_, err := ComputeTotals("USD", []money.Money{money.New(100, "EUR")}, Adjustments{})
+	assert.ErrorIs(t, err, money.ErrCurrencyMismatch)
+}
@@ -16,28 +16,44 @@ const (
 type Method string
 
 const (
-	Hourly Method = "hourly"
+	Hourly  Method = "hourly"
+	Fixed   Method = "fixed"
+	PerUnit Method = "per_unit"
+	Tiered  Method = "tiered"
+	Volume  Method = "volume"
 )
 
-type Metadata struct{}
+// MaxTreeDepth bounds how deep recursive service hierarchy queries descend
+const MaxTreeDepth = 32
+
+// Metadata holds the custom fields of a service. Their shape is defined per category by a JSON Schema
+// (see category.Category.MetadataSchema) and checked whenever a service is written.
+type Metadata map[string]any
 
 type Service struct {
 	model.Base
-	UserID          string     `json:"userId" db:"user_id"`
-	Name            string     `json:"name" db:"name"`
-	Description     *string    `json:"description" db:"description"`
-	Status          Status     `json:"status" db:"status"`
-	Rate            *int       `json:"rate" db:"rate"`
-	Method          Method     `json:"method" db:"method"`
-	ParentServiceID *uuid.UUID `json:"parentServiceId" db:"parent_service_id"`
-	CategoryID      *uuid.UUID `json:"categoryId" db:"category_id"`
-	Metadata        *Metadata  `json:"metadata" db:"metadata"`
-	SortOrder       int        `json:"sortOrder" db:"sort_order"`
+	model.BaseWithDeletedAt
+	UserID          string      `json:"userId" db:"user_id"`
+	Name            string      `json:"name" db:"name"`
+	Description     *string     `json:"description" db:"description"`
+	Status          Status      `json:"status" db:"status"`
+	Rate            int64       `json:"rate" db:"rate"`
+	Currency        string      `json:"currency" db:"currency"`
+	Method          Method      `json:"method" db:"method"`
+	UnitLabel       *string     `json:"unitLabel" db:"unit_label"`
+	PriceTiers      []PriceTier `json:"priceTiers" db:"price_tiers"`
+	DurationMinutes int         `json:"durationMinutes" db:"duration_minutes"`
+	ParentServiceID *uuid.UUID  `json:"parentServiceId" db:"parent_service_id"`
+	CategoryID      *uuid.UUID  `json:"categoryId" db:"category_id"`
+	Metadata        Metadata    `json:"metadata" db:"metadata"`
+	SortOrder       int         `json:"sortOrder" db:"sort_order"`
+	RatingAverage   float64     `json:"ratingAverage" db:"rating_average"`
+	RatingCount     int         `json:"ratingCount" db:"rating_count"`
 }
 
 type PopulatedService struct {
 	Service
-	Category    *category.Category
-	Children    []Service
-	Attachments []ServiceAttachment
+	Category    *category.Category  `json:"category" db:"category"`
+	Children    []Service           `json:"children" db:"children"`
+	Attachments []ServiceAttachment `json:"attachments" db:"attachments"`
 }
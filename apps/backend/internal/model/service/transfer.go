@@ -0,0 +1,88 @@
+package service
+
+import "github.com/mukundaparajuli/fixr/internal/errs"
+
+// MaxImportRows bounds how many services a single import file may hold
+const MaxImportRows = 1000
+
+// ServiceRow is the flat form of a service used by import and export. Parents and categories are
+// referenced by name, so an exported file can be edited by hand and imported into another account.
+type ServiceRow struct {
+	Name            string      `json:"name"`
+	Description     *string     `json:"description,omitempty"`
+	Status          *Status     `json:"status,omitempty"`
+	Rate            *int64      `json:"rate,omitempty"`
+	Currency        *string     `json:"currency,omitempty"`
+	Method          *Method     `json:"method,omitempty"`
+	UnitLabel       *string     `json:"unitLabel,omitempty"`
+	PriceTiers      []PriceTier `json:"priceTiers,omitempty"`
+	DurationMinutes *int        `json:"durationMinutes,omitempty"`
+	Category        *string     `json:"category,omitempty"`
+	Parent          *string     `json:"parent,omitempty"`
+	Metadata        Metadata    `json:"metadata,omitempty"`
+}
+
+// NewServiceRow flattens a service, resolving its category and parent ids to names
+func NewServiceRow(s *Service, categoryName *string, parentName *string) ServiceRow {
+	status := s.Status
+	rate := s.Rate
+	currency := s.Currency
+	method := s.Method
+	duration := s.DurationMinutes
+
+	return ServiceRow{
+		Name:            s.Name,
+		Description:     s.Description,
+		Status:          &status,
+		Rate:            &rate,
+		Currency:        &currency,
+		Method:          &method,
+		UnitLabel:       s.UnitLabel,
+		PriceTiers:      s.PriceTiers,
+		DurationMinutes: &duration,
+		Category:        categoryName,
+		Parent:          parentName,
+		Metadata:        s.Metadata,
+	}
+}
+
+// CreatePayload converts the row into a create payload; the parent and category are resolved by the caller
+func (r *ServiceRow) CreatePayload() *CreateServicePayload {
+	var metadata *Metadata
+	if r.Metadata != nil {
+		metadata = &r.Metadata
+	}
+
+	return &CreateServicePayload{
+		Name:            r.Name,
+		Description:     r.Description,
+		Status:          r.Status,
+		Rate:            r.Rate,
+		Currency:        r.Currency,
+		Method:          r.Method,
+		UnitLabel:       r.UnitLabel,
+		PriceTiers:      r.PriceTiers,
+		DurationMinutes: r.DurationMinutes,
+		Metadata:        metadata,
+	}
+}
+
+// ImportItem is one validated row ready to insert. ParentName and CategoryName point at a service
+// or category created earlier in the same import; otherwise the payload already carries the ids.
+type ImportItem struct {
+	Payload      *CreateServicePayload
+	ParentName   *string
+	CategoryName *string
+}
+
+// ImportResult reports on an import. Row errors use field paths such as "rows[3].rate", where
+// the index is the zero-based position of the row in the file, not counting the CSV header.
+type ImportResult struct {
+	DryRun            bool              `json:"dryRun"`
+	Valid             bool              `json:"valid"`
+	Total             int               `json:"total"`
+	Created           int               `json:"created"`
+	CategoriesCreated []string          `json:"categoriesCreated"`
+	Errors            []errs.FieldError `json:"errors"`
+	Services          []Service         `json:"services"`
+}
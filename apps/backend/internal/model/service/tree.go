@@ -0,0 +1,37 @@
+package service
+
+import "github.com/google/uuid"
+
+type TreeNode struct {
+	Service
+	Depth    int         `json:"depth"`
+	Children []*TreeNode `json:"children"`
+}
+
+// BuildTree nests a flat list holding a root followed by its descendants, as returned by a
+// breadth-first hierarchy query. Sibling order in the list is kept. Returns nil for an empty list.
+func BuildTree(services []Service) *TreeNode {
+	if len(services) == 0 {
+		return nil
+	}
+
+	root := &TreeNode{Service: services[0], Depth: 1, Children: []*TreeNode{}}
+	nodes := map[uuid.UUID]*TreeNode{root.ID: root}
+
+	for _, s := range services[1:] {
+		if s.ParentServiceID == nil {
+			continue
+		}
+
+		parent, ok := nodes[*s.ParentServiceID]
+		if !ok {
+			continue
+		}
+
+		node := &TreeNode{Service: s, Depth: parent.Depth + 1, Children: []*TreeNode{}}
+		parent.Children = append(parent.Children, node)
+		nodes[s.ID] = node
+	}
+
+	return root
+}
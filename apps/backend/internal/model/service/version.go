@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+)
+
+// Version is an immutable snapshot of a service taken after a change to its own fields. Versions
+// are numbered per service from 1; restoring one adds a new version rather than rewriting history.
+type Version struct {
+	model.BaseWithId
+	model.BaseWithCreatedAt
+	ServiceID    uuid.UUID `json:"serviceId" db:"service_id"`
+	UserID       string    `json:"userId" db:"user_id"`
+	Version      int       `json:"version" db:"version"`
+	ActorID      string    `json:"actorId" db:"actor_id"`
+	RequestID    *string   `json:"requestId" db:"request_id"`
+	RestoredFrom *int      `json:"restoredFrom" db:"restored_from"`
+	Snapshot     Service   `json:"snapshot" db:"snapshot"`
+}
+
+// VersionDiff lists the fields that differ between two versions of a service
+type VersionDiff struct {
+	ServiceID uuid.UUID               `json:"serviceId"`
+	From      int                     `json:"from"`
+	To        int                     `json:"to"`
+	Changes   map[string]audit.Change `json:"changes"`
+}
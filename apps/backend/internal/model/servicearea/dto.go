@@ -0,0 +1,49 @@
+package servicearea
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// --------------------------------------------------------------------------
+
+type CreateServiceAreaPayload struct {
+	Name        string   `json:"name" validate:"required,min=1,max=100"`
+	Kind        Kind     `json:"kind" validate:"required,oneof=radius postal_codes"`
+	Latitude    *float64 `json:"latitude" validate:"required_if=Kind radius,excluded_unless=Kind radius,omitempty,latitude"`
+	Longitude   *float64 `json:"longitude" validate:"required_if=Kind radius,excluded_unless=Kind radius,omitempty,longitude"`
+	RadiusKm    *float64 `json:"radiusKm" validate:"required_if=Kind radius,excluded_unless=Kind radius,omitempty,gt=0,max=500"`
+	PostalCodes []string `json:"postalCodes" validate:"required_if=Kind postal_codes,excluded_unless=Kind postal_codes,omitempty,min=1,max=500,dive,min=2,max=12"`
+}
+
+func (p *CreateServiceAreaPayload) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	for i, code := range p.PostalCodes {
+		p.PostalCodes[i] = NormalizePostalCode(code)
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetServiceAreasPayload struct{}
+
+func (p *GetServiceAreasPayload) Validate() error {
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type DeleteServiceAreaPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *DeleteServiceAreaPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
@@ -0,0 +1,102 @@
+package servicearea
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestCreateServiceAreaPayloadValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload CreateServiceAreaPayload
+		wantErr bool
+	}{
+		{
+			name:    "radius",
+			payload: CreateServiceAreaPayload{Name: "Downtown", Kind: KindRadius, Latitude: ptr(51.5), Longitude: ptr(-0.12), RadiusKm: ptr(10.0)},
+		},
+		{
+			name:    "largest radius",
+			payload: CreateServiceAreaPayload{Name: "Region", Kind: KindRadius, Latitude: ptr(51.5), Longitude: ptr(-0.12), RadiusKm: ptr(float64(MaxRadiusKm))},
+		},
+		{
+			name:    "radius too large",
+			payload: CreateServiceAreaPayload{Name: "Country", Kind: KindRadius, Latitude: ptr(51.5), Longitude: ptr(-0.12), RadiusKm: ptr(MaxRadiusKm + 0.1)},
+			wantErr: true,
+		},
+		{
+			name:    "zero radius",
+			payload: CreateServiceAreaPayload{Name: "Nowhere", Kind: KindRadius, Latitude: ptr(51.5), Longitude: ptr(-0.12), RadiusKm: ptr(0.0)},
+			wantErr: true,
+		},
+		{
+			name:    "radius without center",
+			payload: CreateServiceAreaPayload{Name: "Downtown", Kind: KindRadius, RadiusKm: ptr(10.0)},
+			wantErr: true,
+		},
+		{
+			name:    "latitude out of range",
+			payload: CreateServiceAreaPayload{Name: "Downtown", Kind: KindRadius, Latitude: ptr(91.0), Longitude: ptr(-0.12), RadiusKm: ptr(10.0)},
+			wantErr: true,
+		},
+		{
+			name:    "radius with postal codes",
+			payload: CreateServiceAreaPayload{Name: "Downtown", Kind: KindRadius, Latitude: ptr(51.5), Longitude: ptr(-0.12), RadiusKm: ptr(10.0), PostalCodes: []string{"SW1A1AA"}},
+			wantErr: true,
+		},
+		{
+			name:    "postal codes",
+			payload: CreateServiceAreaPayload{Name: "Central", Kind: KindPostalCodes, PostalCodes: []string{"SW1A 1AA", "ec1a-1bb"}},
+		},
+		{
+			name:    "postal codes with a center",
+			payload: CreateServiceAreaPayload{Name: "Central", Kind: KindPostalCodes, Latitude: ptr(51.5), PostalCodes: []string{"SW1A1AA"}},
+			wantErr: true,
+		},
+		{
+			name:    "no postal codes",
+			payload: CreateServiceAreaPayload{Name: "Central", Kind: KindPostalCodes},
+			wantErr: true,
+		},
+		{
+			name:    "postal code too long",
+			payload: CreateServiceAreaPayload{Name: "Central", Kind: KindPostalCodes, PostalCodes: []string{strings.Repeat("1", 13)}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown kind",
+			payload: CreateServiceAreaPayload{Name: "Central", Kind: "polygon"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.payload.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateServiceAreaPayloadNormalizesPostalCodes(t *testing.T) {
+	payload := CreateServiceAreaPayload{Name: "Central", Kind: KindPostalCodes, PostalCodes: []string{" sw1a 1aa ", "ec1a-1bb"}}
+	require.NoError(t, payload.Validate())
+	assert.Equal(t, []string{"SW1A1AA", "EC1A1BB"}, payload.PostalCodes)
+}
+
+func TestNormalizePostalCode(t *testing.T) {
+	assert.Equal(t, "SW1A1AA", NormalizePostalCode("sw1a 1aa"))
+	assert.Equal(t, "12345", NormalizePostalCode(" 12345 "))
+	assert.Equal(t, "123456789", NormalizePostalCode("12345-6789"))
+}
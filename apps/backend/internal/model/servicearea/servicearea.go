@@ -0,0 +1,47 @@
+package servicearea
+
+import (
+	"strings"
+
+	"github.com/mukundaparajuli/fixr/internal/model"
+)
+
+type Kind string
+
+const (
+	KindRadius      Kind = "radius"
+	KindPostalCodes Kind = "postal_codes"
+)
+
+// MaxRadiusKm is the largest radius an area may cover; radius lookups rely on it to bound their index scan
+const MaxRadiusKm = 500
+
+type ServiceArea struct {
+	model.Base
+	UserID      string   `json:"userId" db:"user_id"`
+	Name        string   `json:"name" db:"name"`
+	Kind        Kind     `json:"kind" db:"kind"`
+	Latitude    *float64 `json:"latitude" db:"latitude"`
+	Longitude   *float64 `json:"longitude" db:"longitude"`
+	RadiusKm    *float64 `json:"radiusKm" db:"radius_km"`
+	PostalCodes []string `json:"postalCodes" db:"postal_codes"`
+}
+
+// Location is a customer's position for matching service areas. A point (Lat and Lng, widened by
+// WithinKm) and a postal code may be given together; an area must match both.
+type Location struct {
+	Lat        *float64
+	Lng        *float64
+	WithinKm   *float64
+	PostalCode *string
+}
+
+// NormalizePostalCode uppercases a postal code and drops spaces and dashes so "sw1a 1aa" matches "SW1A1AA"
+func NormalizePostalCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(code)))
+}
@@ -0,0 +1,147 @@
+package storefront
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+// handlePattern mirrors the storefronts_valid_handle check constraint
+var handlePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{2,39}$`)
+
+// --------------------------------------------------------------------------
+
+type UpsertStorefrontPayload struct {
+	Handle      string  `json:"handle" validate:"required,min=3,max=40"`
+	DisplayName string  `json:"displayName" validate:"required,min=1,max=100"`
+	Bio         *string `json:"bio" validate:"omitempty,max=2000"`
+}
+
+func (p *UpsertStorefrontPayload) Validate() error {
+	p.Handle = strings.ToLower(strings.TrimSpace(p.Handle))
+
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	if !handlePattern.MatchString(p.Handle) {
+		return validation.CustomValidationErrors{
+			{Field: "handle", Message: "must start with a letter or digit and contain only lowercase letters, digits and dashes"},
+		}
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetStorefrontPayload struct{}
+
+func (p *GetStorefrontPayload) Validate() error {
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetPublicProviderPayload struct {
+	Handle string `param:"handle" validate:"required,min=3,max=40"`
+}
+
+func (p *GetPublicProviderPayload) Validate() error {
+	p.Handle = strings.ToLower(p.Handle)
+
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+// GetPublicProvidersQuery finds the providers serving a location, across every storefront
+type GetPublicProvidersQuery struct {
+	Lat        *float64 `query:"lat" validate:"required_with=Lng,omitempty,latitude"`
+	Lng        *float64 `query:"lng" validate:"required_with=Lat,omitempty,longitude"`
+	WithinKm   *float64 `query:"withinKm" validate:"excluded_without=Lat,omitempty,min=0,max=200"`
+	PostalCode *string  `query:"postalCode" validate:"omitempty,min=2,max=12"`
+	Page       *int     `query:"page" validate:"omitempty,min=1"`
+	Limit      *int     `query:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+func (q *GetPublicProvidersQuery) Validate() error {
+	validate := validator.New()
+
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	// listing every provider is not a location search
+	if q.Lat == nil && q.PostalCode == nil {
+		return validation.CustomValidationErrors{
+			{Field: "lat", Message: "or postalCode is required"},
+		}
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+	if q.Lat != nil && q.WithinKm == nil {
+		defaultWithinKm := 0.0
+		q.WithinKm = &defaultWithinKm
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetPublicServicesQuery struct {
+	Handle     string     `param:"handle" validate:"required,min=3,max=40"`
+	Page       *int       `query:"page" validate:"omitempty,min=1"`
+	Limit      *int       `query:"limit" validate:"omitempty,min=1,max=50"`
+	CategoryID *uuid.UUID `query:"categoryId" validate:"omitempty,uuid"`
+	Search     *string    `query:"search" validate:"omitempty,min=1"`
+}
+
+func (q *GetPublicServicesQuery) Validate() error {
+	q.Handle = strings.ToLower(q.Handle)
+
+	validate := validator.New()
+
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	// Set defaults for pagination
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetPublicServicePayload struct {
+	Handle string    `param:"handle" validate:"required,min=3,max=40"`
+	ID     uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *GetPublicServicePayload) Validate() error {
+	p.Handle = strings.ToLower(p.Handle)
+
+	validate := validator.New()
+	return validate.Struct(p)
+}
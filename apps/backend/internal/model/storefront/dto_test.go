@@ -0,0 +1,53 @@
+package storefront
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestGetPublicProvidersQueryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   GetPublicProvidersQuery
+		wantErr bool
+	}{
+		{name: "point", query: GetPublicProvidersQuery{Lat: ptr(51.5), Lng: ptr(-0.12)}},
+		{name: "point with distance", query: GetPublicProvidersQuery{Lat: ptr(51.5), Lng: ptr(-0.12), WithinKm: ptr(25.0)}},
+		{name: "postal code", query: GetPublicProvidersQuery{PostalCode: ptr("SW1A 1AA")}},
+		{name: "point and postal code", query: GetPublicProvidersQuery{Lat: ptr(51.5), Lng: ptr(-0.12), PostalCode: ptr("SW1A1AA")}},
+		{name: "no location", query: GetPublicProvidersQuery{}, wantErr: true},
+		{name: "latitude without longitude", query: GetPublicProvidersQuery{Lat: ptr(51.5)}, wantErr: true},
+		{name: "longitude without latitude", query: GetPublicProvidersQuery{Lng: ptr(-0.12), PostalCode: ptr("SW1A1AA")}, wantErr: true},
+		{name: "longitude out of range", query: GetPublicProvidersQuery{Lat: ptr(51.5), Lng: ptr(181.0)}, wantErr: true},
+		{name: "distance without a point", query: GetPublicProvidersQuery{WithinKm: ptr(5.0), PostalCode: ptr("SW1A1AA")}, wantErr: true},
+		{name: "distance too large", query: GetPublicProvidersQuery{Lat: ptr(51.5), Lng: ptr(-0.12), WithinKm: ptr(201.0)}, wantErr: true},
+		{name: "negative distance", query: GetPublicProvidersQuery{Lat: ptr(51.5), Lng: ptr(-0.12), WithinKm: ptr(-1.0)}, wantErr: true},
+		{name: "postal code too short", query: GetPublicProvidersQuery{PostalCode: ptr("1")}, wantErr: true},
+		{name: "limit too large", query: GetPublicProvidersQuery{PostalCode: ptr("SW1A1AA"), Limit: ptr(51)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetPublicProvidersQueryDefaults(t *testing.T) {
+	query := GetPublicProvidersQuery{Lat: ptr(51.5), Lng: ptr(-0.12)}
+	require.NoError(t, query.Validate())
+	assert.Equal(t, 1, *query.Page)
+	assert.Equal(t, 20, *query.Limit)
+	assert.Equal(t, 0.0, *query.WithinKm)
+}
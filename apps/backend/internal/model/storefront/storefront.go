@@ -0,0 +1,105 @@
+package storefront
+
+import (
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/category"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+)
+
+type Storefront struct {
+	model.Base
+	UserID      string  `json:"userId" db:"user_id"`
+	Handle      string  `json:"handle" db:"handle"`
+	DisplayName string  `json:"displayName" db:"display_name"`
+	Bio         *string `json:"bio" db:"bio"`
+}
+
+// The public types below are what unauthenticated customers see. They are built field by field
+// so internal columns (user_id, sort_order, status) never leak when the owning models grow.
+
+type PublicProvider struct {
+	Handle      string  `json:"handle"`
+	DisplayName string  `json:"displayName"`
+	Bio         *string `json:"bio"`
+}
+
+type PublicCategory struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description *string   `json:"description"`
+	Color       string    `json:"color"`
+}
+
+type PublicService struct {
+	ID              uuid.UUID           `json:"id"`
+	Name            string              `json:"name"`
+	Description     *string             `json:"description"`
+	Rate            int64               `json:"rate"`
+	Currency        string              `json:"currency"`
+	Method          service.Method      `json:"method"`
+	UnitLabel       *string             `json:"unitLabel"`
+	PriceTiers      []service.PriceTier `json:"priceTiers"`
+	DurationMinutes int                 `json:"durationMinutes"`
+	RatingAverage   float64             `json:"ratingAverage"`
+	RatingCount     int                 `json:"ratingCount"`
+}
+
+type PublicPopulatedService struct {
+	PublicService
+	Category *PublicCategory `json:"category"`
+	Children []PublicService `json:"children"`
+}
+
+func NewPublicProvider(s *Storefront) *PublicProvider {
+	return &PublicProvider{
+		Handle:      s.Handle,
+		DisplayName: s.DisplayName,
+		Bio:         s.Bio,
+	}
+}
+
+func NewPublicCategory(c *category.Category) *PublicCategory {
+	if c == nil {
+		return nil
+	}
+
+	return &PublicCategory{
+		ID:          c.ID,
+		Name:        c.Name,
+		Description: c.Description,
+		Color:       c.Color,
+	}
+}
+
+func NewPublicService(s *service.Service) PublicService {
+	return PublicService{
+		ID:              s.ID,
+		Name:            s.Name,
+		Description:     s.Description,
+		Rate:            s.Rate,
+		Currency:        s.Currency,
+		Method:          s.Method,
+		UnitLabel:       s.UnitLabel,
+		PriceTiers:      s.PriceTiers,
+		DurationMinutes: s.DurationMinutes,
+		RatingAverage:   s.RatingAverage,
+		RatingCount:     s.RatingCount,
+	}
+}
+
+// NewPublicPopulatedService converts a populated service, dropping children that are not active
+func NewPublicPopulatedService(s *service.PopulatedService) PublicPopulatedService {
+	children := make([]PublicService, 0, len(s.Children))
+	for i := range s.Children {
+		if s.Children[i].Status == service.Active {
+			children = append(children, NewPublicService(&s.Children[i]))
+		}
+	}
+
+	return PublicPopulatedService{
+		PublicService: NewPublicService(&s.Service),
+		Category:      NewPublicCategory(s.Category),
+		Children:      children,
+	}
+}
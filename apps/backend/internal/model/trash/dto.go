@@ -0,0 +1,9 @@
+package trash
+
+// --------------------------------------------------------------------------
+
+type GetTrashPayload struct{}
+
+func (p *GetTrashPayload) Validate() error {
+	return nil
+}
@@ -0,0 +1,26 @@
+package trash
+
+import (
+	"github.com/mukundaparajuli/fixr/internal/model/category"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+)
+
+// Trash lists what a user can still restore. Services are listed by the root of each trashed
+// subtree; attachments only when trashed on their own, since the rest come back with their service.
+type Trash struct {
+	Services    []service.Service           `json:"services"`
+	Categories  []category.Category         `json:"categories"`
+	Attachments []service.ServiceAttachment `json:"attachments"`
+	// RetentionDays is how long after deletedAt an item is purged for good
+	RetentionDays int `json:"retentionDays"`
+}
+
+// PurgeResult counts the rows removed by one purge run and the blobs left to delete.
+// RetainedServices were due but kept because bookings still refer to them.
+type PurgeResult struct {
+	Services         int64
+	RetainedServices int64
+	Categories       int64
+	Attachments      int64
+	DownloadKeys     []string
+}
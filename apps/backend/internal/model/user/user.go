@@ -0,0 +1,91 @@
+package user
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type User struct {
+	ID             string    `json:"id" db:"id"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time `json:"updatedAt" db:"updated_at"`
+	Email          *string   `json:"email" db:"email"`
+	Username       *string   `json:"username" db:"username"`
+	FirstName      *string   `json:"firstName" db:"first_name"`
+	LastName       *string   `json:"lastName" db:"last_name"`
+	ImageURL       *string   `json:"imageUrl" db:"image_url"`
+	ClerkUpdatedAt time.Time `json:"-" db:"clerk_updated_at"`
+	// WelcomeEnqueuedAt is set once the welcome email is queued
+	WelcomeEnqueuedAt *time.Time `json:"-" db:"welcome_enqueued_at"`
+}
+
+// DeleteResult counts the rows removed with a user and the attachment blobs left to delete.
+// RetainedServices were booked by customers and moved to the trash instead.
+type DeleteResult struct {
+	Services         int64
+	RetainedServices int64
+	Categories       int64
+	Attachments      int64
+	DownloadKeys     []string
+}
+
+// Clerk webhook event types handled by the user sync
+const (
+	ClerkUserCreated = "user.created"
+	ClerkUserUpdated = "user.updated"
+	ClerkUserDeleted = "user.deleted"
+)
+
+// ClerkEvent is the envelope of a Clerk webhook; Data depends on Type
+type ClerkEvent struct {
+	Type   string          `json:"type"`
+	Object string          `json:"object"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// ClerkUser is the subset of Clerk's user object kept locally. Timestamps are Unix milliseconds.
+type ClerkUser struct {
+	ID                    string  `json:"id"`
+	Username              *string `json:"username"`
+	FirstName             *string `json:"first_name"`
+	LastName              *string `json:"last_name"`
+	ImageURL              *string `json:"image_url"`
+	PrimaryEmailAddressID *string `json:"primary_email_address_id"`
+	EmailAddresses        []struct {
+		ID           string `json:"id"`
+		EmailAddress string `json:"email_address"`
+	} `json:"email_addresses"`
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// ClerkDeletedUser is the data of a user.deleted event
+type ClerkDeletedUser struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// PrimaryEmail returns the user's primary email address, if any
+func (u *ClerkUser) PrimaryEmail() *string {
+	if u.PrimaryEmailAddressID == nil {
+		return nil
+	}
+	for _, address := range u.EmailAddresses {
+		if address.ID == *u.PrimaryEmailAddressID {
+			return &address.EmailAddress
+		}
+	}
+	return nil
+}
+
+// ToUser maps the Clerk object onto the local profile
+func (u *ClerkUser) ToUser() *User {
+	return &User{
+		ID:             u.ID,
+		Email:          u.PrimaryEmail(),
+		Username:       u.Username,
+		FirstName:      u.FirstName,
+		LastName:       u.LastName,
+		ImageURL:       u.ImageURL,
+		ClerkUpdatedAt: time.UnixMilli(u.UpdatedAt),
+	}
+}
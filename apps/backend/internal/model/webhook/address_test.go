@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "93.184.216.34", want: true},
+		{addr: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{addr: "127.0.0.1", want: false},
+		{addr: "::1", want: false},
+		{addr: "10.0.0.5", want: false},
+		{addr: "172.16.3.4", want: false},
+		{addr: "192.168.1.1", want: false},
+		{addr: "169.254.169.254", want: false},
+		{addr: "fe80::1", want: false},
+		{addr: "fd00::1", want: false},
+		{addr: "0.0.0.0", want: false},
+		{addr: "::", want: false},
+		{addr: "224.0.0.1", want: false},
+		{addr: "100.64.0.1", want: false},
+		{addr: "255.255.255.255", want: false},
+		{addr: "::ffff:127.0.0.1", want: false},
+		{addr: "::ffff:93.184.216.34", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestCheckHostLiteral(t *testing.T) {
+	assert.NoError(t, CheckHost(context.Background(), net.DefaultResolver, "93.184.216.34"))
+	assert.ErrorIs(t, CheckHost(context.Background(), net.DefaultResolver, "169.254.169.254"), ErrBlockedAddress)
+}
+
+func TestDialControl(t *testing.T) {
+	assert.NoError(t, DialControl("tcp4", "93.184.216.34:443", nil))
+	assert.ErrorIs(t, DialControl("tcp4", "127.0.0.1:8080", nil), ErrBlockedAddress)
+	assert.ErrorIs(t, DialControl("tcp6", "[::1]:443", nil), ErrBlockedAddress)
+
+	err := DialControl("tcp", "not-an-address", nil)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrBlockedAddress)
+}
+
+func TestValidateEndpointURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://hooks.example.com/fixr"},
+		{url: "http://93.184.216.34:8080/hook"},
+		{url: "ftp://hooks.example.com", wantErr: true},
+		{url: "https://", wantErr: true},
+		{url: "http://localhost:3000/hook", wantErr: true},
+		{url: "http://api.localhost/hook", wantErr: true},
+		{url: "http://127.0.0.1/hook", wantErr: true},
+		{url: "http://[::1]/hook", wantErr: true},
+		{url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{url: "https://10.1.2.3/hook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			err := validateEndpointURL(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package webhook
+
+import (
+	"net/netip"
+	"net/url"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+// --------------------------------------------------------------------------
+
+type CreateEndpointPayload struct {
+	URL         string      `json:"url" validate:"required,url,max=2048"`
+	Description *string     `json:"description" validate:"omitempty,max=500"`
+	Events      []EventType `json:"events" validate:"required,min=1,max=20,dive,oneof=service.created service.updated service.deleted booking.created booking.confirmed booking.declined booking.cancelled booking.rescheduled booking.completed"`
+}
+
+func (p *CreateEndpointPayload) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	return validateEndpointURL(p.URL)
+}
+
+// --------------------------------------------------------------------------
+
+type UpdateEndpointPayload struct {
+	ID          uuid.UUID    `param:"id" validate:"required,uuid"`
+	URL         *string      `json:"url" validate:"omitempty,url,max=2048"`
+	Description *string      `json:"description" validate:"omitempty,max=500"`
+	Events      *[]EventType `json:"events" validate:"omitempty,min=1,max=20,dive,oneof=service.created service.updated service.deleted booking.created booking.confirmed booking.declined booking.cancelled booking.rescheduled booking.completed"`
+	Active      *bool        `json:"active"`
+}
+
+func (p *UpdateEndpointPayload) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(p); err != nil {
+		return err
+	}
+
+	if p.URL != nil {
+		return validateEndpointURL(*p.URL)
+	}
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetEndpointsPayload struct{}
+
+func (p *GetEndpointsPayload) Validate() error {
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type GetEndpointByIDPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *GetEndpointByIDPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type DeleteEndpointPayload struct {
+	ID uuid.UUID `param:"id" validate:"required,uuid"`
+}
+
+func (p *DeleteEndpointPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// --------------------------------------------------------------------------
+
+type GetDeliveriesQuery struct {
+	ID     uuid.UUID       `param:"id" validate:"required,uuid"`
+	Page   *int            `query:"page" validate:"omitempty,min=1"`
+	Limit  *int            `query:"limit" validate:"omitempty,min=1,max=100"`
+	Status *DeliveryStatus `query:"status" validate:"omitempty,oneof=pending succeeded failed"`
+}
+
+func (q *GetDeliveriesQuery) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(q); err != nil {
+		return err
+	}
+
+	if q.Page == nil {
+		defaultPage := 1
+		q.Page = &defaultPage
+	}
+	if q.Limit == nil {
+		defaultLimit := 20
+		q.Limit = &defaultLimit
+	}
+
+	return nil
+}
+
+// --------------------------------------------------------------------------
+
+type RedeliverPayload struct {
+	ID         uuid.UUID `param:"id" validate:"required,uuid"`
+	DeliveryID uuid.UUID `param:"deliveryId" validate:"required,uuid"`
+}
+
+func (p *RedeliverPayload) Validate() error {
+	validate := validator.New()
+	return validate.Struct(p)
+}
+
+// validateEndpointURL only allows plain http(s) URLs; anything else cannot receive a POST. Hosts
+// that are obviously internal are refused here, the service resolves the rest before saving.
+func validateEndpointURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Hostname() == "" {
+		return validation.CustomValidationErrors{
+			{Field: "url", Message: "must be an http or https URL"},
+		}
+	}
+
+	host := parsed.Hostname()
+	if addr, err := netip.ParseAddr(host); (err == nil && !IsPublicAddr(addr)) || isLocalHostname(host) {
+		return validation.CustomValidationErrors{
+			{Field: "url", Message: "must point at a publicly reachable host"},
+		}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model"
+)
+
+type EventType string
+
+const (
+	EventServiceCreated     EventType = "service.created"
+	EventServiceUpdated     EventType = "service.updated"
+	EventServiceDeleted     EventType = "service.deleted"
+	EventBookingCreated     EventType = "booking.created"
+	EventBookingConfirmed   EventType = "booking.confirmed"
+	EventBookingDeclined    EventType = "booking.declined"
+	EventBookingCancelled   EventType = "booking.cancelled"
+	EventBookingRescheduled EventType = "booking.rescheduled"
+	EventBookingCompleted   EventType = "booking.completed"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Headers sent with every delivery. The signature is "sha256=" followed by the hex HMAC-SHA256
+// of "<timestamp>.<body>" keyed with the endpoint secret.
+const (
+	HeaderEvent     = "X-Fixr-Event"
+	HeaderDelivery  = "X-Fixr-Delivery"
+	HeaderTimestamp = "X-Fixr-Timestamp"
+	HeaderSignature = "X-Fixr-Signature"
+)
+
+const secretPrefix = "whsec_"
+
+type Endpoint struct {
+	model.Base
+	UserID      string      `json:"userId" db:"user_id"`
+	URL         string      `json:"url" db:"url"`
+	Description *string     `json:"description" db:"description"`
+	Secret      string      `json:"-" db:"secret"`
+	Events      []EventType `json:"events" db:"events"`
+	Active      bool        `json:"active" db:"active"`
+}
+
+// CreatedEndpoint is returned when an endpoint is created, the only time its secret is shown
+type CreatedEndpoint struct {
+	Endpoint
+	Secret string `json:"secret"`
+}
+
+type Delivery struct {
+	model.Base
+	EndpointID     uuid.UUID       `json:"endpointId" db:"endpoint_id"`
+	UserID         string          `json:"userId" db:"user_id"`
+	EventID        uuid.UUID       `json:"eventId" db:"event_id"`
+	EventType      EventType       `json:"eventType" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         DeliveryStatus  `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	ResponseStatus *int            `json:"responseStatus" db:"response_status"`
+	ResponseBody   *string         `json:"responseBody" db:"response_body"`
+	Error          *string         `json:"error" db:"error"`
+	DeliveredAt    *time.Time      `json:"deliveredAt" db:"delivered_at"`
+	RedeliveryOf   *uuid.UUID      `json:"redeliveryOf" db:"redelivery_of"`
+}
+
+// Event is the body of a delivery
+type Event struct {
+	ID        uuid.UUID `json:"id"`
+	Type      EventType `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+	Data      any       `json:"data"`
+}
+
+// NewSecret returns a random signing secret for an endpoint
+func NewSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// Sign returns the signature header value for a body sent at the given time
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/model/apikey"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+// apiKeyLastUsedResolution limits how often using a key rewrites its last_used_at
+const apiKeyLastUsedResolution = time.Minute
+
+type APIKeyRepository struct {
+	server *server.Server
+}
+
+func NewAPIKeyRepository(s *server.Server) *APIKeyRepository {
+	return &APIKeyRepository{server: s}
+}
+
+func (r *APIKeyRepository) CreateAPIKey(ctx context.Context, userID string, createdBy string, prefix string, keyHash string, payload *apikey.CreateAPIKeyPayload) (*apikey.APIKey, error) {
+	stmt := `
+		INSERT INTO
+			api_keys (
+				user_id,
+				created_by,
+				name,
+				prefix,
+				key_hash,
+				scopes,
+				expires_at
+			)
+		VALUES
+			(
+				@user_id,
+				@created_by,
+				@name,
+				@prefix,
+				@key_hash,
+				@scopes,
+				@expires_at
+			)
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":    userID,
+		"created_by": createdBy,
+		"name":       payload.Name,
+		"prefix":     prefix,
+		"key_hash":   keyHash,
+		"scopes":     payload.Scopes,
+		"expires_at": payload.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create api key query for user_id=%s: %w", userID, err)
+	}
+
+	key, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[apikey.APIKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:api_keys for user_id=%s: %w", userID, err)
+	}
+
+	return &key, nil
+}
+
+func (r *APIKeyRepository) GetAPIKeys(ctx context.Context, userID string) ([]apikey.APIKey, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			api_keys
+		WHERE
+			user_id=@user_id
+		ORDER BY
+			created_at DESC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get api keys query for user_id=%s: %w", userID, err)
+	}
+
+	keys, err := pgx.CollectRows(rows, pgx.RowToStructByName[apikey.APIKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:api_keys for user_id=%s: %w", userID, err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey revokes a key; revoking an already revoked key keeps its original revoked_at
+func (r *APIKeyRepository) RevokeAPIKey(ctx context.Context, userID string, keyID uuid.UUID) (*apikey.APIKey, error) {
+	stmt := `
+		UPDATE api_keys
+		SET
+			revoked_at=COALESCE(revoked_at, CURRENT_TIMESTAMP)
+		WHERE
+			id=@id
+			AND user_id=@user_id
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      keyID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute revoke api key query for id=%s, user_id=%s: %w", keyID, userID, err)
+	}
+
+	key, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[apikey.APIKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:api_keys for id=%s, user_id=%s: %w", keyID, userID, err)
+	}
+
+	return &key, nil
+}
+
+// GetActiveAPIKeyByHash returns the usable key with the given hash and records that it was used
+func (r *APIKeyRepository) GetActiveAPIKeyByHash(ctx context.Context, keyHash string) (*apikey.APIKey, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			api_keys
+		WHERE
+			key_hash=@key_hash
+			AND revoked_at IS NULL
+			AND (
+				expires_at IS NULL
+				OR expires_at > CURRENT_TIMESTAMP
+			)
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"key_hash": keyHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get api key by hash query: %w", err)
+	}
+
+	key, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[apikey.APIKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:api_keys by hash: %w", err)
+	}
+
+	if key.LastUsedAt == nil || time.Since(*key.LastUsedAt) > apiKeyLastUsedResolution {
+		_, err := r.server.DB.Pool.Exec(ctx, `
+			UPDATE api_keys
+			SET
+				last_used_at=CURRENT_TIMESTAMP
+			WHERE
+				id=@id
+		`, pgx.NamedArgs{
+			"id": key.ID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record api key use for id=%s: %w", key.ID, err)
+		}
+	}
+
+	return &key, nil
+}
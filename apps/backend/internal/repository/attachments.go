@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type AttachmentRepository struct {
+	server *server.Server
+}
+
+func NewAttachmentRepository(s *server.Server) *AttachmentRepository {
+	return &AttachmentRepository{server: s}
+}
+
+func (r *AttachmentRepository) CreateAttachment(
+	ctx context.Context,
+	serviceID uuid.UUID,
+	userID string,
+	uploadedBy string,
+	name string,
+	downloadKey string,
+	fileSize int64,
+	mimeType string,
+) (*service.ServiceAttachment, error) {
+	stmt := `
+		INSERT INTO
+			services_attachment (
+				service_id,
+				name,
+				uploaded_by,
+				download_key,
+				file_size,
+				mime_type
+			)
+		VALUES
+			(
+				@service_id,
+				@name,
+				@uploaded_by,
+				@download_key,
+				@file_size,
+				@mime_type
+			)
+		RETURNING
+			*
+	`
+
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for service_id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
+		"service_id":   serviceID,
+		"name":         name,
+		"uploaded_by":  uploadedBy,
+		"download_key": downloadKey,
+		"file_size":    fileSize,
+		"mime_type":    mimeType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create attachment query for service_id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	attachment, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.ServiceAttachment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:attachments for service_id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityAttachment, attachment.ID, audit.ActionCreated, nil, attachment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for service_id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) GetAttachmentByID(ctx context.Context, userID string, serviceID uuid.UUID, attachmentID uuid.UUID) (*service.ServiceAttachment, error) {
+	stmt := `
+		SELECT
+			a.*
+		FROM
+			services_attachment a
+			JOIN services s ON s.id=a.service_id
+		WHERE
+			a.id=@id
+			AND a.service_id=@service_id
+			AND s.user_id=@user_id
+			AND a.deleted_at IS NULL
+			AND s.deleted_at IS NULL
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":         attachmentID,
+		"service_id": serviceID,
+		"user_id":    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get attachment by id query for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+
+	attachment, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.ServiceAttachment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:attachments for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) GetServiceAttachments(ctx context.Context, userID string, serviceID uuid.UUID) ([]service.ServiceAttachment, error) {
+	stmt := `
+		SELECT
+			a.*
+		FROM
+			services_attachment a
+			JOIN services s ON s.id=a.service_id
+		WHERE
+			a.service_id=@service_id
+			AND s.user_id=@user_id
+			AND a.deleted_at IS NULL
+			AND s.deleted_at IS NULL
+		ORDER BY
+			a.created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"service_id": serviceID,
+		"user_id":    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get service attachments query for service_id=%s: %w", serviceID, err)
+	}
+
+	attachments, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.ServiceAttachment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:attachments for service_id=%s: %w", serviceID, err)
+	}
+
+	return attachments, nil
+}
+
+// DeleteAttachment moves an attachment to the trash; its blob is kept until the purge job runs
+func (r *AttachmentRepository) DeleteAttachment(ctx context.Context, userID string, serviceID uuid.UUID, attachmentID uuid.UUID) (*service.ServiceAttachment, error) {
+	stmt := `
+		UPDATE services_attachment a
+		SET
+			deleted_at=CURRENT_TIMESTAMP
+		FROM
+			services s
+		WHERE
+			s.id=a.service_id
+			AND a.id=@id
+			AND a.service_id=@service_id
+			AND s.user_id=@user_id
+			AND a.deleted_at IS NULL
+			AND s.deleted_at IS NULL
+		RETURNING
+			a.*
+	`
+
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
+		"id":         attachmentID,
+		"service_id": serviceID,
+		"user_id":    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute delete attachment query for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+
+	attachment, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.ServiceAttachment])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "ATTACHMENT_NOT_FOUND"
+			return nil, errs.NewNotFoundError("attachment not found", false, &code)
+		}
+		return nil, fmt.Errorf("failed to collect row from table:attachments for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+
+	before := attachment
+	before.DeletedAt = nil
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityAttachment, attachmentID, audit.ActionDeleted, before, attachment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+
+	return &attachment, nil
+}
+
+// RestoreAttachment takes an attachment out of the trash; attachments of a trashed service come back
+// with the service instead
+func (r *AttachmentRepository) RestoreAttachment(ctx context.Context, userID string, serviceID uuid.UUID, attachmentID uuid.UUID) (*service.ServiceAttachment, error) {
+	stmt := `
+		UPDATE services_attachment a
+		SET
+			deleted_at=NULL
+		FROM
+			services s,
+			services_attachment old
+		WHERE
+			s.id=a.service_id
+			AND old.id=a.id
+			AND a.id=@id
+			AND a.service_id=@service_id
+			AND s.user_id=@user_id
+			AND a.deleted_at IS NOT NULL
+			AND s.deleted_at IS NULL
+		RETURNING
+			a.*,
+			old.deleted_at AS trashed_at
+	`
+
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
+		"id":         attachmentID,
+		"service_id": serviceID,
+		"user_id":    userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute restore attachment query for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+
+	restored, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[struct {
+		service.ServiceAttachment
+		TrashedAt time.Time `db:"trashed_at"`
+	}])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "TRASHED_ATTACHMENT_NOT_FOUND"
+			return nil, errs.NewNotFoundError("Attachment not found in trash", false, &code)
+		}
+		return nil, fmt.Errorf("failed to collect row from table:attachments for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+
+	attachment := restored.ServiceAttachment
+	before := attachment
+	before.DeletedAt = &restored.TrashedAt
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityAttachment, attachmentID, audit.ActionRestored, before, attachment); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for id=%s, service_id=%s: %w", attachmentID, serviceID, err)
+	}
+
+	return &attachment, nil
+}
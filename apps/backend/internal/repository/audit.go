@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type AuditRepository struct {
+	server *server.Server
+}
+
+func NewAuditRepository(s *server.Server) *AuditRepository {
+	return &AuditRepository{server: s}
+}
+
+func (r *AuditRepository) GetAuditEvents(ctx context.Context, userID string, query *audit.GetAuditEventsQuery) (*model.PaginatedResponse[audit.Event], error) {
+	args := pgx.NamedArgs{
+		"user_id": userID,
+	}
+	conditions := []string{"user_id=@user_id"}
+
+	if query.EntityType != nil {
+		conditions = append(conditions, "entity_type=@entity_type")
+		args["entity_type"] = *query.EntityType
+	}
+
+	if query.EntityID != nil {
+		conditions = append(conditions, "entity_id=@entity_id")
+		args["entity_id"] = *query.EntityID
+	}
+
+	if query.Action != nil {
+		conditions = append(conditions, "action=@action")
+		args["action"] = *query.Action
+	}
+
+	if query.ActorID != nil {
+		conditions = append(conditions, "actor_id=@actor_id")
+		args["actor_id"] = *query.ActorID
+	}
+
+	if query.RequestID != nil {
+		conditions = append(conditions, "request_id=@request_id")
+		args["request_id"] = *query.RequestID
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	err := r.server.DB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM audit_events"+where, args).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of audit events for user_id=%s: %w", userID, err)
+	}
+
+	stmt := "SELECT * FROM audit_events" + where
+	if *query.Order == "asc" {
+		stmt += " ORDER BY created_at ASC, id ASC"
+	} else {
+		stmt += " ORDER BY created_at DESC, id DESC"
+	}
+	stmt += " LIMIT @limit OFFSET @offset"
+	args["limit"] = *query.Limit
+	args["offset"] = (*query.Page - 1) * (*query.Limit)
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get audit events query for user_id=%s: %w", userID, err)
+	}
+
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[audit.Event])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:audit_events for user_id=%s: %w", userID, err)
+	}
+
+	return &model.PaginatedResponse[audit.Event]{
+		Data:       events,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
+
+// recordAuditEvent appends an audit event inside the caller's transaction, so the event commits or
+// rolls back together with the change it describes. before and after are the entity as returned by
+// the API (nil for a create or a purge); only the fields that differ are stored.
+func recordAuditEvent(
+	ctx context.Context,
+	tx pgx.Tx,
+	userID string,
+	entityType audit.EntityType,
+	entityID uuid.UUID,
+	action audit.Action,
+	before any,
+	after any,
+) error {
+	actor := audit.ActorFromContext(ctx)
+
+	var requestID *string
+	if actor.RequestID != "" {
+		requestID = &actor.RequestID
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO
+			audit_events (
+				user_id,
+				actor_id,
+				request_id,
+				entity_type,
+				entity_id,
+				action,
+				changes
+			)
+		VALUES
+			(
+				@user_id,
+				@actor_id,
+				@request_id,
+				@entity_type,
+				@entity_id,
+				@action,
+				audit_diff(@before::JSONB, @after::JSONB)
+			)
+	`, pgx.NamedArgs{
+		"user_id":     userID,
+		"actor_id":    actor.ID,
+		"request_id":  requestID,
+		"entity_type": entityType,
+		"entity_id":   entityID,
+		"action":      action,
+		"before":      before,
+		"after":       after,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record %s %s audit event for id=%s, user_id=%s: %w", entityType, action, entityID, userID, err)
+	}
+
+	return nil
+}
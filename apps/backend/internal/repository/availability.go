@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model/availability"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+// DefaultTimezone is assumed for providers that never saved a schedule
+const DefaultTimezone = "UTC"
+
+type AvailabilityRepository struct {
+	server *server.Server
+}
+
+func NewAvailabilityRepository(s *server.Server) *AvailabilityRepository {
+	return &AvailabilityRepository{server: s}
+}
+
+func (r *AvailabilityRepository) GetTimezone(ctx context.Context, userID string) (string, error) {
+	stmt := `
+		SELECT
+			timezone
+		FROM
+			availability_settings
+		WHERE
+			user_id=@user_id
+	`
+
+	var timezone string
+	err := r.server.DB.Pool.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	}).Scan(&timezone)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return DefaultTimezone, nil
+		}
+		return "", fmt.Errorf("failed to get availability timezone for user_id=%s: %w", userID, err)
+	}
+
+	return timezone, nil
+}
+
+func (r *AvailabilityRepository) GetRules(ctx context.Context, userID string) ([]availability.Rule, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			availability_rules
+		WHERE
+			user_id=@user_id
+		ORDER BY
+			weekday ASC,
+			start_minute ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get availability rules query for user_id=%s: %w", userID, err)
+	}
+
+	rules, err := pgx.CollectRows(rows, pgx.RowToStructByName[availability.Rule])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:availability_rules for user_id=%s: %w", userID, err)
+	}
+
+	return rules, nil
+}
+
+// GetOverrides returns the overrides dated between from and to, both inclusive
+func (r *AvailabilityRepository) GetOverrides(ctx context.Context, userID string, from time.Time, to time.Time) ([]availability.Override, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			availability_overrides
+		WHERE
+			user_id=@user_id
+			AND date BETWEEN @from AND @to
+		ORDER BY
+			date ASC,
+			start_minute ASC NULLS FIRST
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+		"from":    from.Format(time.DateOnly),
+		"to":      to.Format(time.DateOnly),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get availability overrides query for user_id=%s: %w", userID, err)
+	}
+
+	overrides, err := pgx.CollectRows(rows, pgx.RowToStructByName[availability.Override])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:availability_overrides for user_id=%s: %w", userID, err)
+	}
+
+	return overrides, nil
+}
+
+// ReplaceWeeklySchedule stores the timezone and swaps the whole set of weekly rules in one transaction
+func (r *AvailabilityRepository) ReplaceWeeklySchedule(ctx context.Context, userID string, timezone string, rules []availability.RulePayload) error {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for user_id=%s: %w", userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO
+			availability_settings (user_id, timezone)
+		VALUES
+			(@user_id, @timezone)
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			timezone=EXCLUDED.timezone
+	`, pgx.NamedArgs{
+		"user_id":  userID,
+		"timezone": timezone,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert availability settings for user_id=%s: %w", userID, err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM availability_rules WHERE user_id=@user_id`, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete availability rules for user_id=%s: %w", userID, err)
+	}
+
+	for _, rule := range rules {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO
+				availability_rules (user_id, weekday, start_minute, end_minute)
+			VALUES
+				(@user_id, @weekday, @start_minute, @end_minute)
+		`, pgx.NamedArgs{
+			"user_id":      userID,
+			"weekday":      int(rule.Weekday),
+			"start_minute": int(rule.StartTime),
+			"end_minute":   int(rule.EndTime),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert availability rule for user_id=%s: %w", userID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction for user_id=%s: %w", userID, err)
+	}
+
+	return nil
+}
+
+// ReplaceOverridesForDate swaps every override on date. An empty window list stores a blackout row.
+func (r *AvailabilityRepository) ReplaceOverridesForDate(ctx context.Context, userID string, date time.Time, windows []availability.WindowPayload) error {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for user_id=%s: %w", userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	day := date.Format(time.DateOnly)
+
+	_, err = tx.Exec(ctx, `DELETE FROM availability_overrides WHERE user_id=@user_id AND date=@date`, pgx.NamedArgs{
+		"user_id": userID,
+		"date":    day,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete availability overrides for user_id=%s, date=%s: %w", userID, day, err)
+	}
+
+	insert := `
+		INSERT INTO
+			availability_overrides (user_id, date, start_minute, end_minute)
+		VALUES
+			(@user_id, @date, @start_minute, @end_minute)
+	`
+
+	if len(windows) == 0 {
+		_, err = tx.Exec(ctx, insert, pgx.NamedArgs{
+			"user_id":      userID,
+			"date":         day,
+			"start_minute": nil,
+			"end_minute":   nil,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert availability blackout for user_id=%s, date=%s: %w", userID, day, err)
+		}
+	}
+
+	for _, w := range windows {
+		_, err = tx.Exec(ctx, insert, pgx.NamedArgs{
+			"user_id":      userID,
+			"date":         day,
+			"start_minute": int(w.StartTime),
+			"end_minute":   int(w.EndTime),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to insert availability override for user_id=%s, date=%s: %w", userID, day, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction for user_id=%s: %w", userID, err)
+	}
+
+	return nil
+}
+
+func (r *AvailabilityRepository) DeleteOverridesForDate(ctx context.Context, userID string, date time.Time) error {
+	day := date.Format(time.DateOnly)
+
+	result, err := r.server.DB.Pool.Exec(ctx, `DELETE FROM availability_overrides WHERE user_id=@user_id AND date=@date`, pgx.NamedArgs{
+		"user_id": userID,
+		"date":    day,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete availability overrides for user_id=%s, date=%s: %w", userID, day, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		code := "AVAILABILITY_OVERRIDE_NOT_FOUND"
+		return errs.NewNotFoundError("no availability override for this date", false, &code)
+	}
+
+	return nil
+}
@@ -0,0 +1,296 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/booking"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type BookingRepository struct {
+	server *server.Server
+}
+
+func NewBookingRepository(s *server.Server) *BookingRepository {
+	return &BookingRepository{server: s}
+}
+
+func (r *BookingRepository) CreateBooking(ctx context.Context, customerID string, providerID string, payload *booking.CreateBookingPayload) (*booking.Booking, error) {
+	stmt := `
+		INSERT INTO
+			bookings (
+				service_id,
+				provider_id,
+				customer_id,
+				starts_at,
+				ends_at,
+				notes
+			)
+		VALUES
+			(
+				@service_id,
+				@provider_id,
+				@customer_id,
+				@starts_at,
+				@ends_at,
+				@notes
+			)
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"service_id":  payload.ServiceID,
+		"provider_id": providerID,
+		"customer_id": customerID,
+		"starts_at":   payload.StartsAt,
+		"ends_at":     payload.EndsAt,
+		"notes":       payload.Notes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create booking query for service_id=%s, customer_id=%s: %w", payload.ServiceID, customerID, err)
+	}
+
+	bookingItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[booking.Booking])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:bookings for service_id=%s, customer_id=%s: %w", payload.ServiceID, customerID, err)
+	}
+
+	return &bookingItem, nil
+}
+
+// GetBookingByID returns a booking the caller takes part in, either as its customer (the signed-in
+// user) or as its provider (the account owning the booked service)
+func (r *BookingRepository) GetBookingByID(ctx context.Context, customerID string, providerID string, bookingID uuid.UUID) (*booking.Booking, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			bookings
+		WHERE
+			id=@id
+			AND (
+				provider_id=@provider_id
+				OR customer_id=@customer_id
+			)
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":          bookingID,
+		"customer_id": customerID,
+		"provider_id": providerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get booking by id query for id=%s, customer_id=%s, provider_id=%s: %w", bookingID, customerID, providerID, err)
+	}
+
+	bookingItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[booking.Booking])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:bookings for id=%s, customer_id=%s, provider_id=%s: %w", bookingID, customerID, providerID, err)
+	}
+
+	return &bookingItem, nil
+}
+
+func (r *BookingRepository) GetBookings(ctx context.Context, customerID string, providerID string, query *booking.GetBookingsQuery) (*model.PaginatedResponse[booking.Booking], error) {
+	args := pgx.NamedArgs{
+		"customer_id": customerID,
+		"provider_id": providerID,
+	}
+
+	conditions := []string{}
+	switch {
+	case query.Role != nil && *query.Role == "provider":
+		conditions = append(conditions, "provider_id = @provider_id")
+	case query.Role != nil && *query.Role == "customer":
+		conditions = append(conditions, "customer_id = @customer_id")
+	default:
+		conditions = append(conditions, "(provider_id = @provider_id OR customer_id = @customer_id)")
+	}
+
+	if query.Status != nil {
+		conditions = append(conditions, "status = @status")
+		args["status"] = *query.Status
+	}
+
+	if query.ServiceID != nil {
+		conditions = append(conditions, "service_id = @service_id")
+		args["service_id"] = *query.ServiceID
+	}
+
+	if query.From != nil {
+		conditions = append(conditions, "ends_at > @from")
+		args["from"] = *query.From
+	}
+
+	if query.To != nil {
+		conditions = append(conditions, "starts_at < @to")
+		args["to"] = *query.To
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.server.DB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM bookings"+where, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to get total count for bookings customer_id=%s, provider_id=%s: %w", customerID, providerID, err)
+	}
+
+	stmt := "SELECT * FROM bookings" + where
+	if query.Order != nil && *query.Order == "desc" {
+		stmt += " ORDER BY starts_at DESC"
+	} else {
+		stmt += " ORDER BY starts_at ASC"
+	}
+
+	stmt += " LIMIT @limit OFFSET @offset"
+	args["limit"] = *query.Limit
+	args["offset"] = (*query.Page - 1) * (*query.Limit)
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get bookings query for customer_id=%s, provider_id=%s: %w", customerID, providerID, err)
+	}
+
+	bookings, err := pgx.CollectRows(rows, pgx.RowToStructByName[booking.Booking])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:bookings for customer_id=%s, provider_id=%s: %w", customerID, providerID, err)
+	}
+
+	return &model.PaginatedResponse[booking.Booking]{
+		Data:       bookings,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
+
+// UpdateBookingStatus moves a booking from one status to another. The update only applies while the
+// booking is still in the expected status, so concurrent transitions cannot overwrite each other.
+func (r *BookingRepository) UpdateBookingStatus(
+	ctx context.Context,
+	bookingID uuid.UUID,
+	from booking.Status,
+	to booking.Status,
+	reason *string,
+) (*booking.Booking, error) {
+	stmt := `
+		UPDATE bookings
+		SET
+			status=@to,
+			status_reason=@reason
+		WHERE
+			id=@id
+			AND status=@from
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":     bookingID,
+		"from":   from,
+		"to":     to,
+		"reason": reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update booking status query for id=%s: %w", bookingID, err)
+	}
+
+	bookingItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[booking.Booking])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "BOOKING_STATUS_CHANGED"
+			return nil, errs.NewConflictError("Booking was modified by someone else, please retry", false, &code)
+		}
+		return nil, fmt.Errorf("failed to collect row from table:bookings for id=%s: %w", bookingID, err)
+	}
+
+	return &bookingItem, nil
+}
+
+// RescheduleBooking moves a booking to a new time slot and puts it back to pending
+func (r *BookingRepository) RescheduleBooking(
+	ctx context.Context,
+	bookingID uuid.UUID,
+	from booking.Status,
+	startsAt time.Time,
+	endsAt time.Time,
+	reason *string,
+) (*booking.Booking, error) {
+	stmt := `
+		UPDATE bookings
+		SET
+			status='pending',
+			starts_at=@starts_at,
+			ends_at=@ends_at,
+			status_reason=@reason
+		WHERE
+			id=@id
+			AND status=@from
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":        bookingID,
+		"from":      from,
+		"starts_at": startsAt,
+		"ends_at":   endsAt,
+		"reason":    reason,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute reschedule booking query for id=%s: %w", bookingID, err)
+	}
+
+	bookingItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[booking.Booking])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "BOOKING_STATUS_CHANGED"
+			return nil, errs.NewConflictError("Booking was modified by someone else, please retry", false, &code)
+		}
+		return nil, fmt.Errorf("failed to collect row from table:bookings for id=%s: %w", bookingID, err)
+	}
+
+	return &bookingItem, nil
+}
+
+// GetProviderBookingsInRange returns the provider's pending and confirmed bookings that overlap [from, to)
+func (r *BookingRepository) GetProviderBookingsInRange(ctx context.Context, providerID string, from time.Time, to time.Time) ([]booking.Booking, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			bookings
+		WHERE
+			provider_id=@provider_id
+			AND status IN ('pending', 'confirmed')
+			AND starts_at < @to
+			AND ends_at > @from
+		ORDER BY
+			starts_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"provider_id": providerID,
+		"from":        from,
+		"to":          to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get provider bookings in range query for provider_id=%s: %w", providerID, err)
+	}
+
+	bookings, err := pgx.CollectRows(rows, pgx.RowToStructByName[booking.Booking])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:bookings for provider_id=%s: %w", providerID, err)
+	}
+
+	return bookings, nil
+}
@@ -2,12 +2,17 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
 	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
 	"github.com/mukundaparajuli/fixr/internal/model/category"
 	"github.com/mukundaparajuli/fixr/internal/server"
 )
@@ -28,27 +33,36 @@ func (r *CategoryRepository) CreateCategory(
 
 	stmt := `
 		INSERT INTO 
-			service_categories(
+			services_categories(
 				user_id,
 				name,
 				description,
-				color
+				color,
+				metadata_schema
 			)
 		VALUES
 			(
 				@user_id,
 				@name,
 				@description,
-				@color
+				@color,
+				@metadata_schema
 			)	
 		RETURNING
 		*
 	`
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
-		"user_id":     UserID,
-		"name":        payload.Name,
-		"description": payload.Description,
-		"color":       payload.Color,
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for user_id=%s, name=%s : %w", UserID, payload.Name, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":         UserID,
+		"name":            payload.Name,
+		"description":     payload.Description,
+		"color":           payload.Color,
+		"metadata_schema": nullableJSON(payload.MetadataSchema),
 	})
 
 	if err != nil {
@@ -60,6 +74,14 @@ func (r *CategoryRepository) CreateCategory(
 		return nil, fmt.Errorf("failed to execute create category query for user_id=%s, name=%s :%w", UserID, payload.Name, err)
 	}
 
+	if err := recordAuditEvent(ctx, tx, UserID, audit.EntityCategory, categoryItem.ID, audit.ActionCreated, nil, categoryItem); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for user_id=%s, name=%s : %w", UserID, payload.Name, err)
+	}
+
 	return &categoryItem, nil
 }
 
@@ -70,10 +92,11 @@ func (r *CategoryRepository) GetCategoryByID(
 ) (*category.Category, error) {
 	stmt := `
 		SELECT *
-		FROM service_categories
+		FROM services_categories
 		WHERE
-			id = @id,
-			user_id = @user_id
+			id = @id
+			AND user_id = @user_id
+			AND deleted_at IS NULL
 	`
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
 		"id":      categoryID,
@@ -85,24 +108,95 @@ func (r *CategoryRepository) GetCategoryByID(
 	}
 
 	categoryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[category.Category])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:category for user_id=%s, category_id=%s : %w", userID, categoryID, err)
+	}
+
 	return &categoryItem, nil
 }
 
-func (r *CategoryRepository) GetCategories(ctx context.Context, userID string, query category.GetCategoriesQuery) (*model.PaginatedResponse[category.Category], error) {
+// GetMetadataSchema returns the metadata schema of a live category, or nil when it has none or is trashed
+func (r *CategoryRepository) GetMetadataSchema(ctx context.Context, userID string, categoryID uuid.UUID) (json.RawMessage, error) {
+	stmt := `
+		SELECT metadata_schema
+		FROM services_categories
+		WHERE
+			id = @id
+			AND user_id = @user_id
+			AND deleted_at IS NULL
+	`
+	var schema json.RawMessage
+	err := r.server.DB.Pool.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"id":      categoryID,
+		"user_id": userID,
+	}).Scan(&schema)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get metadata schema query for user_id=%s, category_id=%s : %w", userID, categoryID, err)
+	}
+
+	return schema, nil
+}
+
+// GetAllCategories returns every live category of a user, unpaginated, for resolving names on import and export
+func (r *CategoryRepository) GetAllCategories(ctx context.Context, userID string) ([]category.Category, error) {
 	stmt := `
 		SELECT *
-		FROM service_categories
-		WHERE user_id=@user_id
+		FROM services_categories
+		WHERE
+			user_id = @user_id
+			AND deleted_at IS NULL
+		ORDER BY name ASC
+	`
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get all categories query for user_id=%s : %w", userID, err)
+	}
+
+	categories, err := pgx.CollectRows(rows, pgx.RowToStructByName[category.Category])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:category for user_id=%s : %w", userID, err)
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) GetCategories(ctx context.Context, userID string, query *category.GetCategoriesQuery) (*model.PaginatedResponse[category.CategoryWithServiceCounts], error) {
+	stmt := `
+		SELECT
+			c.*,
+			COUNT(s.id) FILTER (
+				WHERE
+					s.status = 'active'
+			) AS active_service_count,
+			COUNT(s.id) FILTER (
+				WHERE
+					s.status = 'inactive'
+			) AS inactive_service_count
+		FROM
+			services_categories c
+			LEFT JOIN services s ON s.category_id = c.id
+			AND s.user_id = c.user_id
+			AND s.deleted_at IS NULL
+		WHERE
+			c.user_id = @user_id
+			AND c.deleted_at IS NULL
 	`
 	args := pgx.NamedArgs{
 		"user_id": userID,
 	}
 
 	if query.Search != nil {
-		stmt += `AND name ILIKE '%s' || @search || '%s'`
+		stmt += ` AND c.name ILIKE '%' || @search || '%'`
 		args["search"] = *query.Search
 	}
 
+	stmt += ` GROUP BY c.id`
+
 	sortColumn := "name"
 	if query.Sort != nil {
 		sortColumn = *query.Sort
@@ -113,7 +207,7 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string, q
 		sortOrder = *query.Order
 	}
 
-	stmt += fmt.Sprintf(`AND ORDER BY %s %s`, sortColumn, sortOrder)
+	stmt += fmt.Sprintf(` ORDER BY c.%s %s`, sortColumn, sortOrder)
 
 	stmt += ` LIMIT @limit OFFSET @offset`
 	args["limit"] = *query.Limit
@@ -124,7 +218,7 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string, q
 		return nil, fmt.Errorf("failed to execute get categories query for user_id=%s : %w", userID, err)
 	}
 
-	categories, err := pgx.CollectRows(rows, pgx.RowToStructByName[category.Category])
+	categories, err := pgx.CollectRows(rows, pgx.RowToStructByName[category.CategoryWithServiceCounts])
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute get categories query for user_id=%s : %w", userID, err)
 	}
@@ -134,9 +228,10 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string, q
 		SELECT
 			COUNT(*)
 		FROM
-			service_categories
+			services_categories
 		WHERE
 			user_id=@user_id
+			AND deleted_at IS NULL
 	`
 
 	countArgs := pgx.NamedArgs{
@@ -154,7 +249,7 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string, q
 		return nil, fmt.Errorf("failed to get total count of categories for user_id=%s: %w", userID, err)
 	}
 
-	return &model.PaginatedResponse[category.Category]{
+	return &model.PaginatedResponse[category.CategoryWithServiceCounts]{
 		Data:       categories,
 		Page:       *query.Page,
 		Limit:      *query.Limit,
@@ -164,7 +259,7 @@ func (r *CategoryRepository) GetCategories(ctx context.Context, userID string, q
 }
 
 func (r *CategoryRepository) UpdateCategory(ctx context.Context, userID string, categoryID uuid.UUID, payload *category.UpdateCategoryPayload) (*category.Category, error) {
-	stmt := `UPDATE service_categories SET`
+	stmt := `UPDATE services_categories SET `
 	args := pgx.NamedArgs{
 		"id":      categoryID,
 		"user_id": userID,
@@ -187,43 +282,162 @@ func (r *CategoryRepository) UpdateCategory(ctx context.Context, userID string,
 		args["color"] = *payload.Color
 	}
 
+	if len(payload.MetadataSchema) > 0 {
+		setClauses = append(setClauses, "metadata_schema = @metadata_schema")
+		args["metadata_schema"] = nullableJSON(payload.MetadataSchema)
+	}
+
 	if len(setClauses) == 0 {
-		return nil, fmt.Errorf("no field to update")
+		return nil, errs.NewBadRequestError("no fields to update", false, nil, nil, nil)
 	}
 
-	stmt += strings.Join(setClauses, ",")
-	stmt += `WHERE id = @id AND user_id=%s RETURNING *`
+	stmt += strings.Join(setClauses, ", ")
+	stmt += ` WHERE id = @id AND user_id = @user_id AND deleted_at IS NULL RETURNING *`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for category_id = %s, user_id = %s : %w", categoryID, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT *
+		FROM services_categories
+		WHERE
+			id = @id
+			AND user_id = @user_id
+			AND deleted_at IS NULL
+		FOR UPDATE
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute lock category query for category_id = %s, user_id = %s : %w", categoryID, userID, err)
+	}
+
+	before, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[category.Category])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:category for category_id = %s, user_id = %s : %w", categoryID, userID, err)
+	}
+
+	rows, err = tx.Query(ctx, stmt, args)
 	if err != nil {
 		return nil, fmt.Errorf("failed to executed update category query for category_id = %s, user_id = %s : %w", categoryID, userID, err)
 	}
 
 	updatedCategory, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[category.Category])
 	if err != nil {
-		return nil, fmt.Errorf("failed to executed update category query for category_id = %s, user_id = %s : %w", categoryID, userID, err)
+		return nil, fmt.Errorf("failed to collect row from table:category for category_id = %s, user_id = %s : %w", categoryID, userID, err)
+	}
+
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityCategory, categoryID, audit.ActionUpdated, before, updatedCategory); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for category_id = %s, user_id = %s : %w", categoryID, userID, err)
 	}
 
 	return &updatedCategory, nil
 }
 
+// DeleteCategory moves a category to the trash. Its services keep category_id and simply stop
+// showing the category until it is restored; the purge job lets ON DELETE SET NULL clear it for good.
 func (r *CategoryRepository) DeleteCategory(ctx context.Context, userID string, categoryID uuid.UUID) error {
 	stmt := `
-		DELETE FROM service_categories
-		WHERE id=@id AND user_id=@user_id 
+		UPDATE services_categories
+		SET deleted_at=CURRENT_TIMESTAMP
+		WHERE id=@id AND user_id=@user_id AND deleted_at IS NULL
+		RETURNING *
 	`
-	result, err := r.server.DB.Pool.Exec(ctx, stmt, pgx.NamedArgs{
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for category_id=%s, user_id=%s: %w", categoryID, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
 		"id":      categoryID,
 		"user_id": userID,
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to execute delete category query for category_id=%s, user_id=%s", categoryID, userID)
+		return fmt.Errorf("failed to execute delete category query for category_id=%s, user_id=%s: %w", categoryID, userID, err)
 	}
 
-	if result.RowsAffected() == 0 {
-		return fmt.Errorf("category not found")
+	trashed, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[category.Category])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "CATEGORY_NOT_FOUND"
+			return errs.NewNotFoundError("category not found", false, &code)
+		}
+		return fmt.Errorf("failed to collect row from table:category for category_id=%s, user_id=%s: %w", categoryID, userID, err)
+	}
+
+	before := trashed
+	before.DeletedAt = nil
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityCategory, categoryID, audit.ActionDeleted, before, trashed); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction for category_id=%s, user_id=%s: %w", categoryID, userID, err)
 	}
 
 	return nil
 }
+
+func (r *CategoryRepository) RestoreCategory(ctx context.Context, userID string, categoryID uuid.UUID) (*category.Category, error) {
+	// old is the row as it was before the update, which gives the deleted_at for the audit event
+	stmt := `
+		UPDATE services_categories c
+		SET deleted_at=NULL
+		FROM services_categories old
+		WHERE c.id=@id AND c.user_id=@user_id AND c.deleted_at IS NOT NULL AND old.id=c.id
+		RETURNING c.*, old.deleted_at AS trashed_at
+	`
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for category_id=%s, user_id=%s: %w", categoryID, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      categoryID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute restore category query for category_id=%s, user_id=%s: %w", categoryID, userID, err)
+	}
+
+	restored, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[struct {
+		category.Category
+		TrashedAt time.Time `db:"trashed_at"`
+	}])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "TRASHED_CATEGORY_NOT_FOUND"
+			return nil, errs.NewNotFoundError("Category not found in trash", false, &code)
+		}
+		return nil, fmt.Errorf("failed to collect row from table:category for category_id=%s, user_id=%s: %w", categoryID, userID, err)
+	}
+
+	categoryItem := restored.Category
+	before := categoryItem
+	before.DeletedAt = &restored.TrashedAt
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityCategory, categoryID, audit.ActionRestored, before, categoryItem); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for category_id=%s, user_id=%s: %w", categoryID, userID, err)
+	}
+
+	return &categoryItem, nil
+}
+
+// nullableJSON maps an absent or null JSON document to SQL NULL
+func nullableJSON(raw json.RawMessage) any {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	return string(raw)
+}
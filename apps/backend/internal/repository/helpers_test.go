@@ -0,0 +1,54 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	testhelpers "github.com/mukundaparajuli/fixr/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testMaxDepth is the hierarchy depth limit used by the repository tests
+const testMaxDepth = 3
+
+func setupServiceRepository(t *testing.T) (*server.Server, *repository.ServiceRepository) {
+	t.Helper()
+
+	_, testServer, cleanup := testhelpers.SetupTest(t)
+	t.Cleanup(cleanup)
+
+	return testServer, repository.NewServiceRepository(testServer)
+}
+
+// createService inserts a service named name, under parentID when set
+func createService(t *testing.T, repo *repository.ServiceRepository, userID string, name string, parentID *uuid.UUID) *service.Service {
+	t.Helper()
+
+	payload := &service.CreateServicePayload{Name: name, ParentServiceID: parentID}
+	require.NoError(t, payload.Validate())
+
+	created, err := repo.CreateService(context.Background(), userID, payload, testMaxDepth)
+	require.NoError(t, err)
+
+	return created
+}
+
+// assertErrorCode checks that err is an HTTP error carrying code
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var httpErr *errs.HTTPError
+	require.True(t, errors.As(err, &httpErr), "expected an HTTP error, got %v", err)
+	assert.Equal(t, code, httpErr.Code)
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/invoice"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type InvoiceRepository struct {
+	server *server.Server
+}
+
+func NewInvoiceRepository(s *server.Server) *InvoiceRepository {
+	return &InvoiceRepository{server: s}
+}
+
+const populatedInvoiceSelect = `
+	SELECT
+		i.*,
+		COALESCE(
+			(
+				SELECT
+					jsonb_agg(
+						to_jsonb(camel (ii))
+						ORDER BY
+							ii.sort_order ASC
+					)
+				FROM
+					invoice_items ii
+				WHERE
+					ii.invoice_id=i.id
+			),
+			'[]'::JSONB
+		) AS items
+	FROM
+		invoices i
+`
+
+// CreateInvoice draws the provider's next invoice number and stores the invoice with its items.
+// The sequence row stays locked until commit, so numbers are handed out without gaps or duplicates.
+func (r *InvoiceRepository) CreateInvoice(ctx context.Context, inv *invoice.Invoice, items []invoice.Item) (*invoice.PopulatedInvoice, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for user_id=%s: %w", inv.UserID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var number int64
+	err = tx.QueryRow(ctx, `
+		INSERT INTO
+			invoice_sequences (user_id, last_number)
+		VALUES
+			(@user_id, 1)
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			last_number=invoice_sequences.last_number + 1
+		RETURNING
+			last_number
+	`, pgx.NamedArgs{
+		"user_id": inv.UserID,
+	}).Scan(&number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate invoice number for user_id=%s: %w", inv.UserID, err)
+	}
+
+	var invoiceID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO
+			invoices (
+				user_id,
+				customer_id,
+				number,
+				booking_id,
+				quote_id,
+				currency,
+				subtotal,
+				discount_amount,
+				tax_rate,
+				tax_amount,
+				total,
+				notes,
+				due_at
+			)
+		VALUES
+			(
+				@user_id,
+				@customer_id,
+				@number,
+				@booking_id,
+				@quote_id,
+				@currency,
+				@subtotal,
+				@discount_amount,
+				@tax_rate,
+				@tax_amount,
+				@total,
+				@notes,
+				@due_at
+			)
+		RETURNING
+			id
+	`, pgx.NamedArgs{
+		"user_id":         inv.UserID,
+		"customer_id":     inv.CustomerID,
+		"number":          number,
+		"booking_id":      inv.BookingID,
+		"quote_id":        inv.QuoteID,
+		"currency":        inv.Currency,
+		"subtotal":        inv.Subtotal,
+		"discount_amount": inv.DiscountAmount,
+		"tax_rate":        inv.TaxRate,
+		"tax_amount":      inv.TaxAmount,
+		"total":           inv.Total,
+		"notes":           inv.Notes,
+		"due_at":          inv.DueAt,
+	}).Scan(&invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create invoice query for user_id=%s: %w", inv.UserID, err)
+	}
+
+	for i, item := range items {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO
+				invoice_items (
+					invoice_id,
+					service_id,
+					description,
+					method,
+					quantity,
+					unit_label,
+					amount,
+					lines,
+					sort_order
+				)
+			VALUES
+				(
+					@invoice_id,
+					@service_id,
+					@description,
+					@method,
+					@quantity,
+					@unit_label,
+					@amount,
+					@lines,
+					@sort_order
+				)
+		`, pgx.NamedArgs{
+			"invoice_id":  invoiceID,
+			"service_id":  item.ServiceID,
+			"description": item.Description,
+			"method":      item.Method,
+			"quantity":    item.Quantity,
+			"unit_label":  item.UnitLabel,
+			"amount":      item.Amount,
+			"lines":       item.Lines,
+			"sort_order":  i,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert invoice item for invoice_id=%s: %w", invoiceID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for invoice_id=%s: %w", invoiceID, err)
+	}
+
+	return r.GetInvoiceByID(ctx, inv.UserID, invoiceID)
+}
+
+// GetInvoiceByID returns the invoice when the user issued it or is the billed customer
+func (r *InvoiceRepository) GetInvoiceByID(ctx context.Context, userID string, invoiceID uuid.UUID) (*invoice.PopulatedInvoice, error) {
+	stmt := populatedInvoiceSelect + `
+		WHERE
+			i.id=@id
+			AND (
+				i.user_id=@user_id
+				OR i.customer_id=@user_id
+			)
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      invoiceID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get invoice by id query for id=%s, user_id=%s: %w", invoiceID, userID, err)
+	}
+
+	invoiceItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[invoice.PopulatedInvoice])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:invoices for id=%s, user_id=%s: %w", invoiceID, userID, err)
+	}
+
+	return &invoiceItem, nil
+}
+
+func (r *InvoiceRepository) GetInvoices(ctx context.Context, userID string, query *invoice.GetInvoicesQuery) (*model.PaginatedResponse[invoice.Invoice], error) {
+	args := pgx.NamedArgs{
+		"user_id": userID,
+	}
+
+	where := " WHERE user_id = @user_id"
+	if query.Status != nil {
+		where += " AND status = @status"
+		args["status"] = *query.Status
+	}
+
+	var total int
+	if err := r.server.DB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM invoices"+where, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to get total count for invoices user_id=%s: %w", userID, err)
+	}
+
+	stmt := "SELECT * FROM invoices" + where + " ORDER BY number DESC LIMIT @limit OFFSET @offset"
+	args["limit"] = *query.Limit
+	args["offset"] = (*query.Page - 1) * (*query.Limit)
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get invoices query for user_id=%s: %w", userID, err)
+	}
+
+	invoices, err := pgx.CollectRows(rows, pgx.RowToStructByName[invoice.Invoice])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:invoices for user_id=%s: %w", userID, err)
+	}
+
+	return &model.PaginatedResponse[invoice.Invoice]{
+		Data:       invoices,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
+
+// UpdateInvoiceStatus moves an invoice between statuses and stamps the matching timestamp,
+// guarded on the expected current status like bookings and quotes
+func (r *InvoiceRepository) UpdateInvoiceStatus(ctx context.Context, invoiceID uuid.UUID, from invoice.Status, to invoice.Status) (*invoice.Invoice, error) {
+	stmt := `
+		UPDATE invoices
+		SET
+			status=@to,
+			sent_at=CASE
+				WHEN @to='sent' THEN CURRENT_TIMESTAMP
+				ELSE sent_at
+			END,
+			paid_at=CASE
+				WHEN @to='paid' THEN CURRENT_TIMESTAMP
+				ELSE paid_at
+			END,
+			voided_at=CASE
+				WHEN @to='void' THEN CURRENT_TIMESTAMP
+				ELSE voided_at
+			END
+		WHERE
+			id=@id
+			AND status=@from
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":   invoiceID,
+		"from": from,
+		"to":   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update invoice status query for id=%s: %w", invoiceID, err)
+	}
+
+	invoiceItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[invoice.Invoice])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "INVOICE_STATUS_CHANGED"
+			return nil, errs.NewConflictError("Invoice was modified by someone else, please retry", false, &code)
+		}
+		return nil, fmt.Errorf("failed to collect row from table:invoices for id=%s: %w", invoiceID, err)
+	}
+
+	return &invoiceItem, nil
+}
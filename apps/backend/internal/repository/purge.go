@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+)
+
+// servicePurge is what purgeServices removed, and what it had to leave in place
+type servicePurge struct {
+	Services     int64
+	Attachments  int64
+	Retained     int64
+	DownloadKeys []string
+}
+
+// purgeServices permanently deletes the services matching serviceCond, their attachments, and any
+// other attachment matching attachmentCond. Both conditions are SQL over the row aliased s (services)
+// and a (services_attachment) respectively, with their parameters in args; args must also carry
+// @actor_id. A service whose subtree has ever been booked is retained, since bookings and reviews keep
+// pointing at it, and so are its ancestors. Every removed row gets a "purged" audit event carrying its
+// last state; joining the table back in reads the rows as they were before the DELETE. The attachment
+// blob keys are returned for the caller to delete after commit.
+func purgeServices(ctx context.Context, tx pgx.Tx, serviceCond string, attachmentCond string, args pgx.NamedArgs) (*servicePurge, error) {
+	args["max_depth"] = service.MaxTreeDepth
+
+	rows, err := tx.Query(ctx, `
+		WITH RECURSIVE
+			candidates AS (
+				SELECT
+					s.id
+				FROM
+					services s
+				WHERE
+					`+serviceCond+`
+			),
+			subtree AS (
+				SELECT
+					id AS root_id,
+					id,
+					1 AS depth
+				FROM
+					candidates
+				UNION ALL
+				SELECT
+					subtree.root_id,
+					child.id,
+					subtree.depth + 1
+				FROM
+					services child
+					JOIN subtree ON child.parent_service_id=subtree.id
+				WHERE
+					subtree.depth <= @max_depth
+			)
+		SELECT
+			c.id,
+			EXISTS (
+				SELECT
+					1
+				FROM
+					subtree t
+					JOIN bookings b ON b.service_id=t.id
+				WHERE
+					t.root_id=c.id
+			) AS booked
+		FROM
+			candidates c
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute select purgeable services query: %w", err)
+	}
+
+	serviceIDs := []uuid.UUID{}
+	var retained int64
+	var serviceID uuid.UUID
+	var booked bool
+	_, err = pgx.ForEachRow(rows, []any{&serviceID, &booked}, func() error {
+		if booked {
+			retained++
+		} else {
+			serviceIDs = append(serviceIDs, serviceID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services: %w", err)
+	}
+
+	args["service_ids"] = serviceIDs
+
+	rows, err = tx.Query(ctx, `
+		WITH
+			purged AS (
+				DELETE FROM services_attachment a
+				WHERE
+					a.service_id=ANY (@service_ids)
+					OR (`+attachmentCond+`)
+				RETURNING
+					id,
+					service_id,
+					download_key
+			),
+			recorded AS (
+				INSERT INTO
+					audit_events (user_id, actor_id, entity_type, entity_id, action, changes)
+				SELECT
+					s.user_id,
+					@actor_id,
+					'attachment',
+					a.id,
+					'purged',
+					audit_diff(camel (a), NULL)
+				FROM
+					purged p
+					JOIN services_attachment a ON a.id=p.id
+					JOIN services s ON s.id=p.service_id
+			)
+		SELECT
+			download_key
+		FROM
+			purged
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute purge attachments query: %w", err)
+	}
+
+	downloadKeys, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:attachments: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		WITH
+			purged AS (
+				DELETE FROM services
+				WHERE
+					id=ANY (@service_ids)
+				RETURNING
+					id
+			)
+		INSERT INTO
+			audit_events (user_id, actor_id, entity_type, entity_id, action, changes)
+		SELECT
+			s.user_id,
+			@actor_id,
+			'service',
+			s.id,
+			'purged',
+			audit_diff(camel (s), NULL)
+		FROM
+			purged
+			JOIN services s ON s.id=purged.id
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute purge services query: %w", err)
+	}
+
+	return &servicePurge{
+		Services:     result.RowsAffected(),
+		Attachments:  int64(len(downloadKeys)),
+		Retained:     retained,
+		DownloadKeys: downloadKeys,
+	}, nil
+}
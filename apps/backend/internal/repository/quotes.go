@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/quote"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type QuoteRepository struct {
+	server *server.Server
+}
+
+func NewQuoteRepository(s *server.Server) *QuoteRepository {
+	return &QuoteRepository{server: s}
+}
+
+const populatedQuoteSelect = `
+	SELECT
+		q.*,
+		COALESCE(
+			(
+				SELECT
+					jsonb_agg(
+						to_jsonb(camel (qi))
+						ORDER BY
+							qi.sort_order ASC
+					)
+				FROM
+					quote_items qi
+				WHERE
+					qi.quote_id=q.id
+			),
+			'[]'::JSONB
+		) AS items
+	FROM
+		quotes q
+`
+
+// CreateQuote stores the quote together with its line items in one transaction
+func (r *QuoteRepository) CreateQuote(ctx context.Context, q *quote.Quote, items []quote.Item) (*quote.PopulatedQuote, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for user_id=%s: %w", q.UserID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	var quoteID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		INSERT INTO
+			quotes (
+				user_id,
+				root_service_id,
+				title,
+				currency,
+				subtotal,
+				discount_rate,
+				discount_amount,
+				tax_rate,
+				tax_amount,
+				total,
+				notes,
+				share_token,
+				expires_at
+			)
+		VALUES
+			(
+				@user_id,
+				@root_service_id,
+				@title,
+				@currency,
+				@subtotal,
+				@discount_rate,
+				@discount_amount,
+				@tax_rate,
+				@tax_amount,
+				@total,
+				@notes,
+				@share_token,
+				@expires_at
+			)
+		RETURNING
+			id
+	`, pgx.NamedArgs{
+		"user_id":         q.UserID,
+		"root_service_id": q.RootServiceID,
+		"title":           q.Title,
+		"currency":        q.Currency,
+		"subtotal":        q.Subtotal,
+		"discount_rate":   q.DiscountRate,
+		"discount_amount": q.DiscountAmount,
+		"tax_rate":        q.TaxRate,
+		"tax_amount":      q.TaxAmount,
+		"total":           q.Total,
+		"notes":           q.Notes,
+		"share_token":     q.ShareToken,
+		"expires_at":      q.ExpiresAt,
+	}).Scan(&quoteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create quote query for user_id=%s: %w", q.UserID, err)
+	}
+
+	for i, item := range items {
+		_, err = tx.Exec(ctx, `
+			INSERT INTO
+				quote_items (
+					quote_id,
+					service_id,
+					description,
+					method,
+					quantity,
+					unit_label,
+					amount,
+					lines,
+					sort_order
+				)
+			VALUES
+				(
+					@quote_id,
+					@service_id,
+					@description,
+					@method,
+					@quantity,
+					@unit_label,
+					@amount,
+					@lines,
+					@sort_order
+				)
+		`, pgx.NamedArgs{
+			"quote_id":    quoteID,
+			"service_id":  item.ServiceID,
+			"description": item.Description,
+			"method":      item.Method,
+			"quantity":    item.Quantity,
+			"unit_label":  item.UnitLabel,
+			"amount":      item.Amount,
+			"lines":       item.Lines,
+			"sort_order":  i,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert quote item for quote_id=%s: %w", quoteID, err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for quote_id=%s: %w", quoteID, err)
+	}
+
+	return r.GetQuoteByID(ctx, q.UserID, quoteID)
+}
+
+func (r *QuoteRepository) GetQuoteByID(ctx context.Context, userID string, quoteID uuid.UUID) (*quote.PopulatedQuote, error) {
+	stmt := populatedQuoteSelect + `
+		WHERE
+			q.id=@id
+			AND q.user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      quoteID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get quote by id query for id=%s, user_id=%s: %w", quoteID, userID, err)
+	}
+
+	quoteItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[quote.PopulatedQuote])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:quotes for id=%s, user_id=%s: %w", quoteID, userID, err)
+	}
+
+	return &quoteItem, nil
+}
+
+// GetQuoteByShareToken looks a quote up by the token handed to the customer, regardless of owner
+func (r *QuoteRepository) GetQuoteByShareToken(ctx context.Context, token string) (*quote.PopulatedQuote, error) {
+	stmt := populatedQuoteSelect + `
+		WHERE
+			q.share_token=@share_token
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"share_token": token,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get quote by share token query: %w", err)
+	}
+
+	quoteItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[quote.PopulatedQuote])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:quotes for share token: %w", err)
+	}
+
+	return &quoteItem, nil
+}
+
+func (r *QuoteRepository) GetQuotes(ctx context.Context, userID string, query *quote.GetQuotesQuery) (*model.PaginatedResponse[quote.Quote], error) {
+	args := pgx.NamedArgs{
+		"user_id": userID,
+	}
+
+	where := " WHERE user_id = @user_id"
+	if query.Status != nil {
+		where += " AND status = @status"
+		args["status"] = *query.Status
+	}
+
+	var total int
+	if err := r.server.DB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM quotes"+where, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to get total count for quotes user_id=%s: %w", userID, err)
+	}
+
+	stmt := "SELECT * FROM quotes" + where + " ORDER BY created_at DESC LIMIT @limit OFFSET @offset"
+	args["limit"] = *query.Limit
+	args["offset"] = (*query.Page - 1) * (*query.Limit)
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get quotes query for user_id=%s: %w", userID, err)
+	}
+
+	quotes, err := pgx.CollectRows(rows, pgx.RowToStructByName[quote.Quote])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:quotes for user_id=%s: %w", userID, err)
+	}
+
+	return &model.PaginatedResponse[quote.Quote]{
+		Data:       quotes,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
+
+// UpdateQuoteStatus records a response to a quote. Like bookings, it only applies while the quote is
+// still in the expected status so a double accept/reject cannot both win.
+func (r *QuoteRepository) UpdateQuoteStatus(
+	ctx context.Context,
+	quoteID uuid.UUID,
+	from quote.Status,
+	to quote.Status,
+	note *string,
+) (*quote.Quote, error) {
+	stmt := `
+		UPDATE quotes
+		SET
+			status=@to,
+			response_note=COALESCE(@note, response_note),
+			responded_at=CASE
+				WHEN @to IN ('accepted', 'rejected') THEN CURRENT_TIMESTAMP
+				ELSE responded_at
+			END
+		WHERE
+			id=@id
+			AND status=@from
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":   quoteID,
+		"from": from,
+		"to":   to,
+		"note": note,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update quote status query for id=%s: %w", quoteID, err)
+	}
+
+	quoteItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[quote.Quote])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "QUOTE_STATUS_CHANGED"
+			return nil, errs.NewConflictError("Quote was already answered, please reload", false, &code)
+		}
+		return nil, fmt.Errorf("failed to collect row from table:quotes for id=%s: %w", quoteID, err)
+	}
+
+	return &quoteItem, nil
+}
@@ -3,13 +3,41 @@ package repository
 import "github.com/mukundaparajuli/fixr/internal/server"
 
 type Repositories struct {
-	Category *CategoryRepository
-	Service  *ServiceRepository
+	Category     *CategoryRepository
+	Service      *ServiceRepository
+	Attachment   *AttachmentRepository
+	Booking      *BookingRepository
+	Availability *AvailabilityRepository
+	Quote        *QuoteRepository
+	Invoice      *InvoiceRepository
+	Review       *ReviewRepository
+	Search       *SearchRepository
+	ServiceArea  *ServiceAreaRepository
+	Storefront   *StorefrontRepository
+	Trash        *TrashRepository
+	Audit        *AuditRepository
+	APIKey       *APIKeyRepository
+	Webhook      *WebhookRepository
+	User         *UserRepository
 }
 
 func NewRepositories(s *server.Server) *Repositories {
 	return &Repositories{
-		Category: NewCategoryRepository(s),
-		Service:  NewServiceRepository(s),
+		Category:     NewCategoryRepository(s),
+		Service:      NewServiceRepository(s),
+		Attachment:   NewAttachmentRepository(s),
+		Booking:      NewBookingRepository(s),
+		Availability: NewAvailabilityRepository(s),
+		Quote:        NewQuoteRepository(s),
+		Invoice:      NewInvoiceRepository(s),
+		Review:       NewReviewRepository(s),
+		Search:       NewSearchRepository(s),
+		ServiceArea:  NewServiceAreaRepository(s),
+		Storefront:   NewStorefrontRepository(s),
+		Trash:        NewTrashRepository(s),
+		Audit:        NewAuditRepository(s),
+		APIKey:       NewAPIKeyRepository(s),
+		Webhook:      NewWebhookRepository(s),
+		User:         NewUserRepository(s),
 	}
 }
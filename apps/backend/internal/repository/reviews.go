@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/booking"
+	"github.com/mukundaparajuli/fixr/internal/model/review"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type ReviewRepository struct {
+	server *server.Server
+}
+
+func NewReviewRepository(s *server.Server) *ReviewRepository {
+	return &ReviewRepository{server: s}
+}
+
+func (r *ReviewRepository) CreateReview(ctx context.Context, bookingItem *booking.Booking, payload *review.CreateReviewPayload) (*review.Review, error) {
+	stmt := `
+		INSERT INTO
+			reviews (
+				booking_id,
+				service_id,
+				provider_id,
+				customer_id,
+				rating,
+				comment
+			)
+		VALUES
+			(
+				@booking_id,
+				@service_id,
+				@provider_id,
+				@customer_id,
+				@rating,
+				@comment
+			)
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"booking_id":  bookingItem.ID,
+		"service_id":  bookingItem.ServiceID,
+		"provider_id": bookingItem.ProviderID,
+		"customer_id": bookingItem.CustomerID,
+		"rating":      payload.Rating,
+		"comment":     payload.Comment,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create review query for booking_id=%s: %w", bookingItem.ID, err)
+	}
+
+	reviewItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:reviews for booking_id=%s: %w", bookingItem.ID, err)
+	}
+
+	return &reviewItem, nil
+}
+
+func (r *ReviewRepository) GetReviewByID(ctx context.Context, reviewID uuid.UUID) (*review.Review, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			reviews
+		WHERE
+			id=@id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": reviewID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get review by id query for id=%s: %w", reviewID, err)
+	}
+
+	reviewItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:reviews for id=%s: %w", reviewID, err)
+	}
+
+	return &reviewItem, nil
+}
+
+func (r *ReviewRepository) GetServiceReviews(ctx context.Context, query *review.GetServiceReviewsQuery) (*model.PaginatedResponse[review.Review], error) {
+	args := pgx.NamedArgs{
+		"service_id": query.ServiceID,
+	}
+
+	conditions := []string{"service_id = @service_id"}
+	if query.Rating != nil {
+		conditions = append(conditions, "rating = @rating")
+		args["rating"] = *query.Rating
+	}
+
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.server.DB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM reviews"+where, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to get total count for reviews service_id=%s: %w", query.ServiceID, err)
+	}
+
+	stmt := "SELECT * FROM reviews" + where + " ORDER BY " + *query.Sort
+	if *query.Order == "desc" {
+		stmt += " DESC"
+	} else {
+		stmt += " ASC"
+	}
+
+	stmt += ", created_at DESC LIMIT @limit OFFSET @offset"
+	args["limit"] = *query.Limit
+	args["offset"] = (*query.Page - 1) * (*query.Limit)
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get service reviews query for service_id=%s: %w", query.ServiceID, err)
+	}
+
+	reviews, err := pgx.CollectRows(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:reviews for service_id=%s: %w", query.ServiceID, err)
+	}
+
+	return &model.PaginatedResponse[review.Review]{
+		Data:       reviews,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
+
+// UpdateReview edits a review; only the customer who wrote it matches
+func (r *ReviewRepository) UpdateReview(ctx context.Context, customerID string, payload *review.UpdateReviewPayload) (*review.Review, error) {
+	stmt := `UPDATE reviews SET `
+	args := pgx.NamedArgs{
+		"id":          payload.ID,
+		"customer_id": customerID,
+	}
+	setClauses := []string{}
+
+	if payload.Rating != nil {
+		setClauses = append(setClauses, "rating=@rating")
+		args["rating"] = *payload.Rating
+	}
+
+	if payload.Comment != nil {
+		setClauses = append(setClauses, "comment=@comment")
+		args["comment"] = *payload.Comment
+	}
+
+	if len(setClauses) == 0 {
+		return nil, errs.NewBadRequestError("no fields to update", false, nil, nil, nil)
+	}
+
+	stmt += strings.Join(setClauses, ", ")
+	stmt += " WHERE id=@id AND customer_id=@customer_id RETURNING *"
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update review query for id=%s, customer_id=%s: %w", payload.ID, customerID, err)
+	}
+
+	reviewItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:reviews for id=%s, customer_id=%s: %w", payload.ID, customerID, err)
+	}
+
+	return &reviewItem, nil
+}
+
+func (r *ReviewRepository) DeleteReview(ctx context.Context, customerID string, reviewID uuid.UUID) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `DELETE FROM reviews WHERE id=@id AND customer_id=@customer_id`, pgx.NamedArgs{
+		"id":          reviewID,
+		"customer_id": customerID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute delete review query for id=%s, customer_id=%s: %w", reviewID, customerID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		code := "REVIEW_NOT_FOUND"
+		return errs.NewNotFoundError("review not found", false, &code)
+	}
+
+	return nil
+}
+
+// ReplyToReview sets or replaces the provider's public answer to a review
+func (r *ReviewRepository) ReplyToReview(ctx context.Context, providerID string, reviewID uuid.UUID, reply string) (*review.Review, error) {
+	stmt := `
+		UPDATE reviews
+		SET
+			reply=@reply,
+			replied_at=CURRENT_TIMESTAMP
+		WHERE
+			id=@id
+			AND provider_id=@provider_id
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":          reviewID,
+		"provider_id": providerID,
+		"reply":       reply,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute reply to review query for id=%s, provider_id=%s: %w", reviewID, providerID, err)
+	}
+
+	reviewItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:reviews for id=%s, provider_id=%s: %w", reviewID, providerID, err)
+	}
+
+	return &reviewItem, nil
+}
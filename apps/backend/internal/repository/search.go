@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/search"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+// maxCategoryHits caps the category matches returned next to the service page
+const maxCategoryHits = 5
+
+const highlightOptions = "StartSel=<mark>, StopSel=</mark>"
+
+type SearchRepository struct {
+	server *server.Server
+}
+
+func NewSearchRepository(s *server.Server) *SearchRepository {
+	return &SearchRepository{server: s}
+}
+
+// SearchServices ranks the user's services by full-text relevance, topped up by trigram similarity
+// on the name so that misspelt queries still find something
+func (r *SearchRepository) SearchServices(ctx context.Context, userID string, query *search.SearchQuery) (*model.PaginatedResponse[search.ServiceHit], error) {
+	args := pgx.NamedArgs{
+		"user_id": userID,
+		"tsquery": search.PrefixQuery(query.Q),
+		"raw":     strings.ToLower(query.Q),
+	}
+
+	conditions := []string{
+		"s.user_id = @user_id",
+		"s.deleted_at IS NULL",
+		"(d.search_vector @@ to_tsquery('simple', @tsquery) OR s.name % @raw OR @raw <% s.name)",
+	}
+	if query.Status != nil {
+		conditions = append(conditions, "s.status = @status")
+		args["status"] = *query.Status
+	}
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	from := `
+		FROM
+			services s
+			JOIN service_search_documents d ON d.service_id=s.id
+			LEFT JOIN services_categories c ON c.id=s.category_id
+			AND c.deleted_at IS NULL
+	`
+
+	var total int
+	if err := r.server.DB.Pool.QueryRow(ctx, "SELECT COUNT(*)"+from+where, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to get total count for service search user_id=%s: %w", userID, err)
+	}
+
+	stmt := `
+		SELECT
+			s.*,
+			c.name AS category_name,
+			ts_rank_cd(d.search_vector, to_tsquery('simple', @tsquery)) + similarity(s.name, @raw) AS rank,
+			ts_headline('simple', s.name, to_tsquery('simple', @tsquery), 'HighlightAll=true, ` + highlightOptions + `') AS name_highlight,
+			ts_headline(
+				'simple',
+				s.description,
+				to_tsquery('simple', @tsquery),
+				'MaxFragments=2, MaxWords=20, MinWords=5, ` + highlightOptions + `'
+			) AS snippet
+	` + from + where + `
+		ORDER BY
+			rank DESC,
+			s.name ASC
+		LIMIT
+			@limit
+		OFFSET
+			@offset
+	`
+	args["limit"] = *query.Limit
+	args["offset"] = (*query.Page - 1) * (*query.Limit)
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute service search query for user_id=%s: %w", userID, err)
+	}
+
+	hits, err := pgx.CollectRows(rows, pgx.RowToStructByName[search.ServiceHit])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services for user_id=%s: %w", userID, err)
+	}
+
+	return &model.PaginatedResponse[search.ServiceHit]{
+		Data:       hits,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
+
+func (r *SearchRepository) SearchCategories(ctx context.Context, userID string, query *search.SearchQuery) ([]search.CategoryHit, error) {
+	stmt := `
+		SELECT
+			c.*,
+			ts_rank_cd(
+				to_tsvector('simple', c.name || ' ' || COALESCE(c.description, '')),
+				to_tsquery('simple', @tsquery)
+			) + similarity(c.name, @raw) AS rank,
+			ts_headline('simple', c.name, to_tsquery('simple', @tsquery), 'HighlightAll=true, ` + highlightOptions + `') AS name_highlight
+		FROM
+			services_categories c
+		WHERE
+			c.user_id=@user_id
+			AND c.deleted_at IS NULL
+			AND (
+				to_tsvector('simple', c.name || ' ' || COALESCE(c.description, '')) @@ to_tsquery('simple', @tsquery)
+				OR c.name % @raw
+			)
+		ORDER BY
+			rank DESC,
+			c.name ASC
+		LIMIT
+			@limit
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+		"tsquery": search.PrefixQuery(query.Q),
+		"raw":     strings.ToLower(query.Q),
+		"limit":   maxCategoryHits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute category search query for user_id=%s: %w", userID, err)
+	}
+
+	hits, err := pgx.CollectRows(rows, pgx.RowToStructByName[search.CategoryHit])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:category for user_id=%s: %w", userID, err)
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model/servicearea"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type ServiceAreaRepository struct {
+	server *server.Server
+}
+
+func NewServiceAreaRepository(s *server.Server) *ServiceAreaRepository {
+	return &ServiceAreaRepository{server: s}
+}
+
+// serviceAreaConditions returns the SQL conditions keeping providers with a service area covering the
+// location; owner is the column holding the provider's user_id in the surrounding query
+func serviceAreaConditions(owner string, location servicearea.Location, args pgx.NamedArgs) []string {
+	conditions := []string{}
+
+	if location.Lat != nil && location.Lng != nil {
+		withinKm := 0.0
+		if location.WithinKm != nil {
+			withinKm = *location.WithinKm
+		}
+
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM service_areas a
+			WHERE a.user_id = `+owner+`
+				AND a.kind = 'radius'
+				AND earth_box(ll_to_earth(@lat, @lng), @box_meters) @> ll_to_earth(a.latitude, a.longitude)
+				AND earth_distance(ll_to_earth(@lat, @lng), ll_to_earth(a.latitude, a.longitude)) <= (a.radius_km + @within_km) * 1000
+		)`)
+		args["lat"] = *location.Lat
+		args["lng"] = *location.Lng
+		args["within_km"] = withinKm
+		args["box_meters"] = (servicearea.MaxRadiusKm + withinKm) * 1000
+	}
+
+	if location.PostalCode != nil {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM service_areas a
+			WHERE a.user_id = `+owner+`
+				AND a.kind = 'postal_codes'
+				AND a.postal_codes @> ARRAY[@postal_code]::TEXT[]
+		)`)
+		args["postal_code"] = servicearea.NormalizePostalCode(*location.PostalCode)
+	}
+
+	return conditions
+}
+
+func (r *ServiceAreaRepository) CreateServiceArea(ctx context.Context, userID string, payload *servicearea.CreateServiceAreaPayload) (*servicearea.ServiceArea, error) {
+	stmt := `
+		INSERT INTO
+			service_areas (
+				user_id,
+				name,
+				kind,
+				latitude,
+				longitude,
+				radius_km,
+				postal_codes
+			)
+		VALUES
+			(
+				@user_id,
+				@name,
+				@kind,
+				@latitude,
+				@longitude,
+				@radius_km,
+				@postal_codes
+			)
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":      userID,
+		"name":         payload.Name,
+		"kind":         payload.Kind,
+		"latitude":     payload.Latitude,
+		"longitude":    payload.Longitude,
+		"radius_km":    payload.RadiusKm,
+		"postal_codes": payload.PostalCodes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create service area query for user_id=%s: %w", userID, err)
+	}
+
+	area, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[servicearea.ServiceArea])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:service_areas for user_id=%s: %w", userID, err)
+	}
+
+	return &area, nil
+}
+
+func (r *ServiceAreaRepository) GetServiceAreas(ctx context.Context, userID string) ([]servicearea.ServiceArea, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			service_areas
+		WHERE
+			user_id=@user_id
+		ORDER BY
+			created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get service areas query for user_id=%s: %w", userID, err)
+	}
+
+	areas, err := pgx.CollectRows(rows, pgx.RowToStructByName[servicearea.ServiceArea])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:service_areas for user_id=%s: %w", userID, err)
+	}
+
+	return areas, nil
+}
+
+func (r *ServiceAreaRepository) DeleteServiceArea(ctx context.Context, userID string, areaID uuid.UUID) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `DELETE FROM service_areas WHERE id=@id AND user_id=@user_id`, pgx.NamedArgs{
+		"id":      areaID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute delete service area query for id=%s, user_id=%s: %w", areaID, userID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		code := "SERVICE_AREA_NOT_FOUND"
+		return errs.NewNotFoundError("service area not found", false, &code)
+	}
+
+	return nil
+}
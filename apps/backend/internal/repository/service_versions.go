@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+)
+
+func (r *ServiceRepository) GetServiceVersions(ctx context.Context, userID string, query *service.GetServiceVersionsQuery) (*model.PaginatedResponse[service.Version], error) {
+	args := pgx.NamedArgs{
+		"service_id": query.ID,
+		"user_id":    userID,
+		"limit":      *query.Limit,
+		"offset":     (*query.Page - 1) * (*query.Limit),
+	}
+
+	var total int
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		SELECT
+			COUNT(*)
+		FROM
+			service_versions
+		WHERE
+			service_id=@service_id
+			AND user_id=@user_id
+	`, args).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of service versions for service_id=%s, user_id=%s: %w", query.ID, userID, err)
+	}
+
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			*
+		FROM
+			service_versions
+		WHERE
+			service_id=@service_id
+			AND user_id=@user_id
+		ORDER BY
+			version DESC
+		LIMIT
+			@limit
+		OFFSET
+			@offset
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get service versions query for service_id=%s, user_id=%s: %w", query.ID, userID, err)
+	}
+
+	versions, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.Version])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:service_versions for service_id=%s, user_id=%s: %w", query.ID, userID, err)
+	}
+
+	return &model.PaginatedResponse[service.Version]{
+		Data:       versions,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
+
+func (r *ServiceRepository) GetServiceVersion(ctx context.Context, userID string, serviceID uuid.UUID, version int) (*service.Version, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			*
+		FROM
+			service_versions
+		WHERE
+			service_id=@service_id
+			AND user_id=@user_id
+			AND version=@version
+	`, pgx.NamedArgs{
+		"service_id": serviceID,
+		"user_id":    userID,
+		"version":    version,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get service version query for service_id=%s, version=%d, user_id=%s: %w", serviceID, version, userID, err)
+	}
+
+	versionItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.Version])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:service_versions for service_id=%s, version=%d, user_id=%s: %w", serviceID, version, userID, err)
+	}
+
+	return &versionItem, nil
+}
+
+// DiffServiceVersions returns the fields whose values differ from one version to the other
+func (r *ServiceRepository) DiffServiceVersions(ctx context.Context, userID string, serviceID uuid.UUID, from int, to int) (map[string]audit.Change, error) {
+	var changes map[string]audit.Change
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		SELECT
+			audit_diff(f.snapshot, t.snapshot)
+		FROM
+			service_versions f
+			JOIN service_versions t ON t.service_id=f.service_id
+		WHERE
+			f.service_id=@service_id
+			AND f.user_id=@user_id
+			AND f.version=@from
+			AND t.version=@to
+	`, pgx.NamedArgs{
+		"service_id": serviceID,
+		"user_id":    userID,
+		"from":       from,
+		"to":         to,
+	}).Scan(&changes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:service_versions for service_id=%s, from=%d, to=%d, user_id=%s: %w", serviceID, from, to, userID, err)
+	}
+
+	return changes, nil
+}
+
+// RevertService writes the fields of an earlier version back to a live service and records the
+// result as a new version. The hierarchy (parent and sort order) is left where it is now, since
+// moving a service has its own cycle and depth checks.
+func (r *ServiceRepository) RevertService(ctx context.Context, userID string, serviceID uuid.UUID, target *service.Version) (*service.Service, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	before, err := lockService(ctx, tx, userID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := target.Snapshot
+	rows, err := tx.Query(ctx, `
+		UPDATE services
+		SET
+			name=@name,
+			description=@description,
+			status=@status,
+			rate=@rate,
+			currency=@currency,
+			method=@method,
+			unit_label=@unit_label,
+			price_tiers=@price_tiers,
+			duration_minutes=@duration_minutes,
+			category_id=@category_id,
+			metadata=@metadata
+		WHERE
+			id=@id
+			AND user_id=@user_id
+		RETURNING
+			*
+	`, pgx.NamedArgs{
+		"id":               serviceID,
+		"user_id":          userID,
+		"name":             snapshot.Name,
+		"description":      snapshot.Description,
+		"status":           snapshot.Status,
+		"rate":             snapshot.Rate,
+		"currency":         snapshot.Currency,
+		"method":           snapshot.Method,
+		"unit_label":       snapshot.UnitLabel,
+		"price_tiers":      snapshot.PriceTiers,
+		"duration_minutes": snapshot.DurationMinutes,
+		"category_id":      snapshot.CategoryID,
+		"metadata":         snapshot.Metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute revert service query for id=%s, version=%d, user_id=%s: %w", serviceID, target.Version, userID, err)
+	}
+
+	revertedService, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:services for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityService, serviceID, audit.ActionReverted, before, revertedService); err != nil {
+		return nil, err
+	}
+
+	if err := recordServiceVersion(ctx, tx, userID, &revertedService, &target.Version); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	return &revertedService, nil
+}
+
+// recordServiceVersion snapshots a service inside the caller's transaction as its next version.
+// Callers hold the service row (freshly inserted or locked FOR UPDATE), so numbering cannot race.
+func recordServiceVersion(ctx context.Context, tx pgx.Tx, userID string, serviceItem *service.Service, restoredFrom *int) error {
+	actor := audit.ActorFromContext(ctx)
+
+	var requestID *string
+	if actor.RequestID != "" {
+		requestID = &actor.RequestID
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO
+			service_versions (
+				service_id,
+				user_id,
+				version,
+				actor_id,
+				request_id,
+				restored_from,
+				snapshot
+			)
+		SELECT
+			@service_id,
+			@user_id,
+			COALESCE(MAX(version), 0) + 1,
+			@actor_id,
+			@request_id,
+			@restored_from,
+			@snapshot
+		FROM
+			service_versions
+		WHERE
+			service_id=@service_id
+	`, pgx.NamedArgs{
+		"service_id":    serviceItem.ID,
+		"user_id":       userID,
+		"actor_id":      actor.ID,
+		"request_id":    requestID,
+		"restored_from": restoredFrom,
+		"snapshot":      serviceItem,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record service version for id=%s, user_id=%s: %w", serviceItem.ID, userID, err)
+	}
+
+	return nil
+}
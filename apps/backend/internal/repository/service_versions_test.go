@@ -0,0 +1,75 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevertService(t *testing.T) {
+	_, repo := setupServiceRepository(t)
+	ctx := context.Background()
+	userID := "user_" + uuid.NewString()
+
+	root := createService(t, repo, userID, "Plumbing", nil)
+	created := createService(t, repo, userID, "Leaks", nil)
+
+	payload := &service.UpdateServicePayload{ID: created.ID, Name: ptr("Burst pipes"), Description: ptr("Emergency call-outs")}
+	require.NoError(t, payload.Validate())
+	_, err := repo.UpdateService(ctx, userID, created.ID, payload, testMaxDepth)
+	require.NoError(t, err)
+
+	// moving is part of the history but is not undone by a revert
+	_, err = repo.MoveService(ctx, userID, created.ID, &root.ID, testMaxDepth)
+	require.NoError(t, err)
+
+	first, err := repo.GetServiceVersion(ctx, userID, created.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Leaks", first.Snapshot.Name)
+	assert.Nil(t, first.RestoredFrom)
+
+	changes, err := repo.DiffServiceVersions(ctx, userID, created.ID, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]audit.Change{
+		"name":        {Before: "Leaks", After: "Burst pipes"},
+		"description": {Before: nil, After: "Emergency call-outs"},
+	}, changes)
+
+	reverted, err := repo.RevertService(ctx, userID, created.ID, first)
+	require.NoError(t, err)
+	assert.Equal(t, "Leaks", reverted.Name)
+	assert.Nil(t, reverted.Description)
+	require.NotNil(t, reverted.ParentServiceID)
+	assert.Equal(t, root.ID, *reverted.ParentServiceID)
+
+	t.Run("the revert is recorded as a new version", func(t *testing.T) {
+		latest, err := repo.GetServiceVersion(ctx, userID, created.ID, 4)
+		require.NoError(t, err)
+		require.NotNil(t, latest.RestoredFrom)
+		assert.Equal(t, 1, *latest.RestoredFrom)
+		assert.Equal(t, "Leaks", latest.Snapshot.Name)
+
+		query := &service.GetServiceVersionsQuery{ID: created.ID}
+		require.NoError(t, query.Validate())
+		versions, err := repo.GetServiceVersions(ctx, userID, query)
+		require.NoError(t, err)
+		assert.Equal(t, 4, versions.Total)
+	})
+
+	t.Run("versions are scoped to the owner", func(t *testing.T) {
+		_, err := repo.GetServiceVersion(ctx, "user_"+uuid.NewString(), created.ID, 1)
+		require.Error(t, err)
+	})
+
+	t.Run("a trashed service cannot be reverted", func(t *testing.T) {
+		require.NoError(t, repo.DeleteService(ctx, userID, created.ID))
+
+		_, err := repo.RevertService(ctx, userID, created.ID, first)
+		require.Error(t, err)
+	})
+}
@@ -5,12 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/mukundaparajuli/fixr/internal/errs"
 	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/model/category"
+	"github.com/mukundaparajuli/fixr/internal/model/search"
 	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/model/servicearea"
 	"github.com/mukundaparajuli/fixr/internal/server"
 )
 
@@ -19,10 +24,56 @@ type ServiceRepository struct {
 }
 
 func NewServiceRepository(s *server.Server) *ServiceRepository {
-	return &ServiceRepository{}
+	return &ServiceRepository{server: s}
 }
 
-func (r *ServiceRepository) CreateService(ctx context.Context, userID string, payload *service.CreateServicePayload) (*service.Service, error) {
+// CreateService inserts a service, under ParentServiceID when set. The parent is checked under the
+// hierarchy lock, so a concurrent move or delete cannot leave the new service too deep or under a
+// trashed parent.
+func (r *ServiceRepository) CreateService(ctx context.Context, userID string, payload *service.CreateServicePayload, maxDepth int) (*service.Service, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for name=%s, user_id=%s: %w", payload.Name, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockServiceHierarchy(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	if payload.ParentServiceID != nil {
+		if err := checkServiceParent(ctx, tx, userID, *payload.ParentServiceID, maxDepth); err != nil {
+			return nil, err
+		}
+	}
+
+	serviceItem, err := insertService(ctx, tx, userID, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for name=%s, user_id=%s: %w", payload.Name, userID, err)
+	}
+
+	return serviceItem, nil
+}
+
+// lockServiceHierarchy takes a transaction-scoped advisory lock on the user's service hierarchy.
+// Every write that adds, moves or reorders services holds it, so checks made against the current
+// siblings or ancestors stay true until commit.
+func lockServiceHierarchy(ctx context.Context, tx pgx.Tx, userID string) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('services:' || @user_id::TEXT))`, pgx.NamedArgs{
+		"user_id": userID,
+	}); err != nil {
+		return fmt.Errorf("failed to lock service hierarchy for user_id=%s: %w", userID, err)
+	}
+
+	return nil
+}
+
+// insertService inserts a service and records its audit event and first version in the given transaction
+func insertService(ctx context.Context, tx pgx.Tx, userID string, payload *service.CreateServicePayload) (*service.Service, error) {
 	stmt := `
 		INSERT INTO
 			services(
@@ -31,10 +82,14 @@ func (r *ServiceRepository) CreateService(ctx context.Context, userID string, pa
 				description,
 				status,
 				rate,
+				currency,
 				method,
+				unit_label,
+				price_tiers,
+				duration_minutes,
 				parent_service_id,
 				category_id,
-				metadata,
+				metadata
 			)
 		VALUES
 			(
@@ -43,22 +98,33 @@ func (r *ServiceRepository) CreateService(ctx context.Context, userID string, pa
 				@description,
 				@status,
 				@rate,
+				@currency,
 				@method,
+				@unit_label,
+				@price_tiers,
+				@duration_minutes,
 				@parent_service_id,
 				@category_id,
 				@metadata
 			)
+		RETURNING
+			*
 	`
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+	rows, err := tx.Query(ctx, stmt, pgx.NamedArgs{
 		"user_id":           userID,
 		"name":              payload.Name,
-		"description":       *payload.Description,
+		"description":       payload.Description,
+		"status":            payload.Status,
 		"rate":              payload.Rate,
+		"currency":          payload.Currency,
 		"method":            payload.Method,
-		"parent_service_id": *payload.ParentServiceID,
+		"unit_label":        payload.UnitLabel,
+		"price_tiers":       payload.PriceTiers,
+		"duration_minutes":  payload.DurationMinutes,
+		"parent_service_id": payload.ParentServiceID,
 		"category_id":       payload.CategoryID,
-		"metadata":          *payload.Metadata,
+		"metadata":          payload.Metadata,
 	})
 
 	if err != nil {
@@ -67,8 +133,17 @@ func (r *ServiceRepository) CreateService(ctx context.Context, userID string, pa
 
 	serviceItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.Service])
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute create service query for name=%s, user_id=%s :%w", payload.Name, userID, err)
+		return nil, fmt.Errorf("failed to collect row from table:services for name=%s, user_id=%s :%w", payload.Name, userID, err)
 	}
+
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityService, serviceItem.ID, audit.ActionCreated, nil, serviceItem); err != nil {
+		return nil, err
+	}
+
+	if err := recordServiceVersion(ctx, tx, userID, &serviceItem, nil); err != nil {
+		return nil, err
+	}
+
 	return &serviceItem, nil
 }
 
@@ -91,13 +166,35 @@ func (r *ServiceRepository) GetServiceByID(ctx context.Context, userID string, s
 					child.id IS NOT NULL
 			),
 			'[]'::JSONB
-		) AS children
+		) AS children,
+		COALESCE(
+			(
+				SELECT
+					jsonb_agg(
+						to_jsonb(camel (a))
+						ORDER BY
+							a.created_at ASC
+					)
+				FROM
+					services_attachment a
+				WHERE
+					a.service_id=s.id
+					AND a.deleted_at IS NULL
+			),
+			'[]'::JSONB
+		) AS attachments
 	FROM
 		services s
-		LEFT JOIN service_categories c ON c.id=s.category_id
+		LEFT JOIN services_categories c ON c.id=s.category_id
 		AND c.user_id=@user_id
+		AND c.deleted_at IS NULL
 		LEFT JOIN services child ON child.parent_service_id=s.id
 		AND child.user_id=@user_id
+		AND child.deleted_at IS NULL
+	WHERE
+		s.id=@id
+		AND s.user_id=@user_id
+		AND s.deleted_at IS NULL
 	GROUP BY
 		s.id,
 		c.id
@@ -108,12 +205,134 @@ func (r *ServiceRepository) GetServiceByID(ctx context.Context, userID string, s
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute get service by id query for id=%s, user_id=%s", serviceID, userID)
+		return nil, fmt.Errorf("failed to execute get service by id query for id=%s, user_id=%s: %w", serviceID, userID, err)
 	}
 
 	serviceItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.PopulatedService])
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute get service by id query for id=%s, user_id=%s", serviceID, userID)
+		return nil, fmt.Errorf("failed to collect row from table:services for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	return &serviceItem, nil
+}
+
+func (r *ServiceRepository) CheckServiceExists(ctx context.Context, userID string, serviceID uuid.UUID) (*service.Service, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			services
+		WHERE
+			id=@id
+			AND user_id=@user_id
+			AND deleted_at IS NULL
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      serviceID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute check service exists query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	serviceItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:services for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	return &serviceItem, nil
+}
+
+// GetServiceTree returns the root service followed by all of its descendants, breadth first
+func (r *ServiceRepository) GetServiceTree(ctx context.Context, userID string, rootID uuid.UUID) ([]service.Service, error) {
+	stmt := `
+		WITH RECURSIVE
+			tree AS (
+				SELECT
+					s.*,
+					0 AS depth
+				FROM
+					services s
+				WHERE
+					s.id=@id
+					AND s.user_id=@user_id
+					AND s.deleted_at IS NULL
+				UNION ALL
+				SELECT
+					child.*,
+					tree.depth + 1
+				FROM
+					services child
+					JOIN tree ON child.parent_service_id=tree.id
+				WHERE
+					child.user_id=@user_id
+					AND child.deleted_at IS NULL
+					AND tree.depth < @max_depth
+			)
+		SELECT
+			*
+		FROM
+			tree
+		ORDER BY
+			depth ASC,
+			sort_order ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":        rootID,
+		"user_id":   userID,
+		"max_depth": service.MaxTreeDepth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get service tree query for id=%s, user_id=%s: %w", rootID, userID, err)
+	}
+
+	type treeRow struct {
+		service.Service
+		Depth int `db:"depth"`
+	}
+
+	treeRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[treeRow])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services for id=%s, user_id=%s: %w", rootID, userID, err)
+	}
+
+	if len(treeRows) == 0 {
+		return nil, fmt.Errorf("failed to collect row from table:services for id=%s, user_id=%s: %w", rootID, userID, pgx.ErrNoRows)
+	}
+
+	services := make([]service.Service, 0, len(treeRows))
+	for _, row := range treeRows {
+		services = append(services, row.Service)
+	}
+
+	return services, nil
+}
+
+// GetActiveServiceByID looks up an active service regardless of who owns it, for customer-facing flows
+func (r *ServiceRepository) GetActiveServiceByID(ctx context.Context, serviceID uuid.UUID) (*service.Service, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			services
+		WHERE
+			id=@id
+			AND status='active'
+			AND deleted_at IS NULL
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": serviceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get active service by id query for id=%s: %w", serviceID, err)
+	}
+
+	serviceItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:services for id=%s: %w", serviceID, err)
 	}
 
 	return &serviceItem, nil
@@ -138,18 +357,36 @@ func (r *ServiceRepository) GetServices(ctx context.Context, userID string, quer
 					child.id IS NOT NULL
 			),
 			'[]'::JSONB
-		) AS children
+		) AS children,
+		COALESCE(
+			(
+				SELECT
+					jsonb_agg(
+						to_jsonb(camel (a))
+						ORDER BY
+							a.created_at ASC
+					)
+				FROM
+					services_attachment a
+				WHERE
+					a.service_id=s.id
+					AND a.deleted_at IS NULL
+			),
+			'[]'::JSONB
+		) AS attachments
 	FROM
 		services s
-		LEFT JOIN service_categories c ON c.id=s.category_id
+		LEFT JOIN services_categories c ON c.id=s.category_id
 		AND c.user_id=@user_id
+		AND c.deleted_at IS NULL
 		LEFT JOIN services child ON child.parent_service_id=s.id
 		AND child.user_id=@user_id
+		AND child.deleted_at IS NULL
 		`
 	args := pgx.NamedArgs{
 		"user_id": userID,
 	}
-	conditions := []string{"s.user_id == @user_id"}
+	conditions := []string{"s.user_id = @user_id", "s.deleted_at IS NULL"}
 	if query.Status != nil {
 		conditions = append(conditions, "s.status = @status")
 		args["status"] = *query.Status
@@ -162,15 +399,47 @@ func (r *ServiceRepository) GetServices(ctx context.Context, userID string, quer
 
 	if query.ParentServiceID != nil {
 		conditions = append(conditions, "s.parent_service_id = @parent_service_id")
-		args["parent_todo_id"] = *query.ParentServiceID
+		args["parent_service_id"] = *query.ParentServiceID
 	} else {
 		// By default, only show root services (no parent)
 		conditions = append(conditions, "s.parent_service_id IS NULL")
 	}
 
+	// a provider's service areas apply to all of their services
+	conditions = append(conditions, serviceAreaConditions("s.user_id", servicearea.Location{
+		Lat:        query.Lat,
+		Lng:        query.Lng,
+		WithinKm:   query.WithinKm,
+		PostalCode: query.PostalCode,
+	}, args)...)
+
+	if query.MinRating != nil {
+		conditions = append(conditions, "s.rating_average >= @min_rating")
+		args["min_rating"] = *query.MinRating
+	}
+
+	if len(query.MetadataKeys) > 0 {
+		conditions = append(conditions, "s.metadata ?& @metadata_keys")
+		args["metadata_keys"] = query.MetadataKeys
+	}
+
+	// the ? check lets the GIN index narrow rows before ->> compares the values
+	for i, filter := range query.MetadataFilters() {
+		conditions = append(conditions, fmt.Sprintf(
+			"s.metadata ? @metadata_key_%[1]d AND s.metadata ->> @metadata_key_%[1]d = @metadata_value_%[1]d", i,
+		))
+		args[fmt.Sprintf("metadata_key_%d", i)] = filter.Key
+		args[fmt.Sprintf("metadata_value_%d", i)] = filter.Value
+	}
+
 	if query.Search != nil {
-		conditions = append(conditions, "(s.title ILIKE @search OR s.description ILIKE @search)")
-		args["search"] = "%" + *query.Search + "%"
+		if tsquery := search.PrefixQuery(*query.Search); tsquery != "" {
+			conditions = append(conditions, `EXISTS (
+				SELECT 1 FROM service_search_documents d
+				WHERE d.service_id = s.id AND d.search_vector @@ to_tsquery('simple', @search)
+			)`)
+			args["search"] = tsquery
+		}
 	}
 
 	if len(conditions) > 0 {
@@ -207,7 +476,7 @@ func (r *ServiceRepository) GetServices(ctx context.Context, userID string, quer
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute get todos query for user_id=%s: %w", userID, err)
+		return nil, fmt.Errorf("failed to execute get services query for user_id=%s: %w", userID, err)
 	}
 
 	services, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.PopulatedService])
@@ -221,7 +490,7 @@ func (r *ServiceRepository) GetServices(ctx context.Context, userID string, quer
 				TotalPages: 0,
 			}, nil
 		}
-		return nil, fmt.Errorf("failed to collect rows from table:todos for user_id=%s: %w", userID, err)
+		return nil, fmt.Errorf("failed to collect rows from table:services for user_id=%s: %w", userID, err)
 	}
 
 	return &model.PaginatedResponse[service.PopulatedService]{
@@ -234,8 +503,10 @@ func (r *ServiceRepository) GetServices(ctx context.Context, userID string, quer
 
 }
 
-func (r *ServiceRepository) UpdateService(ctx context.Context, userID string, serviceID uuid.UUID, payload *service.UpdateServicePayload) (*service.Service, error) {
-	stmt := `UPDATE services SET`
+// UpdateService applies a partial update. A parent change goes through the same checks as MoveService
+// and lands in the same transaction as the other fields, so the update yields one version and one audit event.
+func (r *ServiceRepository) UpdateService(ctx context.Context, userID string, serviceID uuid.UUID, payload *service.UpdateServicePayload, maxDepth int) (*service.Service, error) {
+	stmt := `UPDATE services SET `
 	args := pgx.NamedArgs{
 		"id":      serviceID,
 		"user_id": userID,
@@ -252,6 +523,57 @@ func (r *ServiceRepository) UpdateService(ctx context.Context, userID string, se
 		args["description"] = *payload.Description
 	}
 
+	if payload.Status != nil {
+		setClauses = append(setClauses, "status=@status")
+		args["status"] = *payload.Status
+	}
+
+	if payload.Rate != nil {
+		setClauses = append(setClauses, "rate=@rate")
+		args["rate"] = *payload.Rate
+	}
+
+	if payload.Method != nil {
+		setClauses = append(setClauses, "method=@method")
+		args["method"] = *payload.Method
+	}
+
+	if payload.Currency != nil {
+		setClauses = append(setClauses, "currency=@currency")
+		args["currency"] = *payload.Currency
+	}
+
+	if payload.UnitLabel != nil {
+		setClauses = append(setClauses, "unit_label=@unit_label")
+		args["unit_label"] = *payload.UnitLabel
+	}
+
+	if payload.PriceTiers != nil {
+		setClauses = append(setClauses, "price_tiers=@price_tiers")
+		args["price_tiers"] = payload.PriceTiers
+	}
+
+	if payload.DurationMinutes != nil {
+		setClauses = append(setClauses, "duration_minutes=@duration_minutes")
+		args["duration_minutes"] = *payload.DurationMinutes
+	}
+
+	if payload.ParentServiceID != nil {
+		// appended after its new siblings, as in MoveService
+		setClauses = append(setClauses, `parent_service_id=@parent_service_id, sort_order=(
+			SELECT
+				COALESCE(MAX(sort_order), 0) + 1
+			FROM
+				services
+			WHERE
+				user_id=@user_id
+				AND parent_service_id=@parent_service_id
+				AND deleted_at IS NULL
+				AND id!=@id
+		)`)
+		args["parent_service_id"] = *payload.ParentServiceID
+	}
+
 	if payload.CategoryID != nil {
 		setClauses = append(setClauses, "category_id=@category_id")
 		args["category_id"] = *payload.CategoryID
@@ -266,40 +588,818 @@ func (r *ServiceRepository) UpdateService(ctx context.Context, userID string, se
 		return nil, errs.NewBadRequestError("no fields to update", false, nil, nil, nil)
 	}
 
-	stmt += strings.Join(setClauses, ",")
-	stmt += "where id=@id AND user_id=@user_id"
+	stmt += strings.Join(setClauses, ", ")
+	stmt += " WHERE id=@id AND user_id=@user_id AND deleted_at IS NULL RETURNING *"
 
-	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if payload.ParentServiceID != nil {
+		if err := lockServiceHierarchy(ctx, tx, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	before, err := lockService(ctx, tx, userID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.ParentServiceID != nil {
+		if err := checkServiceMove(ctx, tx, userID, serviceID, payload.ParentServiceID, maxDepth); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := tx.Query(ctx, stmt, args)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return nil, fmt.Errorf("failed to execute update service query for id=%s, user_id=%s: %w", serviceID, userID, err)
 	}
 
 	updatedService, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.Service])
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
+		return nil, fmt.Errorf("failed to collect row from table:services for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityService, serviceID, audit.ActionUpdated, before, updatedService); err != nil {
+		return nil, err
+	}
+
+	if err := recordServiceVersion(ctx, tx, userID, &updatedService, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
 	}
 
 	return &updatedService, nil
 }
 
+// lockService reads a live service FOR UPDATE, giving the before state for its audit event
+func lockService(ctx context.Context, tx pgx.Tx, userID string, serviceID uuid.UUID) (*service.Service, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT
+			*
+		FROM
+			services
+		WHERE
+			id=@id
+			AND user_id=@user_id
+			AND deleted_at IS NULL
+		FOR UPDATE
+	`, pgx.NamedArgs{
+		"id":      serviceID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute lock service query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	serviceItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:services for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	return &serviceItem, nil
+}
+
+// serviceSubtreeQuery walks down from @id through the live services, numbering the start as depth 1
+const serviceSubtreeQuery = `
+	WITH RECURSIVE
+		tree AS (
+			SELECT
+				id,
+				1 AS depth
+			FROM
+				services
+			WHERE
+				id=@id
+				AND user_id=@user_id
+				AND deleted_at IS NULL
+			UNION ALL
+			SELECT
+				child.id,
+				tree.depth + 1
+			FROM
+				services child
+				JOIN tree ON child.parent_service_id=tree.id
+			WHERE
+				child.user_id=@user_id
+				AND child.deleted_at IS NULL
+				AND tree.depth <= @max_depth
+		)
+`
+
+// DeleteService moves a service, its whole subtree and their attachments to the trash. It is refused
+// while any of them has a pending or confirmed booking. Everything trashed together shares one
+// deleted_at (the transaction timestamp), which is how RestoreService later finds exactly this batch.
 func (r *ServiceRepository) DeleteService(ctx context.Context, userID string, serviceID uuid.UUID) error {
-	stmt := `
-		DELETE FROM services
-		WHERE 
-			id=@serviceID
-			AND user_id=@user_id 	
-	`
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	defer tx.Rollback(ctx)
 
-	result, err := r.server.DB.Pool.Exec(ctx, stmt, pgx.NamedArgs{
-		"id":         userID,
-		"service_id": serviceID,
-	})
+	args := pgx.NamedArgs{
+		"id":        serviceID,
+		"user_id":   userID,
+		"max_depth": service.MaxTreeDepth,
+	}
+
+	// a trashed service can no longer be confirmed, completed or rescheduled, so open bookings
+	// have to be settled first
+	var hasOpenBookings bool
+	err = tx.QueryRow(ctx, serviceSubtreeQuery+`
+		SELECT
+			EXISTS (
+				SELECT
+					1
+				FROM
+					bookings b
+					JOIN tree ON tree.id=b.service_id
+				WHERE
+					b.status IN ('pending', 'confirmed')
+			)
+	`, args).Scan(&hasOpenBookings)
+	if err != nil {
+		return fmt.Errorf("failed to execute check open bookings query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	if hasOpenBookings {
+		code := "SERVICE_HAS_OPEN_BOOKINGS"
+		return errs.NewConflictError("The service or one of its sub-services has pending or confirmed bookings", false, &code)
+	}
+
+	rows, err := tx.Query(ctx, serviceSubtreeQuery+`
+		UPDATE services
+		SET
+			deleted_at=CURRENT_TIMESTAMP
+		WHERE
+			id IN (
+				SELECT
+					id
+				FROM
+					tree
+			)
+		RETURNING
+			*
+	`, args)
 	if err != nil {
-		return fmt.Errorf("failed to execute query : %w", err)
+		return fmt.Errorf("failed to execute trash service query for id=%s, user_id=%s: %w", serviceID, userID, err)
 	}
-	if result.RowsAffected() == 0 {
+
+	trashedServices, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return fmt.Errorf("failed to collect rows from table:services for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	if len(trashedServices) == 0 {
 		code := "SERVICE_NOT_FOUND"
 		return errs.NewNotFoundError("services not found", false, &code)
 	}
+
+	trashedIDs := make([]uuid.UUID, 0, len(trashedServices))
+	for _, trashed := range trashedServices {
+		trashedIDs = append(trashedIDs, trashed.ID)
+
+		before := trashed
+		before.DeletedAt = nil
+		if err := recordAuditEvent(ctx, tx, userID, audit.EntityService, trashed.ID, audit.ActionDeleted, before, trashed); err != nil {
+			return err
+		}
+	}
+
+	rows, err = tx.Query(ctx, `
+		UPDATE services_attachment
+		SET
+			deleted_at=CURRENT_TIMESTAMP
+		WHERE
+			service_id=ANY (@service_ids)
+			AND deleted_at IS NULL
+		RETURNING
+			*
+	`, pgx.NamedArgs{
+		"service_ids": trashedIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute trash service attachments query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	trashedAttachments, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.ServiceAttachment])
+	if err != nil {
+		return fmt.Errorf("failed to collect rows from table:attachments for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	for _, trashed := range trashedAttachments {
+		before := trashed
+		before.DeletedAt = nil
+		if err := recordAuditEvent(ctx, tx, userID, audit.EntityAttachment, trashed.ID, audit.ActionDeleted, before, trashed); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	return nil
+}
+
+// RestoreService brings a trashed service back together with everything that was trashed in the
+// same batch: the descendants and attachments sharing its deleted_at. Items trashed separately
+// beforehand stay in the trash. A service whose parent is still trashed cannot be restored on its own.
+// Every restored service is returned, the requested one first.
+func (r *ServiceRepository) RestoreService(ctx context.Context, userID string, serviceID uuid.UUID) ([]service.Service, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockServiceHierarchy(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	var deletedAt time.Time
+	var parentTrashed bool
+	err = tx.QueryRow(ctx, `
+		SELECT
+			s.deleted_at,
+			p.deleted_at IS NOT NULL
+		FROM
+			services s
+			LEFT JOIN services p ON p.id=s.parent_service_id
+		WHERE
+			s.id=@id
+			AND s.user_id=@user_id
+			AND s.deleted_at IS NOT NULL
+		FOR UPDATE OF
+			s
+	`, pgx.NamedArgs{
+		"id":      serviceID,
+		"user_id": userID,
+	}).Scan(&deletedAt, &parentTrashed)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			code := "TRASHED_SERVICE_NOT_FOUND"
+			return nil, errs.NewNotFoundError("Service not found in trash", false, &code)
+		}
+		return nil, fmt.Errorf("failed to execute get trashed service query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	if parentTrashed {
+		code := "SERVICE_PARENT_TRASHED"
+		return nil, errs.NewConflictError("The parent service is in the trash, restore it first", false, &code)
+	}
+
+	rows, err := tx.Query(ctx, `
+		WITH RECURSIVE
+			tree AS (
+				SELECT
+					id,
+					1 AS depth
+				FROM
+					services
+				WHERE
+					id=@id
+				UNION ALL
+				SELECT
+					child.id,
+					tree.depth + 1
+				FROM
+					services child
+					JOIN tree ON child.parent_service_id=tree.id
+				WHERE
+					child.user_id=@user_id
+					AND child.deleted_at=@deleted_at
+					AND tree.depth <= @max_depth
+			)
+		UPDATE services
+		SET
+			deleted_at=NULL
+		WHERE
+			id IN (
+				SELECT
+					id
+				FROM
+					tree
+			)
+		RETURNING
+			*
+	`, pgx.NamedArgs{
+		"id":         serviceID,
+		"user_id":    userID,
+		"deleted_at": deletedAt,
+		"max_depth":  service.MaxTreeDepth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute restore service query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	restoredServices, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	// the requested service leads, its descendants follow in any order
+	for i := range restoredServices {
+		if restoredServices[i].ID == serviceID {
+			restoredServices[0], restoredServices[i] = restoredServices[i], restoredServices[0]
+			break
+		}
+	}
+
+	restoredIDs := make([]uuid.UUID, 0, len(restoredServices))
+	for _, restored := range restoredServices {
+		restoredIDs = append(restoredIDs, restored.ID)
+
+		before := restored
+		before.DeletedAt = &deletedAt
+		if err := recordAuditEvent(ctx, tx, userID, audit.EntityService, restored.ID, audit.ActionRestored, before, restored); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err = tx.Query(ctx, `
+		UPDATE services_attachment
+		SET
+			deleted_at=NULL
+		WHERE
+			service_id=ANY (@service_ids)
+			AND deleted_at=@deleted_at
+		RETURNING
+			*
+	`, pgx.NamedArgs{
+		"service_ids": restoredIDs,
+		"deleted_at":  deletedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute restore service attachments query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	restoredAttachments, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.ServiceAttachment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:attachments for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	for _, restored := range restoredAttachments {
+		before := restored
+		before.DeletedAt = &deletedAt
+		if err := recordAuditEvent(ctx, tx, userID, audit.EntityAttachment, restored.ID, audit.ActionRestored, before, restored); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	return restoredServices, nil
+}
+
+// ReorderServices rewrites sort_order for the siblings under parentID (root services when nil) to follow
+// serviceIDs. The list must name exactly the current siblings; the hierarchy lock is held until commit so
+// a concurrent create, restore or move cannot slip in between the check and the update.
+func (r *ServiceRepository) ReorderServices(ctx context.Context, userID string, parentID *uuid.UUID, serviceIDs []uuid.UUID) ([]service.Service, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for user_id=%s: %w", userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockServiceHierarchy(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT
+			id,
+			sort_order
+		FROM
+			services
+		WHERE
+			user_id=@user_id
+			AND parent_service_id IS NOT DISTINCT FROM @parent_service_id
+			AND deleted_at IS NULL
+		FOR UPDATE
+	`, pgx.NamedArgs{
+		"user_id":           userID,
+		"parent_service_id": parentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute lock sibling services query for user_id=%s: %w", userID, err)
+	}
+
+	// current sort_order of every sibling, kept for the audit events
+	siblings := make(map[uuid.UUID]int)
+	var siblingID uuid.UUID
+	var sortOrder int
+	_, err = pgx.ForEachRow(rows, []any{&siblingID, &sortOrder}, func() error {
+		siblings[siblingID] = sortOrder
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services for user_id=%s: %w", userID, err)
+	}
+
+	var fieldErrors []errs.FieldError
+	for i, id := range serviceIDs {
+		if _, ok := siblings[id]; !ok {
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: fmt.Sprintf("serviceIds[%d]", i),
+				Error: "is not a service under this parent",
+			})
+		}
+	}
+	if len(fieldErrors) > 0 {
+		code := "SERVICE_REORDER_INVALID"
+		return nil, errs.NewBadRequestError("Some services do not belong under this parent", false, &code, fieldErrors, nil)
+	}
+	if len(serviceIDs) != len(siblings) {
+		code := "SERVICE_REORDER_INCOMPLETE"
+		return nil, errs.NewBadRequestError(
+			fmt.Sprintf("Reorder must list all %d services under this parent", len(siblings)), false, &code, nil, nil,
+		)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE services s
+		SET
+			sort_order=o.position
+		FROM
+			unnest(@service_ids::UUID[]) WITH ORDINALITY AS o (id, position)
+		WHERE
+			s.id=o.id
+			AND s.user_id=@user_id
+	`, pgx.NamedArgs{
+		"service_ids": serviceIDs,
+		"user_id":     userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute reorder services query for user_id=%s: %w", userID, err)
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT
+			*
+		FROM
+			services
+		WHERE
+			user_id=@user_id
+			AND parent_service_id IS NOT DISTINCT FROM @parent_service_id
+			AND deleted_at IS NULL
+		ORDER BY
+			sort_order ASC
+	`, pgx.NamedArgs{
+		"user_id":           userID,
+		"parent_service_id": parentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get reordered services query for user_id=%s: %w", userID, err)
+	}
+
+	services, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services for user_id=%s: %w", userID, err)
+	}
+
+	for _, reordered := range services {
+		if reordered.SortOrder == siblings[reordered.ID] {
+			continue
+		}
+
+		before := reordered
+		before.SortOrder = siblings[reordered.ID]
+		if err := recordAuditEvent(ctx, tx, userID, audit.EntityService, reordered.ID, audit.ActionReordered, before, reordered); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for user_id=%s: %w", userID, err)
+	}
+
+	return services, nil
+}
+
+// serviceAncestorsQuery walks up from @id, numbering the start as depth 1
+const serviceAncestorsQuery = `
+	WITH RECURSIVE
+		ancestors AS (
+			SELECT
+				id,
+				parent_service_id,
+				1 AS depth
+			FROM
+				services
+			WHERE
+				id=@id
+				AND user_id=@user_id
+				AND deleted_at IS NULL
+			UNION ALL
+			SELECT
+				p.id,
+				p.parent_service_id,
+				a.depth + 1
+			FROM
+				services p
+				JOIN ancestors a ON p.id=a.parent_service_id
+			WHERE
+				p.user_id=@user_id
+				AND a.depth <= @max_depth
+		)
+`
+
+// checkServiceParent rejects adding a child under parentID when the parent is missing or trashed, or
+// already sits at the deepest allowed level. Callers hold the hierarchy lock.
+func checkServiceParent(ctx context.Context, tx pgx.Tx, userID string, parentID uuid.UUID, maxDepth int) error {
+	var parentDepth int
+	err := tx.QueryRow(ctx, serviceAncestorsQuery+`
+		SELECT
+			COALESCE(MAX(depth), 0)
+		FROM
+			ancestors
+	`, pgx.NamedArgs{
+		"id":        parentID,
+		"user_id":   userID,
+		"max_depth": service.MaxTreeDepth,
+	}).Scan(&parentDepth)
+	if err != nil {
+		return fmt.Errorf("failed to execute get parent ancestors query for id=%s, user_id=%s: %w", parentID, userID, err)
+	}
+
+	if parentDepth == 0 {
+		code := "PARENT_SERVICE_NOT_FOUND"
+		return errs.NewNotFoundError("Parent service not found", false, &code)
+	}
+	if parentDepth+1 > maxDepth {
+		code := "SERVICE_HIERARCHY_TOO_DEEP"
+		return errs.NewBadRequestError(
+			fmt.Sprintf("Service hierarchies may be at most %d levels deep", maxDepth), false, &code, nil, nil,
+		)
+	}
+
 	return nil
 }
+
+// MoveService re-parents a service (to the root when parentID is nil) and appends it after its new
+// siblings. Moving under one of its own descendants is rejected, as is any move that would make the
+// hierarchy deeper than maxDepth levels. The hierarchy lock keeps two concurrent moves from each
+// passing the checks and together forming a cycle.
+func (r *ServiceRepository) MoveService(ctx context.Context, userID string, serviceID uuid.UUID, parentID *uuid.UUID, maxDepth int) (*service.Service, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockServiceHierarchy(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	before, err := lockService(ctx, tx, userID, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkServiceMove(ctx, tx, userID, serviceID, parentID, maxDepth); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE services
+		SET
+			parent_service_id=@parent_service_id,
+			sort_order=(
+				SELECT
+					COALESCE(MAX(sort_order), 0) + 1
+				FROM
+					services
+				WHERE
+					user_id=@user_id
+					AND parent_service_id IS NOT DISTINCT FROM @parent_service_id
+					AND deleted_at IS NULL
+					AND id!=@id
+			)
+		WHERE
+			id=@id
+			AND user_id=@user_id
+		RETURNING
+			*
+	`, pgx.NamedArgs{
+		"id":                serviceID,
+		"user_id":           userID,
+		"parent_service_id": parentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute move service query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	movedService, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:services for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	if err := recordAuditEvent(ctx, tx, userID, audit.EntityService, serviceID, audit.ActionMoved, before, movedService); err != nil {
+		return nil, err
+	}
+
+	if err := recordServiceVersion(ctx, tx, userID, &movedService, nil); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+
+	return &movedService, nil
+}
+
+// checkServiceMove rejects re-parenting serviceID under parentID (the root when nil) when the parent is
+// missing, lies inside the moved subtree, or would push the hierarchy past maxDepth levels. Callers hold
+// the hierarchy lock so the answer stays true until they commit.
+func checkServiceMove(ctx context.Context, tx pgx.Tx, userID string, serviceID uuid.UUID, parentID *uuid.UUID, maxDepth int) error {
+	// height of the subtree being moved, 1 for a leaf
+	var height int
+	err := tx.QueryRow(ctx, `
+		WITH RECURSIVE
+			tree AS (
+				SELECT
+					id,
+					1 AS depth
+				FROM
+					services
+				WHERE
+					id=@id
+					AND user_id=@user_id
+					AND deleted_at IS NULL
+				UNION ALL
+				SELECT
+					child.id,
+					tree.depth + 1
+				FROM
+					services child
+					JOIN tree ON child.parent_service_id=tree.id
+				WHERE
+					child.user_id=@user_id
+					AND child.deleted_at IS NULL
+					AND tree.depth <= @max_depth
+			)
+		SELECT
+			COALESCE(MAX(depth), 0)
+		FROM
+			tree
+	`, pgx.NamedArgs{
+		"id":        serviceID,
+		"user_id":   userID,
+		"max_depth": service.MaxTreeDepth,
+	}).Scan(&height)
+	if err != nil {
+		return fmt.Errorf("failed to execute get subtree height query for id=%s, user_id=%s: %w", serviceID, userID, err)
+	}
+	if height == 0 {
+		return fmt.Errorf("failed to collect row from table:services for id=%s, user_id=%s: %w", serviceID, userID, pgx.ErrNoRows)
+	}
+
+	parentDepth := 0
+	if parentID != nil {
+		var isDescendant bool
+		err = tx.QueryRow(ctx, serviceAncestorsQuery+`
+			SELECT
+				COALESCE(MAX(depth), 0),
+				COALESCE(BOOL_OR(id=@moving_id), FALSE)
+			FROM
+				ancestors
+		`, pgx.NamedArgs{
+			"id":        *parentID,
+			"moving_id": serviceID,
+			"user_id":   userID,
+			"max_depth": service.MaxTreeDepth,
+		}).Scan(&parentDepth, &isDescendant)
+		if err != nil {
+			return fmt.Errorf("failed to execute get parent ancestors query for id=%s, user_id=%s: %w", *parentID, userID, err)
+		}
+		if parentDepth == 0 {
+			code := "PARENT_SERVICE_NOT_FOUND"
+			return errs.NewNotFoundError("Parent service not found", false, &code)
+		}
+		if isDescendant {
+			code := "SERVICE_HIERARCHY_CYCLE"
+			return errs.NewBadRequestError("A service cannot be moved under itself or one of its sub-services", false, &code, nil, nil)
+		}
+	}
+
+	if parentDepth+height > maxDepth {
+		code := "SERVICE_HIERARCHY_TOO_DEEP"
+		return errs.NewBadRequestError(
+			fmt.Sprintf("Service hierarchies may be at most %d levels deep", maxDepth), false, &code, nil, nil,
+		)
+	}
+
+	return nil
+}
+
+// GetAllServices returns every live service of a user, unpaginated, for export and import checks
+func (r *ServiceRepository) GetAllServices(ctx context.Context, userID string) ([]service.Service, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			*
+		FROM
+			services
+		WHERE
+			user_id=@user_id
+			AND deleted_at IS NULL
+		ORDER BY
+			sort_order ASC
+	`, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get all services query for user_id=%s: %w", userID, err)
+	}
+
+	services, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services for user_id=%s: %w", userID, err)
+	}
+
+	return services, nil
+}
+
+// ImportServices creates the named categories and then the services in a single transaction, so an
+// import either lands completely or not at all. Items must be ordered parents-first: an item whose
+// ParentName or CategoryName is set refers to a service or category created earlier in the same call.
+func (r *ServiceRepository) ImportServices(ctx context.Context, userID string, categoryNames []string, items []service.ImportItem) ([]service.Service, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for user_id=%s: %w", userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockServiceHierarchy(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	categoryIDs := make(map[string]uuid.UUID, len(categoryNames))
+	for _, name := range categoryNames {
+		rows, err := tx.Query(ctx, `
+			INSERT INTO
+				services_categories (user_id, name)
+			VALUES
+				(@user_id, @name)
+			RETURNING
+				*
+		`, pgx.NamedArgs{
+			"user_id": userID,
+			"name":    name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute create category query for name=%s, user_id=%s: %w", name, userID, err)
+		}
+
+		categoryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[category.Category])
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect row from table:category for name=%s, user_id=%s: %w", name, userID, err)
+		}
+
+		if err := recordAuditEvent(ctx, tx, userID, audit.EntityCategory, categoryItem.ID, audit.ActionCreated, nil, categoryItem); err != nil {
+			return nil, err
+		}
+		categoryIDs[name] = categoryItem.ID
+	}
+
+	serviceIDs := make(map[string]uuid.UUID, len(items))
+	services := make([]service.Service, 0, len(items))
+	for _, item := range items {
+		payload := item.Payload
+		if item.CategoryName != nil {
+			id, ok := categoryIDs[*item.CategoryName]
+			if !ok {
+				return nil, fmt.Errorf("category %s is not part of this import for user_id=%s", *item.CategoryName, userID)
+			}
+			payload.CategoryID = &id
+		}
+		if item.ParentName != nil {
+			id, ok := serviceIDs[*item.ParentName]
+			if !ok {
+				return nil, fmt.Errorf("parent service %s is not created before %s for user_id=%s", *item.ParentName, payload.Name, userID)
+			}
+			payload.ParentServiceID = &id
+		}
+
+		created, err := insertService(ctx, tx, userID, payload)
+		if err != nil {
+			return nil, err
+		}
+		serviceIDs[created.Name] = created.ID
+		services = append(services, *created)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for user_id=%s: %w", userID, err)
+	}
+
+	return services, nil
+}
@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAuditEvents(t *testing.T) {
+	testServer, repo := setupServiceRepository(t)
+	auditRepo := repository.NewAuditRepository(testServer)
+	userID := "user_" + uuid.NewString()
+	ctx := audit.WithActor(context.Background(), audit.Actor{ID: userID, RequestID: "req_" + uuid.NewString()})
+
+	created := createService(t, repo, userID, "Plumbing", nil)
+
+	payload := &service.UpdateServicePayload{ID: created.ID, Name: ptr("Pipes"), Description: ptr("Leaks and blockages")}
+	require.NoError(t, payload.Validate())
+	_, err := repo.UpdateService(ctx, userID, created.ID, payload, testMaxDepth)
+	require.NoError(t, err)
+
+	// an update that changes nothing is still recorded, with no changes
+	payload = &service.UpdateServicePayload{ID: created.ID, Name: ptr("Pipes")}
+	require.NoError(t, payload.Validate())
+	_, err = repo.UpdateService(ctx, userID, created.ID, payload, testMaxDepth)
+	require.NoError(t, err)
+
+	query := &audit.GetAuditEventsQuery{EntityID: &created.ID, Order: ptr("asc")}
+	require.NoError(t, query.Validate())
+	events, err := auditRepo.GetAuditEvents(ctx, userID, query)
+	require.NoError(t, err)
+	require.Len(t, events.Data, 3)
+
+	t.Run("create records every field with no before value", func(t *testing.T) {
+		event := events.Data[0]
+		assert.Equal(t, audit.ActionCreated, event.Action)
+		assert.Equal(t, audit.EntityService, event.EntityType)
+		assert.Equal(t, audit.SystemActor, event.ActorID)
+		require.Contains(t, event.Changes, "name")
+		assert.Nil(t, event.Changes["name"].Before)
+		assert.Equal(t, "Plumbing", event.Changes["name"].After)
+	})
+
+	t.Run("update records only the fields that differ", func(t *testing.T) {
+		event := events.Data[1]
+		assert.Equal(t, audit.ActionUpdated, event.Action)
+		assert.Equal(t, userID, event.ActorID)
+		require.NotNil(t, event.RequestID)
+		assert.Equal(t, map[string]audit.Change{
+			"name":        {Before: "Plumbing", After: "Pipes"},
+			"description": {Before: nil, After: "Leaks and blockages"},
+		}, event.Changes)
+	})
+
+	t.Run("update without differences records no changes", func(t *testing.T) {
+		event := events.Data[2]
+		assert.Equal(t, audit.ActionUpdated, event.Action)
+		assert.Empty(t, event.Changes)
+	})
+
+	t.Run("events are scoped to the owner", func(t *testing.T) {
+		others, err := auditRepo.GetAuditEvents(ctx, "user_"+uuid.NewString(), query)
+		require.NoError(t, err)
+		assert.Empty(t, others.Data)
+	})
+}
+
+func TestAuditEventsAreAppendOnly(t *testing.T) {
+	testServer, repo := setupServiceRepository(t)
+	ctx := context.Background()
+	userID := "user_" + uuid.NewString()
+
+	created := createService(t, repo, userID, "Plumbing", nil)
+
+	_, err := testServer.DB.Pool.Exec(ctx, "UPDATE audit_events SET action='purged' WHERE entity_id=$1", created.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "append-only")
+
+	_, err = testServer.DB.Pool.Exec(ctx, "DELETE FROM audit_events WHERE entity_id=$1", created.ID)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "append-only")
+}
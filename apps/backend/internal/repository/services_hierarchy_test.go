@@ -0,0 +1,120 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateServiceChecksParent(t *testing.T) {
+	_, repo := setupServiceRepository(t)
+	ctx := context.Background()
+	userID := "user_" + uuid.NewString()
+
+	root := createService(t, repo, userID, "Plumbing", nil)
+	child := createService(t, repo, userID, "Leaks", &root.ID)
+	leaf := createService(t, repo, userID, "Kitchen leaks", &child.ID)
+
+	tests := []struct {
+		name     string
+		userID   string
+		parentID uuid.UUID
+		wantCode string
+	}{
+		{name: "deeper than the limit", userID: userID, parentID: leaf.ID, wantCode: "SERVICE_HIERARCHY_TOO_DEEP"},
+		{name: "unknown parent", userID: userID, parentID: uuid.New(), wantCode: "PARENT_SERVICE_NOT_FOUND"},
+		{name: "another user's parent", userID: "user_" + uuid.NewString(), parentID: root.ID, wantCode: "PARENT_SERVICE_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := &service.CreateServicePayload{Name: "Child", ParentServiceID: &tt.parentID}
+			require.NoError(t, payload.Validate())
+
+			_, err := repo.CreateService(ctx, tt.userID, payload, testMaxDepth)
+			assertErrorCode(t, err, tt.wantCode)
+		})
+	}
+
+	t.Run("trashed parent", func(t *testing.T) {
+		trashed := createService(t, repo, userID, "Roofing", nil)
+		require.NoError(t, repo.DeleteService(ctx, userID, trashed.ID))
+
+		payload := &service.CreateServicePayload{Name: "Gutters", ParentServiceID: &trashed.ID}
+		require.NoError(t, payload.Validate())
+
+		_, err := repo.CreateService(ctx, userID, payload, testMaxDepth)
+		assertErrorCode(t, err, "PARENT_SERVICE_NOT_FOUND")
+	})
+}
+
+func TestMoveService(t *testing.T) {
+	_, repo := setupServiceRepository(t)
+	ctx := context.Background()
+	userID := "user_" + uuid.NewString()
+
+	// plumbing > leaks > kitchen leaks, and roofing > gutters
+	plumbing := createService(t, repo, userID, "Plumbing", nil)
+	leaks := createService(t, repo, userID, "Leaks", &plumbing.ID)
+	kitchen := createService(t, repo, userID, "Kitchen leaks", &leaks.ID)
+	roofing := createService(t, repo, userID, "Roofing", nil)
+	gutters := createService(t, repo, userID, "Gutters", &roofing.ID)
+
+	tests := []struct {
+		name      string
+		serviceID uuid.UUID
+		parentID  *uuid.UUID
+		wantCode  string
+	}{
+		{name: "under its own child", serviceID: plumbing.ID, parentID: &leaks.ID, wantCode: "SERVICE_HIERARCHY_CYCLE"},
+		{name: "under its own grandchild", serviceID: plumbing.ID, parentID: &kitchen.ID, wantCode: "SERVICE_HIERARCHY_CYCLE"},
+		{name: "under itself", serviceID: leaks.ID, parentID: &leaks.ID, wantCode: "SERVICE_HIERARCHY_CYCLE"},
+		{name: "subtree too deep", serviceID: leaks.ID, parentID: &gutters.ID, wantCode: "SERVICE_HIERARCHY_TOO_DEEP"},
+		{name: "unknown parent", serviceID: gutters.ID, parentID: ptr(uuid.New()), wantCode: "PARENT_SERVICE_NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.MoveService(ctx, userID, tt.serviceID, tt.parentID, testMaxDepth)
+			assertErrorCode(t, err, tt.wantCode)
+		})
+	}
+
+	t.Run("under another branch", func(t *testing.T) {
+		moved, err := repo.MoveService(ctx, userID, kitchen.ID, &gutters.ID, testMaxDepth)
+		require.NoError(t, err)
+		require.NotNil(t, moved.ParentServiceID)
+		assert.Equal(t, gutters.ID, *moved.ParentServiceID)
+	})
+
+	t.Run("to the root", func(t *testing.T) {
+		moved, err := repo.MoveService(ctx, userID, leaks.ID, nil, testMaxDepth)
+		require.NoError(t, err)
+		assert.Nil(t, moved.ParentServiceID)
+	})
+}
+
+func TestUpdateServiceParentChecks(t *testing.T) {
+	_, repo := setupServiceRepository(t)
+	ctx := context.Background()
+	userID := "user_" + uuid.NewString()
+
+	parent := createService(t, repo, userID, "Plumbing", nil)
+	child := createService(t, repo, userID, "Leaks", &parent.ID)
+
+	_, err := repo.UpdateService(ctx, userID, parent.ID, &service.UpdateServicePayload{
+		ID:              parent.ID,
+		Name:            ptr("Plumbing and heating"),
+		ParentServiceID: &child.ID,
+	}, testMaxDepth)
+	assertErrorCode(t, err, "SERVICE_HIERARCHY_CYCLE")
+
+	// the rejected move takes the other fields with it
+	unchanged, err := repo.GetServiceByID(ctx, userID, parent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Plumbing", unchanged.Name)
+}
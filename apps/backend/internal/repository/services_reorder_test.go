@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReorderServices(t *testing.T) {
+	_, repo := setupServiceRepository(t)
+	ctx := context.Background()
+	userID := "user_" + uuid.NewString()
+
+	parent := createService(t, repo, userID, "Plumbing", nil)
+	first := createService(t, repo, userID, "Taps", &parent.ID)
+	second := createService(t, repo, userID, "Drains", &parent.ID)
+	third := createService(t, repo, userID, "Pipes", &parent.ID)
+	other := createService(t, repo, userID, "Roofing", nil)
+
+	t.Run("new order", func(t *testing.T) {
+		reordered, err := repo.ReorderServices(ctx, userID, &parent.ID, []uuid.UUID{third.ID, first.ID, second.ID})
+		require.NoError(t, err)
+
+		require.Len(t, reordered, 3)
+		assert.Equal(t, []uuid.UUID{third.ID, first.ID, second.ID}, []uuid.UUID{reordered[0].ID, reordered[1].ID, reordered[2].ID})
+		assert.Less(t, reordered[0].SortOrder, reordered[1].SortOrder)
+		assert.Less(t, reordered[1].SortOrder, reordered[2].SortOrder)
+	})
+
+	t.Run("root services", func(t *testing.T) {
+		reordered, err := repo.ReorderServices(ctx, userID, nil, []uuid.UUID{other.ID, parent.ID})
+		require.NoError(t, err)
+
+		require.Len(t, reordered, 2)
+		assert.Equal(t, other.ID, reordered[0].ID)
+	})
+
+	t.Run("service under another parent", func(t *testing.T) {
+		_, err := repo.ReorderServices(ctx, userID, &parent.ID, []uuid.UUID{first.ID, second.ID, other.ID})
+		assertErrorCode(t, err, "SERVICE_REORDER_INVALID")
+	})
+
+	t.Run("another user's services", func(t *testing.T) {
+		_, err := repo.ReorderServices(ctx, "user_"+uuid.NewString(), &parent.ID, []uuid.UUID{third.ID, first.ID, second.ID})
+		assertErrorCode(t, err, "SERVICE_REORDER_INVALID")
+	})
+
+	t.Run("missing sibling", func(t *testing.T) {
+		_, err := repo.ReorderServices(ctx, userID, &parent.ID, []uuid.UUID{first.ID, second.ID})
+		assertErrorCode(t, err, "SERVICE_REORDER_INCOMPLETE")
+	})
+
+	t.Run("trashed sibling is not listed", func(t *testing.T) {
+		require.NoError(t, repo.DeleteService(ctx, userID, third.ID))
+
+		reordered, err := repo.ReorderServices(ctx, userID, &parent.ID, []uuid.UUID{second.ID, first.ID})
+		require.NoError(t, err)
+		assert.Len(t, reordered, 2)
+
+		_, err = repo.ReorderServices(ctx, userID, &parent.ID, []uuid.UUID{second.ID, first.ID, third.ID})
+		assertErrorCode(t, err, "SERVICE_REORDER_INVALID")
+	})
+}
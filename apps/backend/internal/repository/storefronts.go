@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/servicearea"
+	"github.com/mukundaparajuli/fixr/internal/model/storefront"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type StorefrontRepository struct {
+	server *server.Server
+}
+
+func NewStorefrontRepository(s *server.Server) *StorefrontRepository {
+	return &StorefrontRepository{server: s}
+}
+
+// UpsertStorefront creates the user's storefront or replaces its handle and profile
+func (r *StorefrontRepository) UpsertStorefront(ctx context.Context, userID string, payload *storefront.UpsertStorefrontPayload) (*storefront.Storefront, error) {
+	stmt := `
+		INSERT INTO
+			storefronts (
+				user_id,
+				handle,
+				display_name,
+				bio
+			)
+		VALUES
+			(
+				@user_id,
+				@handle,
+				@display_name,
+				@bio
+			)
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			handle=EXCLUDED.handle,
+			display_name=EXCLUDED.display_name,
+			bio=EXCLUDED.bio
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":      userID,
+		"handle":       payload.Handle,
+		"display_name": payload.DisplayName,
+		"bio":          payload.Bio,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute upsert storefront query for user_id=%s: %w", userID, err)
+	}
+
+	storefrontItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[storefront.Storefront])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:storefronts for user_id=%s: %w", userID, err)
+	}
+
+	return &storefrontItem, nil
+}
+
+func (r *StorefrontRepository) GetStorefront(ctx context.Context, userID string) (*storefront.Storefront, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			storefronts
+		WHERE
+			user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get storefront query for user_id=%s: %w", userID, err)
+	}
+
+	storefrontItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[storefront.Storefront])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:storefronts for user_id=%s: %w", userID, err)
+	}
+
+	return &storefrontItem, nil
+}
+
+func (r *StorefrontRepository) GetStorefrontByHandle(ctx context.Context, handle string) (*storefront.Storefront, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			storefronts
+		WHERE
+			handle=@handle
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"handle": handle,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get storefront by handle query for handle=%s: %w", handle, err)
+	}
+
+	storefrontItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[storefront.Storefront])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:storefronts for handle=%s: %w", handle, err)
+	}
+
+	return &storefrontItem, nil
+}
+
+// GetPublicProviders lists the storefronts whose provider has a service area covering the location
+// and at least one active service to offer
+func (r *StorefrontRepository) GetPublicProviders(ctx context.Context, query *storefront.GetPublicProvidersQuery) (*model.PaginatedResponse[storefront.Storefront], error) {
+	args := pgx.NamedArgs{}
+	conditions := append([]string{`EXISTS (
+		SELECT 1 FROM services s
+		WHERE s.user_id = sf.user_id
+			AND s.status = 'active'
+			AND s.deleted_at IS NULL
+	)`}, serviceAreaConditions("sf.user_id", servicearea.Location{
+		Lat:        query.Lat,
+		Lng:        query.Lng,
+		WithinKm:   query.WithinKm,
+		PostalCode: query.PostalCode,
+	}, args)...)
+	where := " WHERE " + strings.Join(conditions, " AND ")
+
+	var total int
+	if err := r.server.DB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM storefronts sf"+where, args).Scan(&total); err != nil {
+		return nil, fmt.Errorf("failed to get total count for public providers: %w", err)
+	}
+
+	stmt := `
+		SELECT
+			sf.*
+		FROM
+			storefronts sf
+	` + where + `
+		ORDER BY
+			sf.display_name ASC,
+			sf.handle ASC
+		LIMIT
+			@limit
+		OFFSET
+			@offset
+	`
+	args["limit"] = *query.Limit
+	args["offset"] = (*query.Page - 1) * (*query.Limit)
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get public providers query: %w", err)
+	}
+
+	storefronts, err := pgx.CollectRows(rows, pgx.RowToStructByName[storefront.Storefront])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:storefronts: %w", err)
+	}
+
+	return &model.PaginatedResponse[storefront.Storefront]{
+		Data:       storefronts,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
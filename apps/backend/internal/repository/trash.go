@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/model/category"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/model/trash"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type TrashRepository struct {
+	server *server.Server
+}
+
+func NewTrashRepository(s *server.Server) *TrashRepository {
+	return &TrashRepository{server: s}
+}
+
+func (r *TrashRepository) GetTrash(ctx context.Context, userID string) (*trash.Trash, error) {
+	args := pgx.NamedArgs{
+		"user_id": userID,
+	}
+
+	// a service is the root of its batch when its parent was not trashed at the same moment
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			s.*
+		FROM
+			services s
+			LEFT JOIN services p ON p.id=s.parent_service_id
+		WHERE
+			s.user_id=@user_id
+			AND s.deleted_at IS NOT NULL
+			AND p.deleted_at IS DISTINCT FROM s.deleted_at
+		ORDER BY
+			s.deleted_at DESC
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get trashed services query for user_id=%s: %w", userID, err)
+	}
+
+	services, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services for user_id=%s: %w", userID, err)
+	}
+
+	rows, err = r.server.DB.Pool.Query(ctx, `
+		SELECT
+			*
+		FROM
+			services_categories
+		WHERE
+			user_id=@user_id
+			AND deleted_at IS NOT NULL
+		ORDER BY
+			deleted_at DESC
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get trashed categories query for user_id=%s: %w", userID, err)
+	}
+
+	categories, err := pgx.CollectRows(rows, pgx.RowToStructByName[category.Category])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services_categories for user_id=%s: %w", userID, err)
+	}
+
+	rows, err = r.server.DB.Pool.Query(ctx, `
+		SELECT
+			a.*
+		FROM
+			services_attachment a
+			JOIN services s ON s.id=a.service_id
+		WHERE
+			s.user_id=@user_id
+			AND a.deleted_at IS NOT NULL
+			AND s.deleted_at IS NULL
+		ORDER BY
+			a.deleted_at DESC
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get trashed attachments query for user_id=%s: %w", userID, err)
+	}
+
+	attachments, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.ServiceAttachment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:attachments for user_id=%s: %w", userID, err)
+	}
+
+	return &trash.Trash{
+		Services:    services,
+		Categories:  categories,
+		Attachments: attachments,
+	}, nil
+}
+
+// PurgeTrash permanently deletes everything trashed before cutoff, across all users. Services that
+// were ever booked stay in the trash, see purgeServices. Descendants of a purged service are always
+// trashed no later than it, so the parent cascade only ever reaches rows that are due anyway.
+func (r *TrashRepository) PurgeTrash(ctx context.Context, cutoff time.Time) (*trash.PurgeResult, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for purge cutoff=%s: %w", cutoff, err)
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"cutoff":   cutoff,
+		"actor_id": audit.ActorFromContext(ctx).ID,
+	}
+
+	purged, err := purgeServices(ctx, tx, "s.deleted_at < @cutoff", "a.deleted_at < @cutoff", args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge services for cutoff=%s: %w", cutoff, err)
+	}
+
+	categoriesResult, err := tx.Exec(ctx, `
+		WITH
+			purged AS (
+				DELETE FROM services_categories
+				WHERE
+					deleted_at < @cutoff
+				RETURNING
+					id
+			)
+		INSERT INTO
+			audit_events (user_id, actor_id, entity_type, entity_id, action, changes)
+		SELECT
+			c.user_id,
+			@actor_id,
+			'category',
+			c.id,
+			'purged',
+			audit_diff(camel (c), NULL)
+		FROM
+			purged
+			JOIN services_categories c ON c.id=purged.id
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute purge categories query for cutoff=%s: %w", cutoff, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for purge cutoff=%s: %w", cutoff, err)
+	}
+
+	return &trash.PurgeResult{
+		Services:         purged.Services,
+		RetainedServices: purged.Retained,
+		Categories:       categoriesResult.RowsAffected(),
+		Attachments:      purged.Attachments,
+		DownloadKeys:     purged.DownloadKeys,
+	}, nil
+}
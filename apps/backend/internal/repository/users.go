@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/model/user"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type UserRepository struct {
+	server *server.Server
+}
+
+func NewUserRepository(s *server.Server) *UserRepository {
+	return &UserRepository{server: s}
+}
+
+// UpsertUser stores a Clerk profile and reports whether it was new. A profile older than the stored
+// one (an out of order webhook) or of a deleted user is ignored and returned as nil.
+func (r *UserRepository) UpsertUser(ctx context.Context, profile *user.User) (*user.User, bool, error) {
+	stmt := `
+		INSERT INTO
+			users (
+				id,
+				email,
+				username,
+				first_name,
+				last_name,
+				image_url,
+				clerk_updated_at
+			)
+		SELECT
+			@id,
+			@email,
+			@username,
+			@first_name,
+			@last_name,
+			@image_url,
+			@clerk_updated_at::TIMESTAMPTZ
+		WHERE
+			NOT EXISTS (
+				SELECT
+					1
+				FROM
+					deleted_users
+				WHERE
+					id=@id
+			)
+		ON CONFLICT (id) DO UPDATE
+		SET
+			email=EXCLUDED.email,
+			username=EXCLUDED.username,
+			first_name=EXCLUDED.first_name,
+			last_name=EXCLUDED.last_name,
+			image_url=EXCLUDED.image_url,
+			clerk_updated_at=EXCLUDED.clerk_updated_at
+		WHERE
+			users.clerk_updated_at<=EXCLUDED.clerk_updated_at
+		RETURNING
+			*,
+			(xmax=0) AS inserted
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":               profile.ID,
+		"email":            profile.Email,
+		"username":         profile.Username,
+		"first_name":       profile.FirstName,
+		"last_name":        profile.LastName,
+		"image_url":        profile.ImageURL,
+		"clerk_updated_at": profile.ClerkUpdatedAt,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to execute upsert user query for id=%s: %w", profile.ID, err)
+	}
+
+	type upserted struct {
+		user.User
+		Inserted bool `db:"inserted"`
+	}
+
+	result, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[upserted])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to collect row from table:users for id=%s: %w", profile.ID, err)
+	}
+
+	return &result.User, result.Inserted, nil
+}
+
+// MarkWelcomeEnqueued records that the user's welcome email is queued
+func (r *UserRepository) MarkWelcomeEnqueued(ctx context.Context, userID string) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE users
+		SET
+			welcome_enqueued_at=CURRENT_TIMESTAMP
+		WHERE
+			id=@id
+	`, pgx.NamedArgs{
+		"id": userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute mark welcome enqueued query for id=%s: %w", userID, err)
+	}
+
+	return nil
+}
+
+// DeleteUser removes a user's profile and everything they provide: services, attachments,
+// categories, storefront, service areas, availability and webhook endpoints, and revokes the API
+// keys they created. Services are purged like the trash; the ones a customer booked are moved to the
+// trash instead, so bookings and reviews made by customers keep their service. The user's own
+// bookings and reviews as a customer are left alone. The user id is recorded in deleted_users so a
+// redelivered profile webhook cannot re-create them. The attachment blob keys are returned for the
+// caller to delete after commit.
+func (r *UserRepository) DeleteUser(ctx context.Context, userID string) (*user.DeleteResult, error) {
+	tx, err := r.server.DB.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction for user_id=%s: %w", userID, err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockServiceHierarchy(ctx, tx, userID); err != nil {
+		return nil, err
+	}
+
+	args := pgx.NamedArgs{
+		"user_id":  userID,
+		"actor_id": audit.ActorFromContext(ctx).ID,
+	}
+
+	purged, err := purgeServices(ctx, tx, "s.user_id=@user_id", `a.service_id IN (
+		SELECT
+			id
+		FROM
+			services
+		WHERE
+			user_id=@user_id
+	)`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge services for user_id=%s: %w", userID, err)
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE services
+		SET
+			deleted_at=CURRENT_TIMESTAMP
+		WHERE
+			user_id=@user_id
+			AND deleted_at IS NULL
+		RETURNING
+			*
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute trash retained services query for user_id=%s: %w", userID, err)
+	}
+
+	trashedServices, err := pgx.CollectRows(rows, pgx.RowToStructByName[service.Service])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:services for user_id=%s: %w", userID, err)
+	}
+
+	for _, trashed := range trashedServices {
+		before := trashed
+		before.DeletedAt = nil
+		if err := recordAuditEvent(ctx, tx, userID, audit.EntityService, trashed.ID, audit.ActionDeleted, before, trashed); err != nil {
+			return nil, err
+		}
+	}
+
+	categoriesResult, err := tx.Exec(ctx, `
+		WITH
+			purged AS (
+				DELETE FROM services_categories
+				WHERE
+					user_id=@user_id
+				RETURNING
+					id
+			)
+		INSERT INTO
+			audit_events (user_id, actor_id, entity_type, entity_id, action, changes)
+		SELECT
+			c.user_id,
+			@actor_id,
+			'category',
+			c.id,
+			'purged',
+			audit_diff(camel (c), NULL)
+		FROM
+			purged
+			JOIN services_categories c ON c.id=purged.id
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute delete categories query for user_id=%s: %w", userID, err)
+	}
+
+	// rows nothing else points at; webhook deliveries go with their endpoint
+	for _, table := range []string{
+		"storefronts",
+		"service_areas",
+		"availability_settings",
+		"availability_rules",
+		"availability_overrides",
+		"webhook_endpoints",
+	} {
+		if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE user_id=@user_id`, args); err != nil {
+			return nil, fmt.Errorf("failed to execute delete %s query for user_id=%s: %w", table, userID, err)
+		}
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE api_keys
+		SET
+			revoked_at=CURRENT_TIMESTAMP
+		WHERE
+			created_by=@user_id
+			AND revoked_at IS NULL
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke api keys for user_id=%s: %w", userID, err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM users WHERE id=@user_id`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute delete user query for id=%s: %w", userID, err)
+	}
+
+	// the tombstone keeps redelivered profile webhooks from re-creating the user
+	_, err = tx.Exec(ctx, `
+		INSERT INTO
+			deleted_users (id)
+		VALUES
+			(@user_id)
+		ON CONFLICT (id) DO NOTHING
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record deleted user for id=%s: %w", userID, err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction for user_id=%s: %w", userID, err)
+	}
+
+	return &user.DeleteResult{
+		Services:         purged.Services,
+		RetainedServices: int64(len(trashedServices)),
+		Categories:       categoriesResult.RowsAffected(),
+		Attachments:      purged.Attachments,
+		DownloadKeys:     purged.DownloadKeys,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mukundaparajuli/fixr/internal/model/user"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertUser(t *testing.T) {
+	testServer, serviceRepo := setupServiceRepository(t)
+	repo := repository.NewUserRepository(testServer)
+	ctx := context.Background()
+
+	userID := "user_" + uuid.NewString()
+	updatedAt := time.Now().Truncate(time.Millisecond)
+	profile := func(firstName string, clerkUpdatedAt time.Time) *user.User {
+		return &user.User{ID: userID, FirstName: ptr(firstName), ClerkUpdatedAt: clerkUpdatedAt}
+	}
+
+	created, inserted, err := repo.UpsertUser(ctx, profile("Ada", updatedAt))
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.True(t, inserted)
+
+	t.Run("newer profile updates the user", func(t *testing.T) {
+		updated, inserted, err := repo.UpsertUser(ctx, profile("Grace", updatedAt.Add(time.Minute)))
+		require.NoError(t, err)
+		require.NotNil(t, updated)
+		assert.False(t, inserted)
+		assert.Equal(t, "Grace", *updated.FirstName)
+	})
+
+	t.Run("older profile is ignored", func(t *testing.T) {
+		stale, _, err := repo.UpsertUser(ctx, profile("Ada", updatedAt))
+		require.NoError(t, err)
+		assert.Nil(t, stale)
+	})
+
+	t.Run("deleted user is not re-created", func(t *testing.T) {
+		createService(t, serviceRepo, userID, "Plumbing", nil)
+
+		_, err := repo.DeleteUser(ctx, userID)
+		require.NoError(t, err)
+
+		recreated, _, err := repo.UpsertUser(ctx, profile("Grace", updatedAt.Add(time.Hour)))
+		require.NoError(t, err)
+		assert.Nil(t, recreated)
+
+		// deleting again, as a redelivered user.deleted webhook does, is harmless
+		_, err = repo.DeleteUser(ctx, userID)
+		require.NoError(t, err)
+	})
+}
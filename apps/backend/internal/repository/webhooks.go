@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/webhook"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type WebhookRepository struct {
+	server *server.Server
+}
+
+func NewWebhookRepository(s *server.Server) *WebhookRepository {
+	return &WebhookRepository{server: s}
+}
+
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, userID string, secret string, payload *webhook.CreateEndpointPayload) (*webhook.Endpoint, error) {
+	stmt := `
+		INSERT INTO
+			webhook_endpoints (
+				user_id,
+				url,
+				description,
+				secret,
+				events
+			)
+		VALUES
+			(
+				@user_id,
+				@url,
+				@description,
+				@secret,
+				@events
+			)
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":     userID,
+		"url":         payload.URL,
+		"description": payload.Description,
+		"secret":      secret,
+		"events":      payload.Events,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create webhook endpoint query for user_id=%s: %w", userID, err)
+	}
+
+	endpoint, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_endpoints for user_id=%s: %w", userID, err)
+	}
+
+	return &endpoint, nil
+}
+
+func (r *WebhookRepository) GetEndpoints(ctx context.Context, userID string) ([]webhook.Endpoint, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			webhook_endpoints
+		WHERE
+			user_id=@user_id
+		ORDER BY
+			created_at DESC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get webhook endpoints query for user_id=%s: %w", userID, err)
+	}
+
+	endpoints, err := pgx.CollectRows(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:webhook_endpoints for user_id=%s: %w", userID, err)
+	}
+
+	return endpoints, nil
+}
+
+func (r *WebhookRepository) GetEndpointByID(ctx context.Context, userID string, endpointID uuid.UUID) (*webhook.Endpoint, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			webhook_endpoints
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      endpointID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get webhook endpoint by id query for id=%s, user_id=%s: %w", endpointID, userID, err)
+	}
+
+	endpoint, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_endpoints for id=%s, user_id=%s: %w", endpointID, userID, err)
+	}
+
+	return &endpoint, nil
+}
+
+func (r *WebhookRepository) UpdateEndpoint(ctx context.Context, userID string, payload *webhook.UpdateEndpointPayload) (*webhook.Endpoint, error) {
+	stmt := `UPDATE webhook_endpoints SET `
+	args := pgx.NamedArgs{
+		"id":      payload.ID,
+		"user_id": userID,
+	}
+	setClauses := []string{}
+
+	if payload.URL != nil {
+		setClauses = append(setClauses, "url = @url")
+		args["url"] = *payload.URL
+	}
+
+	if payload.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *payload.Description
+	}
+
+	if payload.Events != nil {
+		setClauses = append(setClauses, "events = @events")
+		args["events"] = *payload.Events
+	}
+
+	if payload.Active != nil {
+		setClauses = append(setClauses, "active = @active")
+		args["active"] = *payload.Active
+	}
+
+	if len(setClauses) == 0 {
+		return r.GetEndpointByID(ctx, userID, payload.ID)
+	}
+
+	stmt += strings.Join(setClauses, ", ")
+	stmt += ` WHERE id = @id AND user_id = @user_id RETURNING *`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update webhook endpoint query for id=%s, user_id=%s: %w", payload.ID, userID, err)
+	}
+
+	endpoint, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_endpoints for id=%s, user_id=%s: %w", payload.ID, userID, err)
+	}
+
+	return &endpoint, nil
+}
+
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, userID string, endpointID uuid.UUID) error {
+	stmt := `
+		DELETE FROM webhook_endpoints
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	result, err := r.server.DB.Pool.Exec(ctx, stmt, pgx.NamedArgs{
+		"id":      endpointID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to execute delete webhook endpoint query for id=%s, user_id=%s: %w", endpointID, userID, err)
+	}
+
+	if result.RowsAffected() == 0 {
+		code := "WEBHOOK_ENDPOINT_NOT_FOUND"
+		return errs.NewNotFoundError("webhook endpoint not found", false, &code)
+	}
+
+	return nil
+}
+
+// CreateDeliveries queues the event for every active endpoint of the owner subscribed to it
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, userID string, event *webhook.Event) ([]webhook.Delivery, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook event id=%s: %w", event.ID, err)
+	}
+
+	stmt := `
+		INSERT INTO
+			webhook_deliveries (endpoint_id, user_id, event_id, event_type, payload)
+		SELECT
+			id,
+			user_id,
+			@event_id,
+			@event_type,
+			@payload
+		FROM
+			webhook_endpoints
+		WHERE
+			user_id=@user_id
+			AND active
+			AND @event_type=ANY (events)
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":    userID,
+		"event_id":   event.ID,
+		"event_type": event.Type,
+		"payload":    payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create webhook deliveries query for user_id=%s: %w", userID, err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[webhook.Delivery])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:webhook_deliveries for user_id=%s: %w", userID, err)
+	}
+
+	return deliveries, nil
+}
+
+// Redeliver queues a copy of a delivery with the same event id, so receivers can deduplicate it
+func (r *WebhookRepository) Redeliver(ctx context.Context, userID string, endpointID uuid.UUID, deliveryID uuid.UUID) (*webhook.Delivery, error) {
+	stmt := `
+		INSERT INTO
+			webhook_deliveries (endpoint_id, user_id, event_id, event_type, payload, redelivery_of)
+		SELECT
+			endpoint_id,
+			user_id,
+			event_id,
+			event_type,
+			payload,
+			id
+		FROM
+			webhook_deliveries
+		WHERE
+			id=@id
+			AND endpoint_id=@endpoint_id
+			AND user_id=@user_id
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":          deliveryID,
+		"endpoint_id": endpointID,
+		"user_id":     userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute redeliver webhook query for id=%s, user_id=%s: %w", deliveryID, userID, err)
+	}
+
+	delivery, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Delivery])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_deliveries for id=%s, user_id=%s: %w", deliveryID, userID, err)
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveryWithEndpoint loads a delivery for sending; unlike the API lookups it is not scoped to an owner
+func (r *WebhookRepository) GetDeliveryWithEndpoint(ctx context.Context, deliveryID uuid.UUID) (*webhook.Delivery, *webhook.Endpoint, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			*
+		FROM
+			webhook_deliveries
+		WHERE
+			id=@id
+	`, pgx.NamedArgs{
+		"id": deliveryID,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute get webhook delivery query for id=%s: %w", deliveryID, err)
+	}
+
+	delivery, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Delivery])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to collect row from table:webhook_deliveries for id=%s: %w", deliveryID, err)
+	}
+
+	endpoint, err := r.GetEndpointByID(ctx, delivery.UserID, delivery.EndpointID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &delivery, endpoint, nil
+}
+
+// RecordDeliveryAttempt stores the outcome of one attempt. responseStatus and responseBody are nil
+// when no response was received; errMsg is nil on success.
+func (r *WebhookRepository) RecordDeliveryAttempt(
+	ctx context.Context,
+	deliveryID uuid.UUID,
+	status webhook.DeliveryStatus,
+	responseStatus *int,
+	responseBody *string,
+	errMsg *string,
+) error {
+	stmt := `
+		UPDATE webhook_deliveries
+		SET
+			status=@status,
+			attempts=attempts + 1,
+			response_status=@response_status,
+			response_body=@response_body,
+			error=@error,
+			delivered_at=CASE
+				WHEN @status='succeeded' THEN CURRENT_TIMESTAMP
+				ELSE delivered_at
+			END
+		WHERE
+			id=@id
+	`
+
+	_, err := r.server.DB.Pool.Exec(ctx, stmt, pgx.NamedArgs{
+		"id":              deliveryID,
+		"status":          status,
+		"response_status": responseStatus,
+		"response_body":   responseBody,
+		"error":           errMsg,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt for id=%s: %w", deliveryID, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetDeliveries(ctx context.Context, userID string, query *webhook.GetDeliveriesQuery) (*model.PaginatedResponse[webhook.Delivery], error) {
+	args := pgx.NamedArgs{
+		"endpoint_id": query.ID,
+		"user_id":     userID,
+	}
+	where := " WHERE endpoint_id=@endpoint_id AND user_id=@user_id"
+
+	if query.Status != nil {
+		where += " AND status=@status"
+		args["status"] = *query.Status
+	}
+
+	var total int
+	err := r.server.DB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM webhook_deliveries"+where, args).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of webhook deliveries for endpoint_id=%s, user_id=%s: %w", query.ID, userID, err)
+	}
+
+	stmt := "SELECT * FROM webhook_deliveries" + where + " ORDER BY created_at DESC, id DESC LIMIT @limit OFFSET @offset"
+	args["limit"] = *query.Limit
+	args["offset"] = (*query.Page - 1) * (*query.Limit)
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get webhook deliveries query for endpoint_id=%s, user_id=%s: %w", query.ID, userID, err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[webhook.Delivery])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:webhook_deliveries for endpoint_id=%s, user_id=%s: %w", query.ID, userID, err)
+	}
+
+	return &model.PaginatedResponse[webhook.Delivery]{
+		Data:       deliveries,
+		Page:       *query.Page,
+		Limit:      *query.Limit,
+		Total:      total,
+		TotalPages: (total + *query.Limit - 1) / *query.Limit,
+	}, nil
+}
@@ -1,12 +1,10 @@
 package router
 
 import (
-	"net/http"
-
 	"github.com/labstack/echo/v4"
-	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/mukundaparajuli/fixr/internal/handler"
 	"github.com/mukundaparajuli/fixr/internal/middleware"
+	v1 "github.com/mukundaparajuli/fixr/internal/router/v1"
 	"github.com/mukundaparajuli/fixr/internal/server"
 	"github.com/mukundaparajuli/fixr/internal/service"
 	"golang.org/x/time/rate"
@@ -21,25 +19,7 @@ func NewRouter(s *server.Server, h *handler.Handlers, services *service.Services
 
 	// global middlewares
 	router.Use(
-		echoMiddleware.RateLimiterWithConfig(echoMiddleware.RateLimiterConfig{
-			Store: echoMiddleware.NewRateLimiterMemoryStore(rate.Limit(20)),
-			DenyHandler: func(c echo.Context, identifier string, err error) error {
-				// Record rate limit hit metrics
-				if rateLimitMiddleware := middlewares.RateLimit; rateLimitMiddleware != nil {
-					rateLimitMiddleware.RecordRateLimitHit(c.Path())
-				}
-
-				s.Logger.Warn().
-					Str("request_id", middleware.GetRequestID(c)).
-					Str("identifier", identifier).
-					Str("path", c.Path()).
-					Str("method", c.Request().Method).
-					Str("ip", c.RealIP()).
-					Msg("rate limit exceeded")
-
-				return echo.NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded")
-			},
-		}),
+		middlewares.RateLimit.Limit(rate.Limit(20), 20),
 		middlewares.Global.CORS(),
 		middlewares.Global.Secure(),
 		middleware.RequestID(),
@@ -53,8 +33,12 @@ func NewRouter(s *server.Server, h *handler.Handlers, services *service.Services
 	// register system routes
 	registerSystemRoutes(router, h)
 
+	// register inbound webhooks from third parties
+	registerWebhookRoutes(router, h)
+
 	// register versioned routes
-	router.Group("/api/v1")
+	v1Router := router.Group("/api/v1")
+	v1.RegisterV1Routes(v1Router, h, middlewares)
 
 	return router
 }
@@ -0,0 +1,17 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerAPIKeyRoutes(r *echo.Group, h *handler.APIKeyHandler, auth *middleware.AuthMiddleware) {
+	// API keys can never hold api_keys:manage, so keys are only managed from a session
+	keys := r.Group("/api-keys")
+	keys.Use(auth.RequireAuth, auth.RequirePermission(middleware.PermAPIKeysManage))
+
+	keys.POST("", h.CreateAPIKey)
+	keys.GET("", h.GetAPIKeys)
+	keys.DELETE("/:id", h.RevokeAPIKey)
+}
@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerAttachmentRoutes(r *echo.Group, h *handler.AttachmentHandler, auth *middleware.AuthMiddleware, global *middleware.GlobalMiddlewares) {
+	// Attachment operations scoped to a service
+	attachments := r.Group("/services/:id/attachments")
+	attachments.Use(auth.RequireAuth)
+
+	write := auth.RequirePermission(middleware.PermAttachmentsWrite)
+	remove := auth.RequirePermission(middleware.PermAttachmentsDelete)
+
+	attachments.POST("", h.UploadAttachment, global.UploadBodyLimit(), write)
+	attachments.GET("", h.GetServiceAttachments)
+
+	dynamicAttachment := attachments.Group("/:attachmentId")
+	dynamicAttachment.GET("/download", h.GetAttachmentDownloadURL)
+	dynamicAttachment.DELETE("", h.DeleteAttachment, remove)
+	dynamicAttachment.POST("/restore", h.RestoreAttachment, remove)
+
+	// Signed downloads are authorized by the URL signature, not a session
+	r.GET("/attachments/download", h.DownloadAttachment)
+}
@@ -0,0 +1,12 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerAuditRoutes(r *echo.Group, h *handler.AuditHandler, auth *middleware.AuthMiddleware) {
+	// Audit events are read-only; they are written by the repositories alongside each change
+	r.GET("/audit", h.GetAuditEvents, auth.RequireAuth, auth.RequirePermission(middleware.PermAuditRead))
+}
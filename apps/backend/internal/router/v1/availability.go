@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerAvailabilityRoutes(r *echo.Group, h *handler.AvailabilityHandler, auth *middleware.AuthMiddleware) {
+	// Provider's own schedule
+	schedule := r.Group("/availability")
+	schedule.Use(auth.RequireAuth)
+
+	write := auth.RequirePermission(middleware.PermAvailabilityWrite)
+
+	schedule.GET("", h.GetSchedule)
+	schedule.PUT("", h.UpdateScheduleRules, write)
+
+	// Date specific overrides
+	schedule.PUT("/overrides/:date", h.SetOverride, write)
+	schedule.DELETE("/overrides/:date", h.DeleteOverride, write)
+
+	// Open slots of a bookable service
+	slots := r.Group("/services/:id/slots")
+	slots.Use(auth.RequireAuth)
+
+	slots.GET("", h.GetServiceSlots)
+}
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerBookingRoutes(r *echo.Group, h *handler.BookingHandler, auth *middleware.AuthMiddleware) {
+	// Booking operations
+	bookings := r.Group("/bookings")
+	bookings.Use(auth.RequireAuth)
+
+	write := auth.RequirePermission(middleware.PermBookingsWrite)
+
+	// Collection operations
+	bookings.POST("", h.CreateBooking, write)
+	bookings.GET("", h.GetBookings)
+
+	// Individual booking operations
+	dynamicBooking := bookings.Group("/:id")
+	dynamicBooking.GET("", h.GetBookingByID)
+
+	// State transitions
+	dynamicBooking.POST("/confirm", h.ConfirmBooking, write)
+	dynamicBooking.POST("/decline", h.DeclineBooking, write)
+	dynamicBooking.POST("/cancel", h.CancelBooking, write)
+	dynamicBooking.POST("/reschedule", h.RescheduleBooking, write)
+	dynamicBooking.POST("/complete", h.CompleteBooking, write)
+}
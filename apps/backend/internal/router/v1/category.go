@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerCategoryRoutes(r *echo.Group, h *handler.CategoryHandler, auth *middleware.AuthMiddleware) {
+	// Category operations
+	categories := r.Group("/categories")
+	categories.Use(auth.RequireAuth)
+
+	// Any organization member may read; changes are checked against the permission registry. API
+	// keys need the read scope to read.
+	read := auth.RequireAPIKeyScope(middleware.PermCategoriesRead)
+	write := auth.RequirePermission(middleware.PermCategoriesWrite)
+	remove := auth.RequirePermission(middleware.PermCategoriesDelete)
+
+	// Collection operations
+	categories.POST("", h.CreateCategory, write)
+	categories.GET("", h.GetCategories, read)
+
+	// Individual category operations
+	dynamicCategory := categories.Group("/:id")
+	dynamicCategory.GET("", h.GetCategoryByID, read)
+	dynamicCategory.PATCH("", h.UpdateCategory, write)
+	dynamicCategory.DELETE("", h.DeleteCategory, remove)
+	dynamicCategory.POST("/restore", h.RestoreCategory, remove)
+}
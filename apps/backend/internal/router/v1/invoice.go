@@ -0,0 +1,29 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerInvoiceRoutes(r *echo.Group, h *handler.InvoiceHandler, auth *middleware.AuthMiddleware) {
+	// Invoice operations
+	invoices := r.Group("/invoices")
+	invoices.Use(auth.RequireAuth)
+
+	write := auth.RequirePermission(middleware.PermInvoicesWrite)
+
+	// Collection operations
+	invoices.POST("", h.CreateInvoice, write)
+	invoices.GET("", h.GetInvoices)
+
+	// Individual invoice operations
+	dynamicInvoice := invoices.Group("/:id")
+	dynamicInvoice.GET("", h.GetInvoiceByID)
+	dynamicInvoice.GET("/pdf", h.GetInvoicePDF)
+
+	// State transitions
+	dynamicInvoice.POST("/send", h.SendInvoice, write)
+	dynamicInvoice.POST("/pay", h.MarkInvoicePaid, write)
+	dynamicInvoice.POST("/void", h.VoidInvoice, write)
+}
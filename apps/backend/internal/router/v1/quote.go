@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerQuoteRoutes(r *echo.Group, h *handler.QuoteHandler, auth *middleware.AuthMiddleware) {
+	// Quote operations
+	quotes := r.Group("/quotes")
+	quotes.Use(auth.RequireAuth)
+
+	write := auth.RequirePermission(middleware.PermQuotesWrite)
+
+	// Collection operations
+	quotes.POST("", h.CreateQuote, write)
+	quotes.GET("", h.GetQuotes)
+
+	// Individual quote operations
+	dynamicQuote := quotes.Group("/:id")
+	dynamicQuote.GET("", h.GetQuoteByID)
+	dynamicQuote.POST("/withdraw", h.WithdrawQuote, write)
+
+	// Customers answer through the share token, which is the only credential they need
+	shared := r.Group("/shared-quotes/:token")
+	shared.GET("", h.GetSharedQuote)
+	shared.POST("/accept", h.AcceptSharedQuote)
+	shared.POST("/reject", h.RejectSharedQuote)
+}
@@ -0,0 +1,24 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerReviewRoutes(r *echo.Group, h *handler.ReviewHandler, auth *middleware.AuthMiddleware) {
+	// Reviews are written against a completed booking
+	r.POST("/bookings/:id/review", h.CreateReview, auth.RequireAuth)
+
+	// Reviews listed per service
+	r.GET("/services/:id/reviews", h.GetServiceReviews, auth.RequireAuth)
+
+	// Individual review operations
+	reviews := r.Group("/reviews/:id")
+	reviews.Use(auth.RequireAuth)
+
+	reviews.PATCH("", h.UpdateReview)
+	reviews.DELETE("", h.DeleteReview)
+	// Replies are written on behalf of the provider
+	reviews.PUT("/reply", h.ReplyToReview, auth.RequirePermission(middleware.PermReviewsReply))
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func RegisterV1Routes(router *echo.Group, handlers *handler.Handlers, middleware *middleware.Middlewares) {
+	// Register service routes
+	registerServiceRoutes(router, handlers.Service, middleware.Auth, middleware.Global)
+
+	// Register category routes
+	registerCategoryRoutes(router, handlers.Category, middleware.Auth)
+
+	// Register attachment routes
+	registerAttachmentRoutes(router, handlers.Attachment, middleware.Auth, middleware.Global)
+
+	// Register booking routes
+	registerBookingRoutes(router, handlers.Booking, middleware.Auth)
+
+	// Register availability routes
+	registerAvailabilityRoutes(router, handlers.Availability, middleware.Auth)
+
+	// Register quote routes
+	registerQuoteRoutes(router, handlers.Quote, middleware.Auth)
+
+	// Register invoice routes
+	registerInvoiceRoutes(router, handlers.Invoice, middleware.Auth)
+
+	// Register review routes
+	registerReviewRoutes(router, handlers.Review, middleware.Auth)
+
+	// Register search routes
+	registerSearchRoutes(router, handlers.Search, middleware.Auth)
+
+	// Register service area routes
+	registerServiceAreaRoutes(router, handlers.ServiceArea, middleware.Auth)
+
+	// Register storefront routes
+	registerStorefrontRoutes(router, handlers.Storefront, middleware.Auth, middleware.RateLimit)
+
+	// Register trash routes
+	registerTrashRoutes(router, handlers.Trash, middleware.Auth)
+
+	// Register audit routes
+	registerAuditRoutes(router, handlers.Audit, middleware.Auth)
+
+	// Register API key routes
+	registerAPIKeyRoutes(router, handlers.APIKey, middleware.Auth)
+
+	// Register webhook routes
+	registerWebhookRoutes(router, handlers.Webhook, middleware.Auth)
+}
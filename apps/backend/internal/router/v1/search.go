@@ -0,0 +1,12 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerSearchRoutes(r *echo.Group, h *handler.SearchHandler, auth *middleware.AuthMiddleware) {
+	// Full-text search across services and categories
+	r.GET("/search", h.Search, auth.RequireAuth)
+}
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerServiceRoutes(r *echo.Group, h *handler.ServiceHandler, auth *middleware.AuthMiddleware, global *middleware.GlobalMiddlewares) {
+	// Service operations
+	services := r.Group("/services")
+	services.Use(auth.RequireAuth)
+
+	// Any organization member may read; changes are checked against the permission registry. API
+	// keys need the read scope to read.
+	read := auth.RequireAPIKeyScope(middleware.PermServicesRead)
+	write := auth.RequirePermission(middleware.PermServicesWrite)
+	remove := auth.RequirePermission(middleware.PermServicesDelete)
+
+	// Collection operations
+	services.POST("", h.CreateService, write)
+	services.GET("", h.GetServices, read)
+	services.PUT("/order", h.ReorderServices, write)
+	services.POST("/import", h.ImportServices, global.UploadBodyLimit(), auth.RequirePermission(middleware.PermServicesImport))
+	services.GET("/export", h.ExportServices, read)
+
+	// Individual service operations
+	dynamicService := services.Group("/:id")
+	dynamicService.GET("", h.GetServiceByID, read)
+	dynamicService.PATCH("", h.UpdateService, write)
+	dynamicService.DELETE("", h.DeleteService, remove)
+	dynamicService.POST("/restore", h.RestoreService, remove)
+
+	// Hierarchy
+	dynamicService.POST("/move", h.MoveService, write)
+	dynamicService.GET("/tree", h.GetServiceTree, read)
+
+	// Pricing
+	dynamicService.GET("/price", h.GetServicePrice, read)
+
+	// Version history
+	dynamicService.GET("/versions", h.GetServiceVersions, read)
+	dynamicService.GET("/versions/diff", h.DiffServiceVersions, read)
+	dynamicService.POST("/versions/:version/restore", h.RestoreServiceVersion, write)
+}
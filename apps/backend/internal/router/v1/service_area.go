@@ -0,0 +1,18 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerServiceAreaRoutes(r *echo.Group, h *handler.ServiceAreaHandler, auth *middleware.AuthMiddleware) {
+	areas := r.Group("/service-areas")
+	areas.Use(auth.RequireAuth)
+
+	write := auth.RequirePermission(middleware.PermServiceAreasWrite)
+
+	areas.POST("", h.CreateServiceArea, write)
+	areas.GET("", h.GetServiceAreas)
+	areas.DELETE("/:id", h.DeleteServiceArea, write)
+}
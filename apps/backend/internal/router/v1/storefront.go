@@ -0,0 +1,36 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"golang.org/x/time/rate"
+)
+
+// Public storefront traffic is anonymous and scrapeable, so it gets a tighter per-IP budget than the global limiter
+const (
+	publicRateLimit = rate.Limit(5)
+	publicRateBurst = 10
+)
+
+func registerStorefrontRoutes(r *echo.Group, h *handler.StorefrontHandler, auth *middleware.AuthMiddleware, rateLimit *middleware.RateLimitMiddleware) {
+	// Providers manage their own storefront
+	storefront := r.Group("/storefront")
+	storefront.Use(auth.RequireAuth)
+
+	storefront.GET("", h.GetStorefront)
+	storefront.PUT("", h.UpsertStorefront, auth.RequirePermission(middleware.PermStorefrontWrite))
+
+	// Read-only public surface, no authentication
+	limit := rateLimit.Limit(publicRateLimit, publicRateBurst)
+
+	// Providers whose service areas cover ?lat=&lng=&withinKm= and/or ?postalCode=
+	r.GET("/public/providers", h.GetPublicProviders, limit)
+
+	provider := r.Group("/public/providers/:handle")
+	provider.Use(limit)
+
+	provider.GET("", h.GetPublicProvider)
+	provider.GET("/services", h.GetPublicServices)
+	provider.GET("/services/:id", h.GetPublicService)
+}
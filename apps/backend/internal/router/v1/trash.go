@@ -0,0 +1,12 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerTrashRoutes(r *echo.Group, h *handler.TrashHandler, auth *middleware.AuthMiddleware) {
+	// Items are restored through their own resource, e.g. POST /services/:id/restore
+	r.GET("/trash", h.GetTrash, auth.RequireAuth, auth.RequirePermission(middleware.PermTrashRead))
+}
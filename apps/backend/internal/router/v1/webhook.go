@@ -0,0 +1,23 @@
+package v1
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/handler"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+)
+
+func registerWebhookRoutes(r *echo.Group, h *handler.WebhookHandler, auth *middleware.AuthMiddleware) {
+	// outbound subscriptions; /webhooks is left for webhooks fixr receives from other services
+	endpoints := r.Group("/webhook-endpoints")
+	endpoints.Use(auth.RequireAuth, auth.RequirePermission(middleware.PermWebhooksManage))
+
+	endpoints.POST("", h.CreateEndpoint)
+	endpoints.GET("", h.GetEndpoints)
+
+	endpoint := endpoints.Group("/:id")
+	endpoint.GET("", h.GetEndpointByID)
+	endpoint.PATCH("", h.UpdateEndpoint)
+	endpoint.DELETE("", h.DeleteEndpoint)
+	endpoint.GET("/deliveries", h.GetDeliveries)
+	endpoint.POST("/deliveries/:deliveryId/redeliver", h.Redeliver)
+}
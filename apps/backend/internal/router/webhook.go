@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/mukundaparajuli/fixr/internal/handler"
+
+	"github.com/labstack/echo/v4"
+)
+
+// registerWebhookRoutes mounts the endpoints third parties call. They sit outside /api/v1 because
+// their paths are configured in the sender's dashboard, not versioned with our API.
+func registerWebhookRoutes(r *echo.Echo, h *handler.Handlers) {
+	// Clerk authenticates with a Svix signature instead of a session, so this route is public
+	r.POST("/webhooks/clerk", h.User.HandleClerkWebhook)
+}
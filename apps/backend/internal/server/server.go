@@ -10,6 +10,7 @@ import (
 	"github.com/mukundaparajuli/fixr/internal/config"
 	"github.com/mukundaparajuli/fixr/internal/database"
 	"github.com/mukundaparajuli/fixr/internal/lib/job"
+	"github.com/mukundaparajuli/fixr/internal/lib/storage"
 	loggerPkg "github.com/mukundaparajuli/fixr/internal/logger"
 	"github.com/newrelic/go-agent/v3/integrations/nrredis-v9"
 	"github.com/redis/go-redis/v9"
@@ -24,6 +25,7 @@ type Server struct {
 	Redis         *redis.Client
 	httpServer    *http.Server
 	Job           *job.JobService
+	Storage       storage.Storage
 }
 
 func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.LoggerService) (*Server, error) {
@@ -51,15 +53,16 @@ func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.Lo
 		// Don't fail startup if Redis is unavailable
 	}
 
-	// job service
+	// Blob storage for service attachments
+	store, err := storage.New(ctx, cfg.Storage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	// job service; it is started once the services have registered their task handlers
 	jobService := job.NewJobService(logger, cfg)
 	jobService.InitHandlers(cfg, logger)
 
-	// Start job server
-	if err := jobService.Start(); err != nil {
-		return nil, err
-	}
-
 	server := &Server{
 		Config:        cfg,
 		Logger:        logger,
@@ -67,6 +70,7 @@ func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.Lo
 		DB:            db,
 		Redis:         redisClient,
 		Job:           jobService,
+		Storage:       store,
 	}
 
 	// Start metrics collection
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/apikey"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type APIKeyService struct {
+	server     *server.Server
+	apiKeyRepo *repository.APIKeyRepository
+}
+
+func NewAPIKeyService(s *server.Server, apiKeyRepo *repository.APIKeyRepository) *APIKeyService {
+	return &APIKeyService{
+		server:     s,
+		apiKeyRepo: apiKeyRepo,
+	}
+}
+
+// CreateAPIKey issues a key for the owner's account. The plain key is only part of this response.
+func (s *APIKeyService) CreateAPIKey(ctx echo.Context, userID string, payload *apikey.CreateAPIKeyPayload) (*apikey.CreatedAPIKey, error) {
+	logger := middleware.GetLogger(ctx)
+
+	// scopes are permissions from the registry; managing keys is kept to interactive sessions
+	var fieldErrors []errs.FieldError
+	scopes := []string{}
+	for i, scope := range payload.Scopes {
+		permission := middleware.Permission(scope)
+		if !middleware.IsRegistered(permission) || permission == middleware.PermAPIKeysManage {
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: fmt.Sprintf("scopes[%d]", i),
+				Error: "is not a valid scope",
+			})
+			continue
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(fieldErrors) > 0 {
+		code := "API_KEY_SCOPE_INVALID"
+		return nil, errs.NewBadRequestError("Invalid API key scopes", true, &code, fieldErrors, nil)
+	}
+
+	// a key may not do more than the member creating it, e.g. a member cannot mint a services:delete key
+	var notHeld []string
+	for _, scope := range scopes {
+		if !middleware.HasPermission(ctx, middleware.Permission(scope)) {
+			notHeld = append(notHeld, scope)
+		}
+	}
+	if len(notHeld) > 0 {
+		logger.Warn().Strs("scopes", notHeld).Msg("api key scopes exceed the caller's permissions")
+		return nil, errs.NewForbiddenError(
+			fmt.Sprintf("You cannot grant scopes you do not hold: %s", strings.Join(notHeld, ", ")), true,
+		)
+	}
+	payload.Scopes = scopes
+
+	key, prefix, err := apikey.Generate()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to generate api key")
+		return nil, err
+	}
+
+	apiKey, err := s.apiKeyRepo.CreateAPIKey(ctx.Request().Context(), userID, middleware.GetUserID(ctx), prefix, apikey.Hash(key), payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create api key")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "api_key_created").
+		Str("api_key_id", apiKey.ID.String()).
+		Strs("scopes", apiKey.Scopes).
+		Msg("api key created successfully")
+
+	return &apikey.CreatedAPIKey{APIKey: *apiKey, Key: key}, nil
+}
+
+func (s *APIKeyService) GetAPIKeys(ctx echo.Context, userID string) ([]apikey.APIKey, error) {
+	logger := middleware.GetLogger(ctx)
+
+	keys, err := s.apiKeyRepo.GetAPIKeys(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch api keys")
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx echo.Context, userID string, keyID uuid.UUID) (*apikey.APIKey, error) {
+	logger := middleware.GetLogger(ctx)
+
+	apiKey, err := s.apiKeyRepo.RevokeAPIKey(ctx.Request().Context(), userID, keyID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to revoke api key")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "api_key_revoked").
+		Str("api_key_id", keyID.String()).
+		Msg("api key revoked successfully")
+
+	return apiKey, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/lib/storage"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type AttachmentService struct {
+	server         *server.Server
+	serviceRepo    *repository.ServiceRepository
+	attachmentRepo *repository.AttachmentRepository
+}
+
+func NewAttachmentService(s *server.Server, serviceRepo *repository.ServiceRepository, attachmentRepo *repository.AttachmentRepository) *AttachmentService {
+	return &AttachmentService{
+		server:         s,
+		serviceRepo:    serviceRepo,
+		attachmentRepo: attachmentRepo,
+	}
+}
+
+// UploadAttachment stores a file under the owner's service; uploadedBy is the signed-in user, which
+// differs from userID inside an organization
+func (s *AttachmentService) UploadAttachment(ctx echo.Context, userID string, uploadedBy string, payload *service.UploadServiceAttachmentPayload) (*service.ServiceAttachment, error) {
+	logger := middleware.GetLogger(ctx)
+
+	// Validate service exists and belongs to user
+	if _, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, payload.ServiceID); err != nil {
+		logger.Error().Err(err).Msg("service validation failed")
+		return nil, err
+	}
+
+	if payload.File.Size > s.server.Config.Storage.MaxUploadSize {
+		logger.Warn().Int64("file_size", payload.File.Size).Msg("attachment exceeds max upload size")
+		return nil, errs.NewBadRequestError(
+			fmt.Sprintf("File exceeds the maximum upload size of %d bytes", s.server.Config.Storage.MaxUploadSize),
+			true, nil, []errs.FieldError{{Field: "file", Error: "is too large"}}, nil,
+		)
+	}
+
+	src, err := payload.File.Open()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to open uploaded file")
+		return nil, errs.NewBadRequestError("Could not read uploaded file", false, nil, nil, nil)
+	}
+	defer src.Close()
+
+	mimeType, err := detectMimeType(src)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to detect attachment mime type")
+		return nil, err
+	}
+	if declared := payload.File.Header.Get(echo.HeaderContentType); declared != "" && declared != mimeType {
+		logger.Debug().Str("declared", declared).Str("detected", mimeType).Msg("overriding declared attachment mime type")
+	}
+
+	name := filepath.Base(payload.File.Filename)
+	downloadKey := fmt.Sprintf("services/%s/%s%s", payload.ServiceID, uuid.New(), strings.ToLower(filepath.Ext(name)))
+
+	if err := s.server.Storage.Put(ctx.Request().Context(), downloadKey, src, payload.File.Size, mimeType); err != nil {
+		logger.Error().Err(err).Str("download_key", downloadKey).Msg("failed to store attachment")
+		return nil, err
+	}
+
+	attachment, err := s.attachmentRepo.CreateAttachment(
+		ctx.Request().Context(),
+		payload.ServiceID,
+		userID,
+		uploadedBy,
+		name,
+		downloadKey,
+		payload.File.Size,
+		mimeType,
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create attachment record")
+		if delErr := s.server.Storage.Delete(ctx.Request().Context(), downloadKey); delErr != nil {
+			logger.Error().Err(delErr).Str("download_key", downloadKey).Msg("failed to clean up orphaned attachment")
+		}
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "attachment_uploaded").
+		Str("attachment_id", attachment.ID.String()).
+		Str("service_id", payload.ServiceID.String()).
+		Int64("file_size", payload.File.Size).
+		Msg("attachment uploaded successfully")
+
+	return attachment, nil
+}
+
+func (s *AttachmentService) GetServiceAttachments(ctx echo.Context, userID string, serviceID uuid.UUID) ([]service.ServiceAttachment, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if _, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, serviceID); err != nil {
+		logger.Error().Err(err).Msg("service validation failed")
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetServiceAttachments(ctx.Request().Context(), userID, serviceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service attachments")
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (s *AttachmentService) GetAttachmentDownloadURL(ctx echo.Context, userID string, serviceID uuid.UUID, attachmentID uuid.UUID) (*service.AttachmentDownloadURL, error) {
+	logger := middleware.GetLogger(ctx)
+
+	attachment, err := s.attachmentRepo.GetAttachmentByID(ctx.Request().Context(), userID, serviceID, attachmentID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch attachment")
+		return nil, err
+	}
+
+	expiry := s.server.Config.Storage.URLExpiry
+	url, err := s.server.Storage.SignedURL(ctx.Request().Context(), attachment.DownloadKey, attachment.Name, expiry)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to sign attachment download url")
+		return nil, err
+	}
+
+	return &service.AttachmentDownloadURL{
+		URL:       url,
+		ExpiresAt: time.Now().Add(expiry).UTC(),
+	}, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx echo.Context, userID string, serviceID uuid.UUID, attachmentID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+
+	// The blob stays in storage so the attachment can be restored; the purge job removes it
+	if _, err := s.attachmentRepo.DeleteAttachment(ctx.Request().Context(), userID, serviceID, attachmentID); err != nil {
+		logger.Error().Err(err).Msg("failed to delete attachment")
+		return err
+	}
+
+	logger.Info().
+		Str("event", "attachment_deleted").
+		Str("attachment_id", attachmentID.String()).
+		Str("service_id", serviceID.String()).
+		Msg("attachment deleted successfully")
+
+	return nil
+}
+
+func (s *AttachmentService) RestoreAttachment(ctx echo.Context, userID string, serviceID uuid.UUID, attachmentID uuid.UUID) (*service.ServiceAttachment, error) {
+	logger := middleware.GetLogger(ctx)
+
+	attachment, err := s.attachmentRepo.RestoreAttachment(ctx.Request().Context(), userID, serviceID, attachmentID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to restore attachment")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "attachment_restored").
+		Str("attachment_id", attachmentID.String()).
+		Str("service_id", serviceID.String()).
+		Msg("attachment restored successfully")
+
+	return attachment, nil
+}
+
+// OpenSignedDownload resolves a signed local download URL back to the stored object.
+// Only the local driver issues URLs pointing at this API; other drivers sign their own URLs.
+func (s *AttachmentService) OpenSignedDownload(ctx echo.Context, query *service.DownloadAttachmentQuery) (*storage.Object, error) {
+	logger := middleware.GetLogger(ctx)
+
+	localStore, ok := s.server.Storage.(*storage.LocalStorage)
+	if !ok {
+		return nil, errs.NewNotFoundError("Route not found", false, nil)
+	}
+
+	if err := localStore.Verify(query.Key, query.Filename, query.Expires, query.Signature); err != nil {
+		logger.Warn().Err(err).Msg("rejected attachment download")
+		return nil, errs.NewForbiddenError("Download link is invalid or has expired", false)
+	}
+
+	obj, err := localStore.Get(ctx.Request().Context(), query.Key)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, errs.NewNotFoundError("Attachment not found", false, nil)
+		}
+		logger.Error().Err(err).Msg("failed to open attachment")
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// detectMimeType sniffs the file content. The client-declared type is never trusted, since the
+// stored type is served back with every download.
+func detectMimeType(src io.ReadSeeker) (string, error) {
+	buf := make([]byte, 512)
+	n, err := src.Read(buf)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	if _, err := src.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to rewind uploaded file: %w", err)
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/audit"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type AuditService struct {
+	server    *server.Server
+	auditRepo *repository.AuditRepository
+}
+
+func NewAuditService(s *server.Server, auditRepo *repository.AuditRepository) *AuditService {
+	return &AuditService{
+		server:    s,
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *AuditService) GetAuditEvents(ctx echo.Context, userID string, query *audit.GetAuditEventsQuery) (*model.PaginatedResponse[audit.Event], error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.auditRepo.GetAuditEvents(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch audit events")
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -2,16 +2,15 @@ package service
 
 import (
 	"github.com/mukundaparajuli/fixr/internal/server"
-
-	"github.com/clerk/clerk-sdk-go/v2"
 )
 
+// AuthService holds authentication concerns of the service layer. Tokens are verified by the
+// Authenticator behind middleware.RequireAuth, which owns its provider client and key.
 type AuthService struct {
 	server *server.Server
 }
 
 func NewAuthService(s *server.Server) *AuthService {
-	clerk.SetKey(s.Config.Auth.SecretKey)
 	return &AuthService{
 		server: s,
 	}
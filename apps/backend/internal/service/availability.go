@@ -0,0 +1,219 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/availability"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+// overrideHorizon limits how far ahead the schedule endpoint lists date overrides
+const overrideHorizon = 365 * 24 * time.Hour
+
+type AvailabilityService struct {
+	server           *server.Server
+	availabilityRepo *repository.AvailabilityRepository
+	serviceRepo      *repository.ServiceRepository
+	bookingRepo      *repository.BookingRepository
+}
+
+func NewAvailabilityService(
+	s *server.Server,
+	availabilityRepo *repository.AvailabilityRepository,
+	serviceRepo *repository.ServiceRepository,
+	bookingRepo *repository.BookingRepository,
+) *AvailabilityService {
+	return &AvailabilityService{
+		server:           s,
+		availabilityRepo: availabilityRepo,
+		serviceRepo:      serviceRepo,
+		bookingRepo:      bookingRepo,
+	}
+}
+
+func (s *AvailabilityService) GetSchedule(ctx echo.Context, userID string) (*availability.Schedule, error) {
+	logger := middleware.GetLogger(ctx)
+
+	timezone, err := s.availabilityRepo.GetTimezone(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch availability timezone")
+		return nil, err
+	}
+
+	rules, err := s.availabilityRepo.GetRules(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch availability rules")
+		return nil, err
+	}
+
+	now := time.Now()
+	overrides, err := s.availabilityRepo.GetOverrides(ctx.Request().Context(), userID, now, now.Add(overrideHorizon))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch availability overrides")
+		return nil, err
+	}
+
+	return &availability.Schedule{
+		Timezone:  timezone,
+		Rules:     rules,
+		Overrides: overrides,
+	}, nil
+}
+
+func (s *AvailabilityService) UpdateScheduleRules(ctx echo.Context, userID string, payload *availability.UpdateScheduleRulesPayload) (*availability.Schedule, error) {
+	logger := middleware.GetLogger(ctx)
+
+	byWeekday := make(map[time.Weekday][]availability.WindowPayload)
+	for _, rule := range payload.Rules {
+		byWeekday[rule.Weekday] = append(byWeekday[rule.Weekday], availability.WindowPayload{
+			StartTime: rule.StartTime,
+			EndTime:   rule.EndTime,
+		})
+	}
+	for weekday, windows := range byWeekday {
+		if windowsOverlap(windows) {
+			return nil, errs.NewBadRequestError("Availability windows must not overlap", true, nil,
+				[]errs.FieldError{{Field: "rules", Error: "overlapping windows on " + weekday.String()}}, nil)
+		}
+	}
+
+	if err := s.availabilityRepo.ReplaceWeeklySchedule(ctx.Request().Context(), userID, payload.Timezone, payload.Rules); err != nil {
+		logger.Error().Err(err).Msg("failed to replace weekly availability")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "availability_updated").
+		Str("timezone", payload.Timezone).
+		Int("rules", len(payload.Rules)).
+		Msg("weekly availability updated successfully")
+
+	return s.GetSchedule(ctx, userID)
+}
+
+func (s *AvailabilityService) SetOverride(ctx echo.Context, userID string, payload *availability.SetOverridePayload) ([]availability.Override, error) {
+	logger := middleware.GetLogger(ctx)
+
+	date, _ := time.Parse(time.DateOnly, payload.Date)
+
+	if windowsOverlap(payload.Windows) {
+		return nil, errs.NewBadRequestError("Availability windows must not overlap", true, nil,
+			[]errs.FieldError{{Field: "windows", Error: "must not overlap"}}, nil)
+	}
+
+	if err := s.availabilityRepo.ReplaceOverridesForDate(ctx.Request().Context(), userID, date, payload.Windows); err != nil {
+		logger.Error().Err(err).Msg("failed to replace availability overrides")
+		return nil, err
+	}
+
+	overrides, err := s.availabilityRepo.GetOverrides(ctx.Request().Context(), userID, date, date)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch availability overrides")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "availability_override_set").
+		Str("date", payload.Date).
+		Bool("blackout", len(payload.Windows) == 0).
+		Msg("availability override saved successfully")
+
+	return overrides, nil
+}
+
+func (s *AvailabilityService) DeleteOverride(ctx echo.Context, userID string, payload *availability.DeleteOverridePayload) error {
+	logger := middleware.GetLogger(ctx)
+
+	date, _ := time.Parse(time.DateOnly, payload.Date)
+
+	if err := s.availabilityRepo.DeleteOverridesForDate(ctx.Request().Context(), userID, date); err != nil {
+		logger.Error().Err(err).Msg("failed to delete availability overrides")
+		return err
+	}
+
+	logger.Info().
+		Str("event", "availability_override_deleted").
+		Str("date", payload.Date).
+		Msg("availability override deleted successfully")
+
+	return nil
+}
+
+// GetServiceSlots lists the open slots of an active service between two calendar dates of the provider's timezone
+func (s *AvailabilityService) GetServiceSlots(ctx echo.Context, query *availability.GetServiceSlotsQuery) (*availability.ServiceSlots, error) {
+	logger := middleware.GetLogger(ctx)
+
+	serviceItem, err := s.serviceRepo.GetActiveServiceByID(ctx.Request().Context(), query.ServiceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("bookable service lookup failed")
+		return nil, err
+	}
+
+	timezone, err := s.availabilityRepo.GetTimezone(ctx.Request().Context(), serviceItem.UserID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch availability timezone")
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		logger.Error().Err(err).Str("timezone", timezone).Msg("failed to load provider timezone")
+		return nil, errs.NewInternalServerError()
+	}
+
+	from, _ := time.ParseInLocation(time.DateOnly, query.From, loc)
+	to, _ := time.ParseInLocation(time.DateOnly, query.To, loc)
+
+	rules, err := s.availabilityRepo.GetRules(ctx.Request().Context(), serviceItem.UserID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch availability rules")
+		return nil, err
+	}
+
+	overrides, err := s.availabilityRepo.GetOverrides(ctx.Request().Context(), serviceItem.UserID, from, to)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch availability overrides")
+		return nil, err
+	}
+
+	bookings, err := s.bookingRepo.GetProviderBookingsInRange(ctx.Request().Context(), serviceItem.UserID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch provider bookings")
+		return nil, err
+	}
+
+	busy := make([]availability.Slot, 0, len(bookings))
+	for _, b := range bookings {
+		busy = append(busy, availability.Slot{StartsAt: b.StartsAt, EndsAt: b.EndsAt})
+	}
+
+	duration := time.Duration(serviceItem.DurationMinutes) * time.Minute
+	slots := availability.ComputeSlots(loc, rules, overrides, busy, duration, from, to, time.Now())
+
+	return &availability.ServiceSlots{
+		ServiceID:       serviceItem.ID.String(),
+		Timezone:        timezone,
+		DurationMinutes: serviceItem.DurationMinutes,
+		Slots:           slots,
+	}, nil
+}
+
+func windowsOverlap(windows []availability.WindowPayload) bool {
+	sorted := make([]availability.WindowPayload, len(windows))
+	copy(sorted, windows)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].StartTime < sorted[j].StartTime
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].StartTime < sorted[i-1].EndTime {
+			return true
+		}
+	}
+	return false
+}
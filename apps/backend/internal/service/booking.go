@@ -0,0 +1,247 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/booking"
+	"github.com/mukundaparajuli/fixr/internal/model/webhook"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type BookingService struct {
+	server         *server.Server
+	bookingRepo    *repository.BookingRepository
+	serviceRepo    *repository.ServiceRepository
+	webhookService *WebhookService
+}
+
+func NewBookingService(
+	s *server.Server,
+	bookingRepo *repository.BookingRepository,
+	serviceRepo *repository.ServiceRepository,
+	webhookService *WebhookService,
+) *BookingService {
+	return &BookingService{
+		server:         s,
+		bookingRepo:    bookingRepo,
+		serviceRepo:    serviceRepo,
+		webhookService: webhookService,
+	}
+}
+
+// bookingEvents maps the status a booking moves to onto the webhook event sent to its provider
+var bookingEvents = map[booking.Status]webhook.EventType{
+	booking.StatusConfirmed: webhook.EventBookingConfirmed,
+	booking.StatusDeclined:  webhook.EventBookingDeclined,
+	booking.StatusCancelled: webhook.EventBookingCancelled,
+	booking.StatusCompleted: webhook.EventBookingCompleted,
+}
+
+// bookingActor describes which party of a booking may perform a transition
+type bookingActor int
+
+const (
+	actorProvider bookingActor = iota
+	actorEither
+)
+
+func (s *BookingService) CreateBooking(ctx echo.Context, customerID string, payload *booking.CreateBookingPayload) (*booking.Booking, error) {
+	logger := middleware.GetLogger(ctx)
+
+	serviceItem, err := s.serviceRepo.GetActiveServiceByID(ctx.Request().Context(), payload.ServiceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("bookable service lookup failed")
+		return nil, err
+	}
+
+	// only the provider booking their personal service is refused; members of an organization may
+	// book its services as customers
+	if serviceItem.UserID == customerID {
+		logger.Warn().Msg("provider attempted to book own service")
+		return nil, errs.NewBadRequestError("You cannot book your own service", false, nil, nil, nil)
+	}
+
+	if !payload.StartsAt.After(time.Now()) {
+		return nil, errs.NewBadRequestError("Booking must start in the future", true, nil,
+			[]errs.FieldError{{Field: "startsat", Error: "must be in the future"}}, nil)
+	}
+
+	bookingItem, err := s.bookingRepo.CreateBooking(ctx.Request().Context(), customerID, serviceItem.UserID, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create booking")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "booking_created").
+		Str("booking_id", bookingItem.ID.String()).
+		Str("service_id", bookingItem.ServiceID.String()).
+		Str("provider_id", bookingItem.ProviderID).
+		Msg("booking requested successfully")
+
+	s.webhookService.Dispatch(ctx, bookingItem.ProviderID, webhook.EventBookingCreated, bookingItem)
+
+	return bookingItem, nil
+}
+
+func (s *BookingService) GetBookingByID(ctx echo.Context, userID string, ownerID string, bookingID uuid.UUID) (*booking.Booking, error) {
+	logger := middleware.GetLogger(ctx)
+
+	bookingItem, err := s.bookingRepo.GetBookingByID(ctx.Request().Context(), userID, ownerID, bookingID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch booking by ID")
+		return nil, err
+	}
+
+	return bookingItem, nil
+}
+
+func (s *BookingService) GetBookings(ctx echo.Context, userID string, ownerID string, query *booking.GetBookingsQuery) (*model.PaginatedResponse[booking.Booking], error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.bookingRepo.GetBookings(ctx.Request().Context(), userID, ownerID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch bookings")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *BookingService) ConfirmBooking(ctx echo.Context, userID string, ownerID string, payload *booking.ConfirmBookingPayload) (*booking.Booking, error) {
+	return s.transition(ctx, userID, ownerID, payload.ID, booking.StatusConfirmed, nil, actorProvider)
+}
+
+func (s *BookingService) DeclineBooking(ctx echo.Context, userID string, ownerID string, payload *booking.DeclineBookingPayload) (*booking.Booking, error) {
+	return s.transition(ctx, userID, ownerID, payload.ID, booking.StatusDeclined, payload.Reason, actorProvider)
+}
+
+func (s *BookingService) CancelBooking(ctx echo.Context, userID string, ownerID string, payload *booking.CancelBookingPayload) (*booking.Booking, error) {
+	return s.transition(ctx, userID, ownerID, payload.ID, booking.StatusCancelled, payload.Reason, actorEither)
+}
+
+func (s *BookingService) CompleteBooking(ctx echo.Context, userID string, ownerID string, payload *booking.CompleteBookingPayload) (*booking.Booking, error) {
+	logger := middleware.GetLogger(ctx)
+
+	current, err := s.bookingRepo.GetBookingByID(ctx.Request().Context(), userID, ownerID, payload.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch booking by ID")
+		return nil, err
+	}
+
+	if time.Now().Before(current.StartsAt) {
+		return nil, errs.NewBadRequestError("A booking cannot be completed before it starts", false, nil, nil, nil)
+	}
+
+	return s.transition(ctx, userID, ownerID, payload.ID, booking.StatusCompleted, nil, actorProvider)
+}
+
+func (s *BookingService) RescheduleBooking(ctx echo.Context, userID string, ownerID string, payload *booking.RescheduleBookingPayload) (*booking.Booking, error) {
+	logger := middleware.GetLogger(ctx)
+
+	current, err := s.bookingRepo.GetBookingByID(ctx.Request().Context(), userID, ownerID, payload.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch booking by ID")
+		return nil, err
+	}
+
+	if err := checkBookingTransition(current, userID, ownerID, booking.StatusPending, actorEither); err != nil {
+		logger.Warn().Err(err).Str("status", string(current.Status)).Msg("booking reschedule rejected")
+		return nil, err
+	}
+
+	if !payload.StartsAt.After(time.Now()) {
+		return nil, errs.NewBadRequestError("Booking must start in the future", true, nil,
+			[]errs.FieldError{{Field: "startsat", Error: "must be in the future"}}, nil)
+	}
+
+	bookingItem, err := s.bookingRepo.RescheduleBooking(
+		ctx.Request().Context(),
+		current.ID,
+		current.Status,
+		payload.StartsAt,
+		payload.EndsAt,
+		payload.Reason,
+	)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to reschedule booking")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "booking_rescheduled").
+		Str("booking_id", bookingItem.ID.String()).
+		Time("starts_at", bookingItem.StartsAt).
+		Msg("booking rescheduled successfully")
+
+	s.webhookService.Dispatch(ctx, bookingItem.ProviderID, webhook.EventBookingRescheduled, bookingItem)
+
+	return bookingItem, nil
+}
+
+func (s *BookingService) transition(
+	ctx echo.Context,
+	userID string,
+	ownerID string,
+	bookingID uuid.UUID,
+	to booking.Status,
+	reason *string,
+	actor bookingActor,
+) (*booking.Booking, error) {
+	logger := middleware.GetLogger(ctx)
+
+	current, err := s.bookingRepo.GetBookingByID(ctx.Request().Context(), userID, ownerID, bookingID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch booking by ID")
+		return nil, err
+	}
+
+	if err := checkBookingTransition(current, userID, ownerID, to, actor); err != nil {
+		logger.Warn().Err(err).Str("from", string(current.Status)).Str("to", string(to)).Msg("booking transition rejected")
+		return nil, err
+	}
+
+	bookingItem, err := s.bookingRepo.UpdateBookingStatus(ctx.Request().Context(), current.ID, current.Status, to, reason)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update booking status")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "booking_"+string(to)).
+		Str("booking_id", bookingItem.ID.String()).
+		Str("from", string(current.Status)).
+		Msg("booking status updated successfully")
+
+	if eventType, ok := bookingEvents[to]; ok {
+		s.webhookService.Dispatch(ctx, bookingItem.ProviderID, eventType, bookingItem)
+	}
+
+	return bookingItem, nil
+}
+
+// checkBookingTransition enforces both the state machine and who is allowed to drive it. The caller acts
+// as the customer with their own userID and as the provider with ownerID, the account owning the service.
+func checkBookingTransition(current *booking.Booking, userID string, ownerID string, to booking.Status, actor bookingActor) error {
+	if actor == actorProvider && !current.IsProvider(ownerID) {
+		return errs.NewForbiddenError("Only the provider can perform this action", false)
+	}
+
+	if !current.Status.CanTransitionTo(to) {
+		code := "BOOKING_INVALID_TRANSITION"
+		return errs.NewConflictError(
+			fmt.Sprintf("Booking cannot move from %s to %s", current.Status, to),
+			false,
+			&code,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/category"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type CategoryService struct {
+	server       *server.Server
+	categoryRepo *repository.CategoryRepository
+}
+
+func NewCategoryService(s *server.Server, categoryRepo *repository.CategoryRepository) *CategoryService {
+	return &CategoryService{
+		server:       s,
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (s *CategoryService) CreateCategory(ctx echo.Context, userID string, payload *category.CreateCategoryPayload) (*category.Category, error) {
+	logger := middleware.GetLogger(ctx)
+
+	categoryItem, err := s.categoryRepo.CreateCategory(ctx.Request().Context(), userID, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create category")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "category_created").
+		Str("category_id", categoryItem.ID.String()).
+		Str("name", categoryItem.Name).
+		Msg("category created successfully")
+
+	return categoryItem, nil
+}
+
+func (s *CategoryService) GetCategoryByID(ctx echo.Context, userID string, categoryID uuid.UUID) (*category.Category, error) {
+	logger := middleware.GetLogger(ctx)
+
+	categoryItem, err := s.categoryRepo.GetCategoryByID(ctx.Request().Context(), userID, categoryID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch category by ID")
+		return nil, err
+	}
+
+	return categoryItem, nil
+}
+
+func (s *CategoryService) GetCategories(ctx echo.Context, userID string, query *category.GetCategoriesQuery) (*model.PaginatedResponse[category.CategoryWithServiceCounts], error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.categoryRepo.GetCategories(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch categories")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *CategoryService) UpdateCategory(ctx echo.Context, userID string, payload *category.UpdateCategoryPayload) (*category.Category, error) {
+	logger := middleware.GetLogger(ctx)
+
+	categoryItem, err := s.categoryRepo.UpdateCategory(ctx.Request().Context(), userID, payload.ID, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update category")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "category_updated").
+		Str("category_id", categoryItem.ID.String()).
+		Msg("category updated successfully")
+
+	return categoryItem, nil
+}
+
+func (s *CategoryService) DeleteCategory(ctx echo.Context, userID string, categoryID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+
+	if err := s.categoryRepo.DeleteCategory(ctx.Request().Context(), userID, categoryID); err != nil {
+		logger.Error().Err(err).Msg("failed to delete category")
+		return err
+	}
+
+	logger.Info().
+		Str("event", "category_deleted").
+		Str("category_id", categoryID.String()).
+		Msg("category deleted successfully")
+
+	return nil
+}
+
+func (s *CategoryService) RestoreCategory(ctx echo.Context, userID string, categoryID uuid.UUID) (*category.Category, error) {
+	logger := middleware.GetLogger(ctx)
+
+	categoryItem, err := s.categoryRepo.RestoreCategory(ctx.Request().Context(), userID, categoryID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to restore category")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "category_restored").
+		Str("category_id", categoryItem.ID.String()).
+		Msg("category restored successfully")
+
+	return categoryItem, nil
+}
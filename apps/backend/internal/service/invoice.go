@@ -0,0 +1,286 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/lib/money"
+	"github.com/mukundaparajuli/fixr/internal/lib/pdf"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/booking"
+	"github.com/mukundaparajuli/fixr/internal/model/invoice"
+	"github.com/mukundaparajuli/fixr/internal/model/quote"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type InvoiceService struct {
+	server      *server.Server
+	invoiceRepo *repository.InvoiceRepository
+	bookingRepo *repository.BookingRepository
+	quoteRepo   *repository.QuoteRepository
+	serviceRepo *repository.ServiceRepository
+}
+
+func NewInvoiceService(
+	s *server.Server,
+	invoiceRepo *repository.InvoiceRepository,
+	bookingRepo *repository.BookingRepository,
+	quoteRepo *repository.QuoteRepository,
+	serviceRepo *repository.ServiceRepository,
+) *InvoiceService {
+	return &InvoiceService{
+		server:      s,
+		invoiceRepo: invoiceRepo,
+		bookingRepo: bookingRepo,
+		quoteRepo:   quoteRepo,
+		serviceRepo: serviceRepo,
+	}
+}
+
+func (s *InvoiceService) CreateInvoice(ctx echo.Context, userID string, payload *invoice.CreateInvoicePayload) (*invoice.PopulatedInvoice, error) {
+	logger := middleware.GetLogger(ctx)
+
+	var (
+		draft *invoice.Invoice
+		items []invoice.Item
+		err   error
+	)
+	if payload.BookingID != nil {
+		draft, items, err = s.draftFromBooking(ctx, userID, *payload.BookingID, payload)
+	} else {
+		draft, items, err = s.draftFromQuote(ctx, userID, *payload.QuoteID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	draft.UserID = userID
+	draft.Notes = payload.Notes
+	if payload.DueInDays != nil {
+		dueAt := time.Now().AddDate(0, 0, *payload.DueInDays)
+		draft.DueAt = &dueAt
+	}
+
+	invoiceItem, err := s.invoiceRepo.CreateInvoice(ctx.Request().Context(), draft, items)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create invoice")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "invoice_created").
+		Str("invoice_id", invoiceItem.ID.String()).
+		Int64("number", invoiceItem.Number).
+		Int64("total", invoiceItem.Total).
+		Str("currency", invoiceItem.Currency).
+		Msg("invoice created successfully")
+
+	return invoiceItem, nil
+}
+
+// draftFromBooking prices the booked service: hourly services bill the booked duration, everything else one unit
+func (s *InvoiceService) draftFromBooking(ctx echo.Context, userID string, bookingID uuid.UUID, payload *invoice.CreateInvoicePayload) (*invoice.Invoice, []invoice.Item, error) {
+	logger := middleware.GetLogger(ctx)
+
+	bookingItem, err := s.bookingRepo.GetBookingByID(ctx.Request().Context(), userID, userID, bookingID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch booking by ID")
+		return nil, nil, err
+	}
+
+	if !bookingItem.IsProvider(userID) {
+		return nil, nil, errs.NewForbiddenError("Only the provider can invoice a booking", false)
+	}
+
+	if bookingItem.Status != booking.StatusCompleted {
+		code := "INVOICE_BOOKING_NOT_COMPLETED"
+		return nil, nil, errs.NewConflictError("Only completed bookings can be invoiced", false, &code)
+	}
+
+	serviceItem, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, bookingItem.ServiceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch booked service")
+		return nil, nil, err
+	}
+
+	quantity := 1.0
+	if serviceItem.Method == service.Hourly {
+		quantity = bookingItem.EndsAt.Sub(bookingItem.StartsAt).Hours()
+	}
+
+	price, err := serviceItem.Pricing().Quote(quantity)
+	if err != nil {
+		logger.Error().Err(err).Str("service_id", serviceItem.ID.String()).Msg("failed to price service")
+		return nil, nil, err
+	}
+
+	adjustments := service.Adjustments{}
+	if payload.TaxRate != nil {
+		adjustments.TaxRate = *payload.TaxRate
+	}
+
+	totals, err := service.ComputeTotals(serviceItem.Currency, []money.Money{price.Total}, adjustments)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to compute invoice totals")
+		return nil, nil, err
+	}
+
+	serviceID := serviceItem.ID
+	customerID := bookingItem.CustomerID
+	draft := &invoice.Invoice{
+		CustomerID:     &customerID,
+		BookingID:      &bookingItem.ID,
+		Currency:       serviceItem.Currency,
+		Subtotal:       totals.Subtotal.Amount,
+		DiscountAmount: totals.Discount.Amount,
+		TaxRate:        adjustments.TaxRate,
+		TaxAmount:      totals.Tax.Amount,
+		Total:          totals.Total.Amount,
+	}
+	items := []invoice.Item{{
+		ServiceID:   &serviceID,
+		Description: serviceItem.Name,
+		Method:      price.Method,
+		Quantity:    price.Quantity,
+		UnitLabel:   price.UnitLabel,
+		Amount:      price.Total.Amount,
+		Lines:       price.Lines,
+	}}
+
+	return draft, items, nil
+}
+
+// draftFromQuote bills exactly what the customer accepted
+func (s *InvoiceService) draftFromQuote(ctx echo.Context, userID string, quoteID uuid.UUID) (*invoice.Invoice, []invoice.Item, error) {
+	logger := middleware.GetLogger(ctx)
+
+	quoteItem, err := s.quoteRepo.GetQuoteByID(ctx.Request().Context(), userID, quoteID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch quote by ID")
+		return nil, nil, err
+	}
+
+	if quoteItem.Status != quote.StatusAccepted {
+		code := "INVOICE_QUOTE_NOT_ACCEPTED"
+		return nil, nil, errs.NewConflictError("Only accepted quotes can be invoiced", false, &code)
+	}
+
+	draft := &invoice.Invoice{
+		QuoteID:        &quoteItem.ID,
+		Currency:       quoteItem.Currency,
+		Subtotal:       quoteItem.Subtotal,
+		DiscountAmount: quoteItem.DiscountAmount,
+		TaxRate:        quoteItem.TaxRate,
+		TaxAmount:      quoteItem.TaxAmount,
+		Total:          quoteItem.Total,
+	}
+
+	items := make([]invoice.Item, 0, len(quoteItem.Items))
+	for _, item := range quoteItem.Items {
+		items = append(items, invoice.Item{
+			ServiceID:   item.ServiceID,
+			Description: item.Description,
+			Method:      item.Method,
+			Quantity:    item.Quantity,
+			UnitLabel:   item.UnitLabel,
+			Amount:      item.Amount,
+			Lines:       item.Lines,
+		})
+	}
+
+	return draft, items, nil
+}
+
+func (s *InvoiceService) GetInvoiceByID(ctx echo.Context, userID string, invoiceID uuid.UUID) (*invoice.PopulatedInvoice, error) {
+	logger := middleware.GetLogger(ctx)
+
+	invoiceItem, err := s.invoiceRepo.GetInvoiceByID(ctx.Request().Context(), userID, invoiceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch invoice by ID")
+		return nil, err
+	}
+
+	return invoiceItem, nil
+}
+
+func (s *InvoiceService) GetInvoices(ctx echo.Context, userID string, query *invoice.GetInvoicesQuery) (*model.PaginatedResponse[invoice.Invoice], error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.invoiceRepo.GetInvoices(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch invoices")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *InvoiceService) GetInvoicePDF(ctx echo.Context, userID string, invoiceID uuid.UUID) ([]byte, error) {
+	logger := middleware.GetLogger(ctx)
+
+	invoiceItem, err := s.invoiceRepo.GetInvoiceByID(ctx.Request().Context(), userID, invoiceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch invoice by ID")
+		return nil, err
+	}
+
+	data, err := pdf.RenderInvoice(invoiceItem)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to render invoice pdf")
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func (s *InvoiceService) SendInvoice(ctx echo.Context, userID string, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	return s.transition(ctx, userID, invoiceID, invoice.StatusSent)
+}
+
+func (s *InvoiceService) MarkInvoicePaid(ctx echo.Context, userID string, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	return s.transition(ctx, userID, invoiceID, invoice.StatusPaid)
+}
+
+func (s *InvoiceService) VoidInvoice(ctx echo.Context, userID string, invoiceID uuid.UUID) (*invoice.Invoice, error) {
+	return s.transition(ctx, userID, invoiceID, invoice.StatusVoid)
+}
+
+func (s *InvoiceService) transition(ctx echo.Context, userID string, invoiceID uuid.UUID, to invoice.Status) (*invoice.Invoice, error) {
+	logger := middleware.GetLogger(ctx)
+
+	current, err := s.invoiceRepo.GetInvoiceByID(ctx.Request().Context(), userID, invoiceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch invoice by ID")
+		return nil, err
+	}
+
+	if current.UserID != userID {
+		return nil, errs.NewForbiddenError("Only the issuer can change an invoice", false)
+	}
+
+	if !current.Status.CanTransitionTo(to) {
+		code := "INVOICE_INVALID_TRANSITION"
+		logger.Warn().Str("from", string(current.Status)).Str("to", string(to)).Msg("invoice transition rejected")
+		return nil, errs.NewConflictError(fmt.Sprintf("Invoice cannot move from %s to %s", current.Status, to), false, &code)
+	}
+
+	invoiceItem, err := s.invoiceRepo.UpdateInvoiceStatus(ctx.Request().Context(), current.ID, current.Status, to)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update invoice status")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "invoice_"+string(to)).
+		Str("invoice_id", invoiceItem.ID.String()).
+		Str("from", string(current.Status)).
+		Msg("invoice status updated successfully")
+
+	return invoiceItem, nil
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/lib/money"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/quote"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type QuoteService struct {
+	server      *server.Server
+	quoteRepo   *repository.QuoteRepository
+	serviceRepo *repository.ServiceRepository
+}
+
+func NewQuoteService(s *server.Server, quoteRepo *repository.QuoteRepository, serviceRepo *repository.ServiceRepository) *QuoteService {
+	return &QuoteService{
+		server:      s,
+		quoteRepo:   quoteRepo,
+		serviceRepo: serviceRepo,
+	}
+}
+
+// CreateQuote prices the picked services of a service tree and stores the result as a shareable quote
+func (s *QuoteService) CreateQuote(ctx echo.Context, userID string, payload *quote.CreateQuotePayload) (*quote.PopulatedQuote, error) {
+	logger := middleware.GetLogger(ctx)
+
+	tree, err := s.serviceRepo.GetServiceTree(ctx.Request().Context(), userID, payload.RootServiceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service tree")
+		return nil, err
+	}
+
+	inTree := make(map[uuid.UUID]*service.Service, len(tree))
+	for i := range tree {
+		inTree[tree[i].ID] = &tree[i]
+	}
+
+	root := inTree[payload.RootServiceID]
+	currency := root.Currency
+
+	items := make([]quote.Item, 0, len(payload.Items))
+	amounts := make([]money.Money, 0, len(payload.Items))
+	for i, picked := range payload.Items {
+		field := fmt.Sprintf("items[%d].serviceId", i)
+
+		serviceItem, ok := inTree[picked.ServiceID]
+		if !ok {
+			return nil, errs.NewBadRequestError("Quoted services must belong to the root service", true, nil,
+				[]errs.FieldError{{Field: field, Error: "is not part of the root service"}}, nil)
+		}
+		if serviceItem.Currency != currency {
+			return nil, errs.NewBadRequestError("All quoted services must share one currency", true, nil,
+				[]errs.FieldError{{Field: field, Error: "is priced in " + serviceItem.Currency + ", expected " + currency}}, nil)
+		}
+
+		price, err := serviceItem.Pricing().Quote(picked.Quantity)
+		if err != nil {
+			logger.Error().Err(err).Str("service_id", serviceItem.ID.String()).Msg("failed to price service")
+			return nil, errs.NewBadRequestError("Service pricing is incomplete", true, nil,
+				[]errs.FieldError{{Field: field, Error: "has invalid pricing"}}, nil)
+		}
+
+		serviceID := serviceItem.ID
+		items = append(items, quote.Item{
+			ServiceID:   &serviceID,
+			Description: serviceItem.Name,
+			Method:      price.Method,
+			Quantity:    price.Quantity,
+			UnitLabel:   price.UnitLabel,
+			Amount:      price.Total.Amount,
+			Lines:       price.Lines,
+		})
+		amounts = append(amounts, price.Total)
+	}
+
+	adjustments := service.Adjustments{}
+	if payload.DiscountRate != nil {
+		adjustments.DiscountRate = *payload.DiscountRate
+	}
+	if payload.DiscountAmount != nil {
+		adjustments.DiscountAmount = *payload.DiscountAmount
+	}
+	if payload.TaxRate != nil {
+		adjustments.TaxRate = *payload.TaxRate
+	}
+
+	totals, err := service.ComputeTotals(currency, amounts, adjustments)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to compute quote totals")
+		return nil, err
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to generate quote share token")
+		return nil, err
+	}
+
+	rootID := root.ID
+	draft := &quote.Quote{
+		UserID:         userID,
+		RootServiceID:  &rootID,
+		Title:          root.Name,
+		Currency:       currency,
+		Subtotal:       totals.Subtotal.Amount,
+		DiscountRate:   adjustments.DiscountRate,
+		DiscountAmount: totals.Discount.Amount,
+		TaxRate:        adjustments.TaxRate,
+		TaxAmount:      totals.Tax.Amount,
+		Total:          totals.Total.Amount,
+		Notes:          payload.Notes,
+		ShareToken:     token,
+		ExpiresAt:      time.Now().AddDate(0, 0, *payload.ValidDays),
+	}
+
+	quoteItem, err := s.quoteRepo.CreateQuote(ctx.Request().Context(), draft, items)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create quote")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "quote_created").
+		Str("quote_id", quoteItem.ID.String()).
+		Int64("total", quoteItem.Total).
+		Str("currency", quoteItem.Currency).
+		Msg("quote created successfully")
+
+	return quoteItem, nil
+}
+
+func (s *QuoteService) GetQuoteByID(ctx echo.Context, userID string, quoteID uuid.UUID) (*quote.PopulatedQuote, error) {
+	logger := middleware.GetLogger(ctx)
+
+	quoteItem, err := s.quoteRepo.GetQuoteByID(ctx.Request().Context(), userID, quoteID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch quote by ID")
+		return nil, err
+	}
+
+	return quoteItem, nil
+}
+
+func (s *QuoteService) GetQuotes(ctx echo.Context, userID string, query *quote.GetQuotesQuery) (*model.PaginatedResponse[quote.Quote], error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.quoteRepo.GetQuotes(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch quotes")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *QuoteService) WithdrawQuote(ctx echo.Context, userID string, quoteID uuid.UUID) (*quote.Quote, error) {
+	logger := middleware.GetLogger(ctx)
+
+	current, err := s.quoteRepo.GetQuoteByID(ctx.Request().Context(), userID, quoteID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch quote by ID")
+		return nil, err
+	}
+
+	return s.respond(ctx, &current.Quote, quote.StatusWithdrawn, nil)
+}
+
+func (s *QuoteService) GetSharedQuote(ctx echo.Context, token string) (*quote.PopulatedQuote, error) {
+	logger := middleware.GetLogger(ctx)
+
+	quoteItem, err := s.quoteRepo.GetQuoteByShareToken(ctx.Request().Context(), token)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch shared quote")
+		return nil, err
+	}
+
+	return quoteItem, nil
+}
+
+func (s *QuoteService) AcceptSharedQuote(ctx echo.Context, payload *quote.RespondToQuotePayload) (*quote.Quote, error) {
+	return s.respondShared(ctx, payload, quote.StatusAccepted)
+}
+
+func (s *QuoteService) RejectSharedQuote(ctx echo.Context, payload *quote.RespondToQuotePayload) (*quote.Quote, error) {
+	return s.respondShared(ctx, payload, quote.StatusRejected)
+}
+
+func (s *QuoteService) respondShared(ctx echo.Context, payload *quote.RespondToQuotePayload, to quote.Status) (*quote.Quote, error) {
+	logger := middleware.GetLogger(ctx)
+
+	current, err := s.quoteRepo.GetQuoteByShareToken(ctx.Request().Context(), payload.Token)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch shared quote")
+		return nil, err
+	}
+
+	if current.IsExpired(time.Now()) {
+		code := "QUOTE_EXPIRED"
+		return nil, errs.NewConflictError("This quote has expired", false, &code)
+	}
+
+	return s.respond(ctx, &current.Quote, to, payload.Note)
+}
+
+// respond moves a pending quote to its final status
+func (s *QuoteService) respond(ctx echo.Context, current *quote.Quote, to quote.Status, note *string) (*quote.Quote, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if current.Status != quote.StatusPending {
+		code := "QUOTE_NOT_PENDING"
+		logger.Warn().Str("status", string(current.Status)).Str("to", string(to)).Msg("quote response rejected")
+		return nil, errs.NewConflictError(fmt.Sprintf("Quote is already %s", current.Status), false, &code)
+	}
+
+	quoteItem, err := s.quoteRepo.UpdateQuoteStatus(ctx.Request().Context(), current.ID, current.Status, to, note)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update quote status")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "quote_"+string(to)).
+		Str("quote_id", quoteItem.ID.String()).
+		Msg("quote status updated successfully")
+
+	return quoteItem, nil
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to read random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
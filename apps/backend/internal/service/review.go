@@ -0,0 +1,135 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/booking"
+	"github.com/mukundaparajuli/fixr/internal/model/review"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type ReviewService struct {
+	server      *server.Server
+	reviewRepo  *repository.ReviewRepository
+	bookingRepo *repository.BookingRepository
+}
+
+func NewReviewService(s *server.Server, reviewRepo *repository.ReviewRepository, bookingRepo *repository.BookingRepository) *ReviewService {
+	return &ReviewService{
+		server:      s,
+		reviewRepo:  reviewRepo,
+		bookingRepo: bookingRepo,
+	}
+}
+
+// CreateReview lets the customer of a completed booking rate it once
+func (s *ReviewService) CreateReview(ctx echo.Context, userID string, payload *review.CreateReviewPayload) (*review.Review, error) {
+	logger := middleware.GetLogger(ctx)
+
+	bookingItem, err := s.bookingRepo.GetBookingByID(ctx.Request().Context(), userID, userID, payload.BookingID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch booking by ID")
+		return nil, err
+	}
+
+	if !bookingItem.IsCustomer(userID) {
+		return nil, errs.NewForbiddenError("Only the customer can review a booking", false)
+	}
+
+	if bookingItem.Status != booking.StatusCompleted {
+		code := "REVIEW_BOOKING_NOT_COMPLETED"
+		return nil, errs.NewConflictError("Only completed bookings can be reviewed", false, &code)
+	}
+
+	reviewItem, err := s.reviewRepo.CreateReview(ctx.Request().Context(), bookingItem, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create review")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "review_created").
+		Str("review_id", reviewItem.ID.String()).
+		Str("service_id", reviewItem.ServiceID.String()).
+		Int("rating", reviewItem.Rating).
+		Msg("review created successfully")
+
+	return reviewItem, nil
+}
+
+func (s *ReviewService) GetServiceReviews(ctx echo.Context, query *review.GetServiceReviewsQuery) (*model.PaginatedResponse[review.Review], error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.reviewRepo.GetServiceReviews(ctx.Request().Context(), query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service reviews")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *ReviewService) UpdateReview(ctx echo.Context, userID string, payload *review.UpdateReviewPayload) (*review.Review, error) {
+	logger := middleware.GetLogger(ctx)
+
+	reviewItem, err := s.reviewRepo.UpdateReview(ctx.Request().Context(), userID, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update review")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "review_updated").
+		Str("review_id", reviewItem.ID.String()).
+		Int("rating", reviewItem.Rating).
+		Msg("review updated successfully")
+
+	return reviewItem, nil
+}
+
+func (s *ReviewService) DeleteReview(ctx echo.Context, userID string, reviewID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+
+	if err := s.reviewRepo.DeleteReview(ctx.Request().Context(), userID, reviewID); err != nil {
+		logger.Error().Err(err).Msg("failed to delete review")
+		return err
+	}
+
+	logger.Info().
+		Str("event", "review_deleted").
+		Str("review_id", reviewID.String()).
+		Msg("review deleted successfully")
+
+	return nil
+}
+
+func (s *ReviewService) ReplyToReview(ctx echo.Context, userID string, payload *review.ReplyToReviewPayload) (*review.Review, error) {
+	logger := middleware.GetLogger(ctx)
+
+	current, err := s.reviewRepo.GetReviewByID(ctx.Request().Context(), payload.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch review by ID")
+		return nil, err
+	}
+
+	if current.ProviderID != userID {
+		return nil, errs.NewForbiddenError("Only the provider can reply to a review", false)
+	}
+
+	reviewItem, err := s.reviewRepo.ReplyToReview(ctx.Request().Context(), userID, payload.ID, payload.Reply)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to reply to review")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "review_replied").
+		Str("review_id", reviewItem.ID.String()).
+		Msg("review reply saved successfully")
+
+	return reviewItem, nil
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/search"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type SearchService struct {
+	server     *server.Server
+	searchRepo *repository.SearchRepository
+}
+
+func NewSearchService(s *server.Server, searchRepo *repository.SearchRepository) *SearchService {
+	return &SearchService{
+		server:     s,
+		searchRepo: searchRepo,
+	}
+}
+
+func (s *SearchService) Search(ctx echo.Context, userID string, query *search.SearchQuery) (*search.Results, error) {
+	logger := middleware.GetLogger(ctx)
+
+	services, err := s.searchRepo.SearchServices(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to search services")
+		return nil, err
+	}
+
+	categories, err := s.searchRepo.SearchCategories(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to search categories")
+		return nil, err
+	}
+
+	return &search.Results{
+		Query:      query.Q,
+		Services:   services,
+		Categories: categories,
+	}, nil
+}
@@ -0,0 +1,356 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/lib/metaschema"
+	"github.com/mukundaparajuli/fixr/internal/lib/money"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/model/webhook"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+)
+
+type ServiceService struct {
+	server         *server.Server
+	serviceRepo    *repository.ServiceRepository
+	categoryRepo   *repository.CategoryRepository
+	webhookService *WebhookService
+}
+
+func NewServiceService(
+	s *server.Server,
+	serviceRepo *repository.ServiceRepository,
+	categoryRepo *repository.CategoryRepository,
+	webhookService *WebhookService,
+) *ServiceService {
+	return &ServiceService{
+		server:         s,
+		serviceRepo:    serviceRepo,
+		categoryRepo:   categoryRepo,
+		webhookService: webhookService,
+	}
+}
+
+func (s *ServiceService) CreateService(ctx echo.Context, userID string, payload *service.CreateServicePayload) (*service.Service, error) {
+	logger := middleware.GetLogger(ctx)
+
+	// Validate category exists and belongs to user, and that the metadata fits its schema (if provided)
+	if payload.CategoryID != nil {
+		categoryItem, err := s.categoryRepo.GetCategoryByID(ctx.Request().Context(), userID, *payload.CategoryID)
+		if err != nil {
+			logger.Error().Err(err).Msg("category validation failed")
+			return nil, err
+		}
+
+		if err := checkMetadata(categoryItem.ID, categoryItem.MetadataSchema, *payload.Metadata); err != nil {
+			logger.Warn().Err(err).Msg("invalid service metadata")
+			return nil, err
+		}
+	}
+
+	// the parent and the depth limit are checked inside the create, under the hierarchy lock
+	serviceItem, err := s.serviceRepo.CreateService(ctx.Request().Context(), userID, payload, s.server.Config.Catalog.MaxServiceDepth)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create service")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "service_created").
+		Str("service_id", serviceItem.ID.String()).
+		Str("name", serviceItem.Name).
+		Msg("service created successfully")
+
+	s.webhookService.Dispatch(ctx, userID, webhook.EventServiceCreated, serviceItem)
+
+	return serviceItem, nil
+}
+
+func (s *ServiceService) GetServiceByID(ctx echo.Context, userID string, serviceID uuid.UUID) (*service.PopulatedService, error) {
+	logger := middleware.GetLogger(ctx)
+
+	serviceItem, err := s.serviceRepo.GetServiceByID(ctx.Request().Context(), userID, serviceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service by ID")
+		return nil, err
+	}
+
+	return serviceItem, nil
+}
+
+func (s *ServiceService) GetServices(ctx echo.Context, userID string, query *service.GetServicesQuery) (*model.PaginatedResponse[service.PopulatedService], error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.serviceRepo.GetServices(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch services")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *ServiceService) UpdateService(ctx echo.Context, userID string, payload *service.UpdateServicePayload) (*service.Service, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if payload.CategoryID != nil {
+		if _, err := s.categoryRepo.GetCategoryByID(ctx.Request().Context(), userID, *payload.CategoryID); err != nil {
+			logger.Error().Err(err).Msg("category validation failed")
+			return nil, err
+		}
+	}
+
+	pricingChanged := payload.Rate != nil || payload.Currency != nil || payload.Method != nil || payload.UnitLabel != nil || payload.PriceTiers != nil
+	metadataChanged := payload.Metadata != nil || payload.CategoryID != nil
+	if pricingChanged || metadataChanged {
+		current, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, payload.ID)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to fetch service for update validation")
+			return nil, err
+		}
+
+		if pricingChanged {
+			if err := mergePricing(current, payload).Validate(); err != nil {
+				logger.Warn().Err(err).Msg("invalid pricing update")
+				return nil, invalidPricingError(err)
+			}
+		}
+
+		// the resulting metadata must fit the schema of the resulting category
+		if metadataChanged {
+			categoryID, metadata := current.CategoryID, current.Metadata
+			if payload.CategoryID != nil {
+				categoryID = payload.CategoryID
+			}
+			if payload.Metadata != nil {
+				metadata = *payload.Metadata
+			}
+
+			if categoryID != nil {
+				schema, err := s.categoryRepo.GetMetadataSchema(ctx.Request().Context(), userID, *categoryID)
+				if err != nil {
+					logger.Error().Err(err).Msg("failed to fetch category metadata schema")
+					return nil, err
+				}
+
+				if err := checkMetadata(*categoryID, schema, metadata); err != nil {
+					logger.Warn().Err(err).Msg("invalid service metadata")
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if payload.ParentServiceID != nil && *payload.ParentServiceID == payload.ID {
+		logger.Warn().Msg("service cannot be its own parent")
+		return nil, errs.NewBadRequestError("Service cannot be its own parent", false, nil, nil, nil)
+	}
+
+	// parent changes go through the same cycle and depth checks as an explicit move
+	updatedService, err := s.serviceRepo.UpdateService(ctx.Request().Context(), userID, payload.ID, payload, s.server.Config.Catalog.MaxServiceDepth)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update service")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "service_updated").
+		Str("service_id", updatedService.ID.String()).
+		Msg("service updated successfully")
+
+	s.webhookService.Dispatch(ctx, userID, webhook.EventServiceUpdated, updatedService)
+
+	return updatedService, nil
+}
+
+// DeleteService moves a service and its subtree to the trash; attachment blobs are kept until the purge job runs
+func (s *ServiceService) DeleteService(ctx echo.Context, userID string, serviceID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+
+	if err := s.serviceRepo.DeleteService(ctx.Request().Context(), userID, serviceID); err != nil {
+		logger.Error().Err(err).Msg("failed to delete service")
+		return err
+	}
+
+	logger.Info().
+		Str("event", "service_deleted").
+		Str("service_id", serviceID.String()).
+		Msg("service deleted successfully")
+
+	s.webhookService.Dispatch(ctx, userID, webhook.EventServiceDeleted, map[string]uuid.UUID{"id": serviceID})
+
+	return nil
+}
+
+func (s *ServiceService) RestoreService(ctx echo.Context, userID string, serviceID uuid.UUID) (*service.Service, error) {
+	logger := middleware.GetLogger(ctx)
+
+	restoredServices, err := s.serviceRepo.RestoreService(ctx.Request().Context(), userID, serviceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to restore service")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "service_restored").
+		Str("service_id", serviceID.String()).
+		Int("restored", len(restoredServices)).
+		Msg("service restored successfully")
+
+	// subscribers were told about the deletion, so each restored service reappears as created
+	for i := range restoredServices {
+		s.webhookService.Dispatch(ctx, userID, webhook.EventServiceCreated, restoredServices[i])
+	}
+
+	return &restoredServices[0], nil
+}
+
+// ReorderServices sets the display order of the services under one parent, or of the root services
+func (s *ServiceService) ReorderServices(ctx echo.Context, userID string, payload *service.ReorderServicesPayload) ([]service.Service, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if payload.ParentServiceID != nil {
+		if _, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, *payload.ParentServiceID); err != nil {
+			logger.Error().Err(err).Msg("parent service validation failed")
+			return nil, err
+		}
+	}
+
+	services, err := s.serviceRepo.ReorderServices(ctx.Request().Context(), userID, payload.ParentServiceID, payload.ServiceIDs)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to reorder services")
+		return nil, err
+	}
+
+	event := logger.Info().
+		Str("event", "services_reordered").
+		Int("count", len(services))
+	if payload.ParentServiceID != nil {
+		event = event.Str("parent_service_id", payload.ParentServiceID.String())
+	}
+	event.Msg("services reordered successfully")
+
+	return services, nil
+}
+
+func (s *ServiceService) MoveService(ctx echo.Context, userID string, payload *service.MoveServicePayload) (*service.Service, error) {
+	return s.moveService(ctx, userID, payload.ID, payload.ParentServiceID)
+}
+
+func (s *ServiceService) moveService(ctx echo.Context, userID string, serviceID uuid.UUID, parentID *uuid.UUID) (*service.Service, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if parentID != nil && *parentID == serviceID {
+		logger.Warn().Msg("service cannot be its own parent")
+		return nil, errs.NewBadRequestError("Service cannot be its own parent", false, nil, nil, nil)
+	}
+
+	movedService, err := s.serviceRepo.MoveService(ctx.Request().Context(), userID, serviceID, parentID, s.server.Config.Catalog.MaxServiceDepth)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to move service")
+		return nil, err
+	}
+
+	event := logger.Info().
+		Str("event", "service_moved").
+		Str("service_id", serviceID.String())
+	if parentID != nil {
+		event = event.Str("parent_service_id", parentID.String())
+	}
+	event.Msg("service moved successfully")
+
+	s.webhookService.Dispatch(ctx, userID, webhook.EventServiceUpdated, movedService)
+
+	return movedService, nil
+}
+
+func (s *ServiceService) GetServiceTree(ctx echo.Context, userID string, serviceID uuid.UUID) (*service.TreeNode, error) {
+	logger := middleware.GetLogger(ctx)
+
+	services, err := s.serviceRepo.GetServiceTree(ctx.Request().Context(), userID, serviceID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service tree")
+		return nil, err
+	}
+
+	return service.BuildTree(services), nil
+}
+
+func (s *ServiceService) GetServicePrice(ctx echo.Context, userID string, query *service.GetServicePriceQuery) (*service.Price, error) {
+	logger := middleware.GetLogger(ctx)
+
+	serviceItem, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, query.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service by ID")
+		return nil, err
+	}
+
+	price, err := serviceItem.Pricing().Quote(*query.Quantity)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to price service")
+		return nil, err
+	}
+
+	return price, nil
+}
+
+// mergePricing overlays the pricing fields of an update onto the stored service
+func mergePricing(current *service.Service, payload *service.UpdateServicePayload) service.Pricing {
+	pricing := current.Pricing()
+
+	if payload.Rate != nil {
+		pricing.Rate.Amount = *payload.Rate
+	}
+	if payload.Currency != nil {
+		pricing.Rate = money.New(pricing.Rate.Amount, *payload.Currency)
+	}
+	if payload.Method != nil {
+		pricing.Method = *payload.Method
+	}
+	if payload.UnitLabel != nil {
+		pricing.UnitLabel = payload.UnitLabel
+	}
+	if payload.PriceTiers != nil {
+		pricing.PriceTiers = payload.PriceTiers
+	}
+
+	return pricing
+}
+
+// checkMetadata validates metadata against a category's schema; categories without one accept any object
+func checkMetadata(categoryID uuid.UUID, schema json.RawMessage, metadata service.Metadata) error {
+	if len(schema) == 0 {
+		return nil
+	}
+
+	compiled, err := metaschema.Compile(schema)
+	if err != nil {
+		return fmt.Errorf("failed to compile metadata schema for category_id=%s: %w", categoryID, err)
+	}
+
+	if fieldErrors := metaschema.Validate(compiled, metadata); len(fieldErrors) > 0 {
+		code := "SERVICE_METADATA_INVALID"
+		return errs.NewBadRequestError("Metadata does not match the category schema", true, &code, fieldErrors, nil)
+	}
+	return nil
+}
+
+func invalidPricingError(err error) error {
+	var fieldErrors []errs.FieldError
+	var validationErrors validation.CustomValidationErrors
+	if errors.As(err, &validationErrors) {
+		for _, e := range validationErrors {
+			fieldErrors = append(fieldErrors, errs.FieldError{Field: e.Field, Error: e.Message})
+		}
+	}
+	return errs.NewBadRequestError("Invalid pricing", true, nil, fieldErrors, nil)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/servicearea"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type ServiceAreaService struct {
+	server          *server.Server
+	serviceAreaRepo *repository.ServiceAreaRepository
+}
+
+func NewServiceAreaService(s *server.Server, serviceAreaRepo *repository.ServiceAreaRepository) *ServiceAreaService {
+	return &ServiceAreaService{
+		server:          s,
+		serviceAreaRepo: serviceAreaRepo,
+	}
+}
+
+func (s *ServiceAreaService) CreateServiceArea(ctx echo.Context, userID string, payload *servicearea.CreateServiceAreaPayload) (*servicearea.ServiceArea, error) {
+	logger := middleware.GetLogger(ctx)
+
+	area, err := s.serviceAreaRepo.CreateServiceArea(ctx.Request().Context(), userID, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create service area")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "service_area_created").
+		Str("service_area_id", area.ID.String()).
+		Str("kind", string(area.Kind)).
+		Msg("service area created successfully")
+
+	return area, nil
+}
+
+func (s *ServiceAreaService) GetServiceAreas(ctx echo.Context, userID string) ([]servicearea.ServiceArea, error) {
+	logger := middleware.GetLogger(ctx)
+
+	areas, err := s.serviceAreaRepo.GetServiceAreas(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service areas")
+		return nil, err
+	}
+
+	return areas, nil
+}
+
+func (s *ServiceAreaService) DeleteServiceArea(ctx echo.Context, userID string, areaID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+
+	if err := s.serviceAreaRepo.DeleteServiceArea(ctx.Request().Context(), userID, areaID); err != nil {
+		logger.Error().Err(err).Msg("failed to delete service area")
+		return err
+	}
+
+	logger.Info().
+		Str("event", "service_area_deleted").
+		Str("service_area_id", areaID.String()).
+		Msg("service area deleted successfully")
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/lib/metaschema"
+	"github.com/mukundaparajuli/fixr/internal/lib/servicefile"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/model/webhook"
+	"github.com/mukundaparajuli/fixr/internal/validation"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// ImportServices creates services from a CSV or JSON file. Every row is checked before anything is
+// written: a dry run reports the outcome without saving, and a real run with any invalid row is
+// rejected as a whole. Categories named in the file that do not exist yet are created.
+func (s *ServiceService) ImportServices(ctx echo.Context, userID string, payload *service.ImportServicesPayload) (*service.ImportResult, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if payload.File.Size > s.server.Config.Storage.MaxUploadSize {
+		logger.Warn().Int64("file_size", payload.File.Size).Msg("import file exceeds max upload size")
+		return nil, errs.NewBadRequestError(
+			fmt.Sprintf("File exceeds the maximum upload size of %d bytes", s.server.Config.Storage.MaxUploadSize),
+			true, nil, []errs.FieldError{{Field: "file", Error: "is too large"}}, nil,
+		)
+	}
+
+	src, err := payload.File.Open()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to open uploaded file")
+		return nil, errs.NewBadRequestError("Could not read uploaded file", false, nil, nil, nil)
+	}
+	defer src.Close()
+
+	rows, fieldErrors, err := servicefile.Decode(*payload.Format, src)
+	if err != nil {
+		logger.Warn().Err(err).Msg("failed to decode import file")
+		return nil, errs.NewBadRequestError("Could not parse import file", true, nil, []errs.FieldError{{Field: "file", Error: err.Error()}}, nil)
+	}
+	if len(rows) == 0 {
+		return nil, errs.NewBadRequestError("Import file has no services", true, nil, []errs.FieldError{{Field: "file", Error: "is empty"}}, nil)
+	}
+	if len(rows) > service.MaxImportRows {
+		return nil, errs.NewBadRequestError(
+			fmt.Sprintf("Import files may hold at most %d services", service.MaxImportRows),
+			true, nil, []errs.FieldError{{Field: "file", Error: "has too many rows"}}, nil,
+		)
+	}
+
+	existingServices, err := s.serviceRepo.GetAllServices(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch services for import")
+		return nil, err
+	}
+	categories, err := s.categoryRepo.GetAllCategories(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch categories for import")
+		return nil, err
+	}
+
+	existingByName := make(map[string]*service.Service, len(existingServices))
+	existingByID := make(map[uuid.UUID]*service.Service, len(existingServices))
+	for i := range existingServices {
+		existingByName[existingServices[i].Name] = &existingServices[i]
+		existingByID[existingServices[i].ID] = &existingServices[i]
+	}
+	categoryIDs := make(map[string]uuid.UUID, len(categories))
+	schemas := make(map[string]*jsonschema.Schema, len(categories))
+	for _, c := range categories {
+		categoryIDs[c.Name] = c.ID
+		if len(c.MetadataSchema) > 0 {
+			schema, err := metaschema.Compile(c.MetadataSchema)
+			if err != nil {
+				logger.Error().Err(err).Str("category_id", c.ID.String()).Msg("failed to compile metadata schema")
+				return nil, fmt.Errorf("failed to compile metadata schema for category_id=%s: %w", c.ID, err)
+			}
+			schemas[c.Name] = schema
+		}
+	}
+
+	addError := func(index int, field string, message string) {
+		fieldErrors = append(fieldErrors, errs.FieldError{Field: servicefile.RowField(index, field), Error: message})
+	}
+
+	items := make([]service.ImportItem, len(rows))
+	rowByName := make(map[string]int, len(rows))
+	newCategories := []string{}
+	for i, row := range rows {
+		createPayload := row.CreatePayload()
+		if err := createPayload.Validate(); err != nil {
+			for _, fieldError := range validation.FieldErrors(err) {
+				addError(i, fieldError.Field, fieldError.Error)
+			}
+		}
+
+		if row.Name != "" {
+			if first, ok := rowByName[row.Name]; ok {
+				addError(i, "name", fmt.Sprintf("duplicates the name of row %d", first))
+			} else {
+				rowByName[row.Name] = i
+			}
+			if _, ok := existingByName[row.Name]; ok {
+				addError(i, "name", "a service with this name already exists")
+			}
+		}
+
+		item := service.ImportItem{Payload: createPayload}
+		if row.Category != nil {
+			if id, ok := categoryIDs[*row.Category]; ok {
+				item.Payload.CategoryID = &id
+				if schema, ok := schemas[*row.Category]; ok {
+					for _, fieldError := range metaschema.Validate(schema, row.Metadata) {
+						addError(i, fieldError.Field, fieldError.Error)
+					}
+				}
+			} else {
+				item.CategoryName = row.Category
+				if !slices.Contains(newCategories, *row.Category) {
+					newCategories = append(newCategories, *row.Category)
+				}
+			}
+		}
+		items[i] = item
+	}
+
+	// parentRow[i] is the row index of a parent created by this import, or -1
+	parentRow := make([]int, len(rows))
+	for i, row := range rows {
+		parentRow[i] = -1
+		if row.Parent == nil {
+			continue
+		}
+		if j, ok := rowByName[*row.Parent]; ok {
+			parentRow[i] = j
+			items[i].ParentName = row.Parent
+		} else if parent, ok := existingByName[*row.Parent]; ok {
+			items[i].Payload.ParentServiceID = &parent.ID
+		} else {
+			addError(i, "parent", "does not match a service in this file or in your catalog")
+		}
+	}
+
+	// depth of every row, following parents inside the file and then up the existing catalog;
+	// a row that reaches itself again is part of a cycle
+	maxDepth := s.server.Config.Catalog.MaxServiceDepth
+	depths := make([]int, len(rows))
+	visiting := make([]bool, len(rows))
+	var depthOf func(i int) int
+	depthOf = func(i int) int {
+		if depths[i] != 0 {
+			return depths[i]
+		}
+		if visiting[i] {
+			return -1
+		}
+		visiting[i] = true
+		defer func() { visiting[i] = false }()
+
+		depth := 1
+		switch {
+		case parentRow[i] >= 0:
+			parentDepth := depthOf(parentRow[i])
+			if parentDepth < 0 {
+				return -1
+			}
+			depth = parentDepth + 1
+		case items[i].Payload.ParentServiceID != nil:
+			for parent := existingByID[*items[i].Payload.ParentServiceID]; parent != nil && depth <= service.MaxTreeDepth; depth++ {
+				if parent.ParentServiceID == nil {
+					parent = nil
+				} else {
+					parent = existingByID[*parent.ParentServiceID]
+				}
+			}
+		}
+		depths[i] = depth
+		return depth
+	}
+	for i := range rows {
+		depth := depthOf(i)
+		if depth < 0 {
+			addError(i, "parent", "forms a cycle with other rows in this file")
+		} else if depth > maxDepth {
+			addError(i, "parent", fmt.Sprintf("would nest this service %d levels deep, the limit is %d", depth, maxDepth))
+		}
+	}
+
+	result := &service.ImportResult{
+		DryRun:            payload.DryRun,
+		Valid:             len(fieldErrors) == 0,
+		Total:             len(rows),
+		CategoriesCreated: newCategories,
+		Errors:            fieldErrors,
+		Services:          []service.Service{},
+	}
+	if result.Errors == nil {
+		result.Errors = []errs.FieldError{}
+	}
+
+	if payload.DryRun {
+		return result, nil
+	}
+	if !result.Valid {
+		code := "SERVICE_IMPORT_INVALID"
+		return nil, errs.NewBadRequestError(
+			fmt.Sprintf("%d problems found in the import file, nothing was imported", len(fieldErrors)), true, &code, fieldErrors, nil,
+		)
+	}
+
+	// a row always sits deeper than its parent, so ordering by depth inserts parents first
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return depths[order[a]] < depths[order[b]] })
+	ordered := make([]service.ImportItem, len(items))
+	for i, index := range order {
+		ordered[i] = items[index]
+	}
+
+	services, err := s.serviceRepo.ImportServices(ctx.Request().Context(), userID, newCategories, ordered)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to import services")
+		return nil, err
+	}
+
+	result.Created = len(services)
+	result.Services = services
+
+	for i := range services {
+		s.webhookService.Dispatch(ctx, userID, webhook.EventServiceCreated, services[i])
+	}
+
+	logger.Info().
+		Str("event", "services_imported").
+		Int("created", len(services)).
+		Int("categories_created", len(newCategories)).
+		Msg("services imported successfully")
+
+	return result, nil
+}
+
+// ExportServices renders every live service as a CSV or JSON file that ImportServices accepts,
+// listing parents before their children
+func (s *ServiceService) ExportServices(ctx echo.Context, userID string, query *service.ExportServicesQuery) ([]byte, error) {
+	logger := middleware.GetLogger(ctx)
+
+	services, err := s.serviceRepo.GetAllServices(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch services for export")
+		return nil, err
+	}
+	categories, err := s.categoryRepo.GetAllCategories(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch categories for export")
+		return nil, err
+	}
+
+	categoryNames := make(map[uuid.UUID]*string, len(categories))
+	for i := range categories {
+		categoryNames[categories[i].ID] = &categories[i].Name
+	}
+	serviceNames := make(map[uuid.UUID]*string, len(services))
+	children := make(map[uuid.UUID][]*service.Service, len(services))
+	var roots []*service.Service
+	for i := range services {
+		serviceItem := &services[i]
+		serviceNames[serviceItem.ID] = &serviceItem.Name
+		if serviceItem.ParentServiceID == nil {
+			roots = append(roots, serviceItem)
+		} else {
+			children[*serviceItem.ParentServiceID] = append(children[*serviceItem.ParentServiceID], serviceItem)
+		}
+	}
+
+	rows := make([]service.ServiceRow, 0, len(services))
+	var visit func(serviceItem *service.Service)
+	visit = func(serviceItem *service.Service) {
+		var categoryName, parentName *string
+		if serviceItem.CategoryID != nil {
+			categoryName = categoryNames[*serviceItem.CategoryID]
+		}
+		if serviceItem.ParentServiceID != nil {
+			parentName = serviceNames[*serviceItem.ParentServiceID]
+		}
+		rows = append(rows, service.NewServiceRow(serviceItem, categoryName, parentName))
+
+		for _, child := range children[serviceItem.ID] {
+			visit(child)
+		}
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+
+	file, err := servicefile.Encode(*query.Format, rows)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to encode services export")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "services_exported").
+		Str("format", *query.Format).
+		Int("count", len(rows)).
+		Msg("services exported successfully")
+
+	return file, nil
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/model/webhook"
+)
+
+func (s *ServiceService) GetServiceVersions(ctx echo.Context, userID string, query *service.GetServiceVersionsQuery) (*model.PaginatedResponse[service.Version], error) {
+	logger := middleware.GetLogger(ctx)
+
+	if _, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, query.ID); err != nil {
+		logger.Error().Err(err).Msg("service validation failed")
+		return nil, err
+	}
+
+	result, err := s.serviceRepo.GetServiceVersions(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service versions")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *ServiceService) DiffServiceVersions(ctx echo.Context, userID string, query *service.DiffServiceVersionsQuery) (*service.VersionDiff, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if _, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, query.ID); err != nil {
+		logger.Error().Err(err).Msg("service validation failed")
+		return nil, err
+	}
+
+	changes, err := s.serviceRepo.DiffServiceVersions(ctx.Request().Context(), userID, query.ID, query.From, query.To)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to diff service versions")
+		return nil, err
+	}
+
+	return &service.VersionDiff{
+		ServiceID: query.ID,
+		From:      query.From,
+		To:        query.To,
+		Changes:   changes,
+	}, nil
+}
+
+// RestoreServiceVersion brings back the fields of an earlier version as a new version. The old
+// version is checked like any other update: its category must still exist and its metadata must
+// fit that category's current schema.
+func (s *ServiceService) RestoreServiceVersion(ctx echo.Context, userID string, serviceID uuid.UUID, version int) (*service.Service, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if _, err := s.serviceRepo.CheckServiceExists(ctx.Request().Context(), userID, serviceID); err != nil {
+		logger.Error().Err(err).Msg("service validation failed")
+		return nil, err
+	}
+
+	target, err := s.serviceRepo.GetServiceVersion(ctx.Request().Context(), userID, serviceID, version)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch service version")
+		return nil, err
+	}
+
+	if categoryID := target.Snapshot.CategoryID; categoryID != nil {
+		categoryItem, err := s.categoryRepo.GetCategoryByID(ctx.Request().Context(), userID, *categoryID)
+		if err != nil {
+			logger.Error().Err(err).Msg("category validation failed")
+			return nil, err
+		}
+
+		if err := checkMetadata(categoryItem.ID, categoryItem.MetadataSchema, target.Snapshot.Metadata); err != nil {
+			logger.Warn().Err(err).Msg("invalid service metadata")
+			return nil, err
+		}
+	}
+
+	revertedService, err := s.serviceRepo.RevertService(ctx.Request().Context(), userID, serviceID, target)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to restore service version")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "service_version_restored").
+		Str("service_id", serviceID.String()).
+		Int("version", version).
+		Msg("service version restored successfully")
+
+	s.webhookService.Dispatch(ctx, userID, webhook.EventServiceUpdated, revertedService)
+
+	return revertedService, nil
+}
@@ -7,15 +7,58 @@ import (
 )
 
 type Services struct {
-	Auth *AuthService
-	Job  *job.JobService
+	Auth         *AuthService
+	Job          *job.JobService
+	Service      *ServiceService
+	Category     *CategoryService
+	Attachment   *AttachmentService
+	Booking      *BookingService
+	Availability *AvailabilityService
+	Quote        *QuoteService
+	Invoice      *InvoiceService
+	Review       *ReviewService
+	Search       *SearchService
+	ServiceArea  *ServiceAreaService
+	Storefront   *StorefrontService
+	Trash        *TrashService
+	Audit        *AuditService
+	APIKey       *APIKeyService
+	Webhook      *WebhookService
+	User         *UserService
 }
 
 func NewServices(s *server.Server, repos *repository.Repositories) (*Services, error) {
 	authService := NewAuthService(s)
 
+	// Trashed catalog items are purged in the background once their retention period is over
+	trashService := NewTrashService(s, repos.Trash)
+	s.Job.RegisterHandler(job.TaskPurgeTrash, trashService.HandlePurgeTrashTask)
+	if err := s.Job.Schedule(s.Config.Catalog.TrashPurgeSchedule, job.NewPurgeTrashTask()); err != nil {
+		return nil, err
+	}
+
+	// Webhook deliveries are sent and retried by the job server
+	webhookService := NewWebhookService(s, repos.Webhook)
+	s.Job.RegisterHandler(job.TaskDeliverWebhook, webhookService.HandleDeliverWebhookTask)
+
 	return &Services{
-		Job:  s.Job,
-		Auth: authService,
+		Job:          s.Job,
+		Auth:         authService,
+		Service:      NewServiceService(s, repos.Service, repos.Category, webhookService),
+		Category:     NewCategoryService(s, repos.Category),
+		Attachment:   NewAttachmentService(s, repos.Service, repos.Attachment),
+		Booking:      NewBookingService(s, repos.Booking, repos.Service, webhookService),
+		Availability: NewAvailabilityService(s, repos.Availability, repos.Service, repos.Booking),
+		Quote:        NewQuoteService(s, repos.Quote, repos.Service),
+		Invoice:      NewInvoiceService(s, repos.Invoice, repos.Booking, repos.Quote, repos.Service),
+		Review:       NewReviewService(s, repos.Review, repos.Booking),
+		Search:       NewSearchService(s, repos.Search),
+		ServiceArea:  NewServiceAreaService(s, repos.ServiceArea),
+		Storefront:   NewStorefrontService(s, repos.Storefront, repos.Service),
+		Trash:        trashService,
+		Audit:        NewAuditService(s, repos.Audit),
+		APIKey:       NewAPIKeyService(s, repos.APIKey),
+		Webhook:      webhookService,
+		User:         NewUserService(s, repos.User),
 	}, nil
 }
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/service"
+	"github.com/mukundaparajuli/fixr/internal/model/storefront"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type StorefrontService struct {
+	server         *server.Server
+	storefrontRepo *repository.StorefrontRepository
+	serviceRepo    *repository.ServiceRepository
+}
+
+func NewStorefrontService(s *server.Server, storefrontRepo *repository.StorefrontRepository, serviceRepo *repository.ServiceRepository) *StorefrontService {
+	return &StorefrontService{
+		server:         s,
+		storefrontRepo: storefrontRepo,
+		serviceRepo:    serviceRepo,
+	}
+}
+
+func (s *StorefrontService) UpsertStorefront(ctx echo.Context, userID string, payload *storefront.UpsertStorefrontPayload) (*storefront.Storefront, error) {
+	logger := middleware.GetLogger(ctx)
+
+	storefrontItem, err := s.storefrontRepo.UpsertStorefront(ctx.Request().Context(), userID, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to save storefront")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "storefront_saved").
+		Str("storefront_id", storefrontItem.ID.String()).
+		Str("handle", storefrontItem.Handle).
+		Msg("storefront saved successfully")
+
+	return storefrontItem, nil
+}
+
+func (s *StorefrontService) GetStorefront(ctx echo.Context, userID string) (*storefront.Storefront, error) {
+	logger := middleware.GetLogger(ctx)
+
+	storefrontItem, err := s.storefrontRepo.GetStorefront(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch storefront")
+		return nil, err
+	}
+
+	return storefrontItem, nil
+}
+
+func (s *StorefrontService) GetPublicProvider(ctx echo.Context, handle string) (*storefront.PublicProvider, error) {
+	logger := middleware.GetLogger(ctx)
+
+	storefrontItem, err := s.storefrontRepo.GetStorefrontByHandle(ctx.Request().Context(), handle)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch storefront by handle")
+		return nil, err
+	}
+
+	return storefront.NewPublicProvider(storefrontItem), nil
+}
+
+// GetPublicProviders finds the providers serving the customer's location
+func (s *StorefrontService) GetPublicProviders(ctx echo.Context, query *storefront.GetPublicProvidersQuery) (*model.PaginatedResponse[storefront.PublicProvider], error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.storefrontRepo.GetPublicProviders(ctx.Request().Context(), query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch public providers")
+		return nil, err
+	}
+
+	providers := make([]storefront.PublicProvider, 0, len(result.Data))
+	for i := range result.Data {
+		providers = append(providers, *storefront.NewPublicProvider(&result.Data[i]))
+	}
+
+	return &model.PaginatedResponse[storefront.PublicProvider]{
+		Data:       providers,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// GetPublicServices lists a provider's active root services in their own display order
+func (s *StorefrontService) GetPublicServices(ctx echo.Context, query *storefront.GetPublicServicesQuery) (*model.PaginatedResponse[storefront.PublicPopulatedService], error) {
+	logger := middleware.GetLogger(ctx)
+
+	storefrontItem, err := s.storefrontRepo.GetStorefrontByHandle(ctx.Request().Context(), query.Handle)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch storefront by handle")
+		return nil, err
+	}
+
+	status := service.Active
+	sort := "sort_order"
+	order := "asc"
+	result, err := s.serviceRepo.GetServices(ctx.Request().Context(), storefrontItem.UserID, &service.GetServicesQuery{
+		Page:       query.Page,
+		Limit:      query.Limit,
+		Sort:       &sort,
+		Order:      &order,
+		Search:     query.Search,
+		Status:     &status,
+		CategoryID: query.CategoryID,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch public services")
+		return nil, err
+	}
+
+	services := make([]storefront.PublicPopulatedService, 0, len(result.Data))
+	for i := range result.Data {
+		services = append(services, storefront.NewPublicPopulatedService(&result.Data[i]))
+	}
+
+	return &model.PaginatedResponse[storefront.PublicPopulatedService]{
+		Data:       services,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+func (s *StorefrontService) GetPublicService(ctx echo.Context, payload *storefront.GetPublicServicePayload) (*storefront.PublicPopulatedService, error) {
+	logger := middleware.GetLogger(ctx)
+
+	storefrontItem, err := s.storefrontRepo.GetStorefrontByHandle(ctx.Request().Context(), payload.Handle)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch storefront by handle")
+		return nil, err
+	}
+
+	serviceItem, err := s.serviceRepo.GetServiceByID(ctx.Request().Context(), storefrontItem.UserID, payload.ID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch public service")
+		return nil, err
+	}
+
+	// inactive services are indistinguishable from missing ones to the public
+	if serviceItem.Status != service.Active {
+		code := "SERVICE_NOT_FOUND"
+		return nil, errs.NewNotFoundError("Service not found", false, &code)
+	}
+
+	publicService := storefront.NewPublicPopulatedService(serviceItem)
+	return &publicService, nil
+}
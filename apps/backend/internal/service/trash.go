@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/trash"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+	"github.com/rs/zerolog"
+)
+
+type TrashService struct {
+	server    *server.Server
+	trashRepo *repository.TrashRepository
+}
+
+func NewTrashService(s *server.Server, trashRepo *repository.TrashRepository) *TrashService {
+	return &TrashService{
+		server:    s,
+		trashRepo: trashRepo,
+	}
+}
+
+func (s *TrashService) GetTrash(ctx echo.Context, userID string) (*trash.Trash, error) {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.trashRepo.GetTrash(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch trash")
+		return nil, err
+	}
+
+	result.RetentionDays = int(s.server.Config.Catalog.TrashRetention / (24 * time.Hour))
+
+	return result, nil
+}
+
+// PurgeTrash permanently deletes trashed items older than the retention period, then their blobs
+func (s *TrashService) PurgeTrash(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.server.Config.Catalog.TrashRetention)
+
+	result, err := s.trashRepo.PurgeTrash(ctx, cutoff)
+	if err != nil {
+		s.server.Logger.Error().Err(err).Msg("failed to purge trash")
+		return err
+	}
+
+	deleteBlobs(ctx, s.server, s.server.Logger, result.DownloadKeys)
+
+	s.server.Logger.Info().
+		Str("event", "trash_purged").
+		Time("cutoff", cutoff).
+		Int64("services", result.Services).
+		Int64("retained_services", result.RetainedServices).
+		Int64("categories", result.Categories).
+		Int64("attachments", result.Attachments).
+		Msg("trash purged successfully")
+
+	return nil
+}
+
+// deleteBlobs removes the blobs of attachments whose rows were purged. The rows are gone at this
+// point and a leftover blob is only wasted space, so failures are logged rather than returned.
+func deleteBlobs(ctx context.Context, s *server.Server, logger *zerolog.Logger, downloadKeys []string) {
+	for _, key := range downloadKeys {
+		if err := s.Storage.Delete(ctx, key); err != nil {
+			logger.Error().Err(err).Str("download_key", key).Msg("failed to delete attachment blob")
+		}
+	}
+}
+
+func (s *TrashService) HandlePurgeTrashTask(ctx context.Context, _ *asynq.Task) error {
+	return s.PurgeTrash(ctx)
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/lib/job"
+	"github.com/mukundaparajuli/fixr/internal/lib/svix"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model/user"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+type UserService struct {
+	server   *server.Server
+	userRepo *repository.UserRepository
+	verifier *svix.Verifier
+}
+
+// NewUserService builds the service; without a valid Clerk webhook secret every webhook is rejected
+func NewUserService(s *server.Server, userRepo *repository.UserRepository) *UserService {
+	verifier, err := svix.NewVerifier(s.Config.Auth.WebhookSecret)
+	if err != nil {
+		s.Logger.Warn().Err(err).Msg("clerk webhook secret is not configured, clerk webhooks will be rejected")
+	}
+
+	return &UserService{
+		server:   s,
+		userRepo: userRepo,
+		verifier: verifier,
+	}
+}
+
+// HandleClerkWebhook verifies and applies a Clerk webhook. Event types other than the user events
+// are acknowledged and ignored, so Clerk does not retry them.
+func (s *UserService) HandleClerkWebhook(ctx echo.Context, body []byte) error {
+	logger := middleware.GetLogger(ctx)
+
+	if s.verifier == nil {
+		return errs.NewUnauthorizedError("Clerk webhooks are not configured", false)
+	}
+
+	if err := s.verifier.Verify(ctx.Request().Header, body, time.Now()); err != nil {
+		logger.Warn().Err(err).Msg("clerk webhook signature verification failed")
+		return errs.NewUnauthorizedError("Invalid webhook signature", false)
+	}
+
+	var event user.ClerkEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return errs.NewBadRequestError("Invalid webhook payload", false, nil, nil, nil)
+	}
+
+	switch event.Type {
+	case user.ClerkUserCreated, user.ClerkUserUpdated:
+		var data user.ClerkUser
+		if err := json.Unmarshal(event.Data, &data); err != nil || data.ID == "" {
+			return errs.NewBadRequestError("Invalid webhook payload", false, nil, nil, nil)
+		}
+		return s.syncUser(ctx, &data)
+
+	case user.ClerkUserDeleted:
+		var data user.ClerkDeletedUser
+		if err := json.Unmarshal(event.Data, &data); err != nil || data.ID == "" {
+			return errs.NewBadRequestError("Invalid webhook payload", false, nil, nil, nil)
+		}
+		return s.deleteUser(ctx, data.ID)
+
+	default:
+		logger.Debug().Str("type", event.Type).Msg("ignoring clerk webhook event")
+		return nil
+	}
+}
+
+// syncUser stores the profile and queues the welcome email until it has been queued once
+func (s *UserService) syncUser(ctx echo.Context, data *user.ClerkUser) error {
+	logger := middleware.GetLogger(ctx)
+
+	profile, inserted, err := s.userRepo.UpsertUser(ctx.Request().Context(), data.ToUser())
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to upsert user")
+		return err
+	}
+
+	if profile == nil {
+		logger.Info().Str("user_id", data.ID).Msg("ignoring stale or deleted clerk user event")
+		return nil
+	}
+
+	logger.Info().
+		Str("event", "user_synced").
+		Str("user_id", profile.ID).
+		Bool("created", inserted).
+		Msg("user synced successfully")
+
+	// Clerk retries a webhook that failed after the upsert, so the email goes out until it is marked as
+	// queued rather than only on insert; the task id keeps a retry from queueing it twice
+	if profile.WelcomeEnqueuedAt == nil && profile.Email != nil {
+		firstName := ""
+		if profile.FirstName != nil {
+			firstName = *profile.FirstName
+		}
+
+		task, err := job.NewWelcomeEmailTask(profile.ID, *profile.Email, firstName)
+		if err != nil {
+			logger.Error().Err(err).Msg("failed to create welcome email task")
+			return err
+		}
+
+		_, err = s.server.Job.Client.EnqueueContext(ctx.Request().Context(), task)
+		if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			logger.Error().Err(err).Msg("failed to enqueue welcome email task")
+			return err
+		}
+
+		if err := s.userRepo.MarkWelcomeEnqueued(ctx.Request().Context(), profile.ID); err != nil {
+			logger.Error().Err(err).Msg("failed to mark welcome email as enqueued")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deleteUser removes the user's profile and provider data, then the attachment blobs
+func (s *UserService) deleteUser(ctx echo.Context, userID string) error {
+	logger := middleware.GetLogger(ctx)
+
+	result, err := s.userRepo.DeleteUser(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to delete user")
+		return err
+	}
+
+	deleteBlobs(ctx.Request().Context(), s.server, logger, result.DownloadKeys)
+
+	logger.Info().
+		Str("event", "user_deleted").
+		Str("user_id", userID).
+		Int64("services", result.Services).
+		Int64("retained_services", result.RetainedServices).
+		Int64("categories", result.Categories).
+		Int64("attachments", result.Attachments).
+		Msg("user deleted successfully")
+
+	return nil
+}
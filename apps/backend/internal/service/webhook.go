@@ -0,0 +1,357 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/mukundaparajuli/fixr/internal/errs"
+	"github.com/mukundaparajuli/fixr/internal/lib/job"
+	"github.com/mukundaparajuli/fixr/internal/middleware"
+	"github.com/mukundaparajuli/fixr/internal/model"
+	"github.com/mukundaparajuli/fixr/internal/model/webhook"
+	"github.com/mukundaparajuli/fixr/internal/repository"
+	"github.com/mukundaparajuli/fixr/internal/server"
+)
+
+const (
+	webhookTimeout = 10 * time.Second
+	// webhookResponseLimit caps how much of a receiver's response body is kept in the delivery log
+	webhookResponseLimit = 4 << 10
+)
+
+type WebhookService struct {
+	server      *server.Server
+	webhookRepo *repository.WebhookRepository
+	client      *http.Client
+}
+
+func NewWebhookService(s *server.Server, webhookRepo *repository.WebhookRepository) *WebhookService {
+	return &WebhookService{
+		server:      s,
+		webhookRepo: webhookRepo,
+		client: &http.Client{
+			Timeout: webhookTimeout,
+			// every connection is checked against the resolved address, and never goes through a proxy
+			// that would do its own resolution
+			Transport: &http.Transport{
+				Proxy:               nil,
+				DialContext:         (&net.Dialer{Timeout: webhookTimeout, Control: webhook.DialControl}).DialContext,
+				TLSHandshakeTimeout: webhookTimeout,
+				MaxIdleConns:        100,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			// a redirect is reported as the receiver's response instead of re-sending the payload elsewhere
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// CreateEndpoint subscribes a URL to events. The signing secret is only part of this response.
+func (s *WebhookService) CreateEndpoint(ctx echo.Context, userID string, payload *webhook.CreateEndpointPayload) (*webhook.CreatedEndpoint, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if err := s.checkEndpointURL(ctx, payload.URL); err != nil {
+		logger.Warn().Err(err).Str("url", payload.URL).Msg("webhook endpoint url rejected")
+		return nil, err
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to generate webhook secret")
+		return nil, err
+	}
+
+	endpoint, err := s.webhookRepo.CreateEndpoint(ctx.Request().Context(), userID, secret, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create webhook endpoint")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "webhook_endpoint_created").
+		Str("endpoint_id", endpoint.ID.String()).
+		Str("url", endpoint.URL).
+		Msg("webhook endpoint created successfully")
+
+	return &webhook.CreatedEndpoint{Endpoint: *endpoint, Secret: endpoint.Secret}, nil
+}
+
+func (s *WebhookService) GetEndpoints(ctx echo.Context, userID string) ([]webhook.Endpoint, error) {
+	logger := middleware.GetLogger(ctx)
+
+	endpoints, err := s.webhookRepo.GetEndpoints(ctx.Request().Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch webhook endpoints")
+		return nil, err
+	}
+
+	return endpoints, nil
+}
+
+func (s *WebhookService) GetEndpointByID(ctx echo.Context, userID string, endpointID uuid.UUID) (*webhook.Endpoint, error) {
+	logger := middleware.GetLogger(ctx)
+
+	endpoint, err := s.webhookRepo.GetEndpointByID(ctx.Request().Context(), userID, endpointID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch webhook endpoint by ID")
+		return nil, err
+	}
+
+	return endpoint, nil
+}
+
+func (s *WebhookService) UpdateEndpoint(ctx echo.Context, userID string, payload *webhook.UpdateEndpointPayload) (*webhook.Endpoint, error) {
+	logger := middleware.GetLogger(ctx)
+
+	if payload.URL != nil {
+		if err := s.checkEndpointURL(ctx, *payload.URL); err != nil {
+			logger.Warn().Err(err).Str("url", *payload.URL).Msg("webhook endpoint url rejected")
+			return nil, err
+		}
+	}
+
+	endpoint, err := s.webhookRepo.UpdateEndpoint(ctx.Request().Context(), userID, payload)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to update webhook endpoint")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "webhook_endpoint_updated").
+		Str("endpoint_id", endpoint.ID.String()).
+		Msg("webhook endpoint updated successfully")
+
+	return endpoint, nil
+}
+
+// checkEndpointURL requires https outside local development and resolves the host, refusing
+// endpoints that point into a private network. Deliveries check the address again when dialing.
+func (s *WebhookService) checkEndpointURL(ctx echo.Context, raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	code := "WEBHOOK_URL_INVALID"
+	if parsed.Scheme != "https" && s.server.Config.Primary.Env != "local" {
+		return errs.NewBadRequestError("Invalid webhook endpoint URL", true, &code, []errs.FieldError{
+			{Field: "url", Error: "must be an https URL"},
+		}, nil)
+	}
+
+	if err := webhook.CheckHost(ctx.Request().Context(), net.DefaultResolver, parsed.Hostname()); err != nil {
+		message := "could not be resolved"
+		if errors.Is(err, webhook.ErrBlockedAddress) {
+			message = "must point at a publicly reachable host"
+		}
+		return errs.NewBadRequestError("Invalid webhook endpoint URL", true, &code, []errs.FieldError{
+			{Field: "url", Error: message},
+		}, nil)
+	}
+	return nil
+}
+
+// DeleteEndpoint removes an endpoint with its delivery log; queued deliveries for it are dropped
+func (s *WebhookService) DeleteEndpoint(ctx echo.Context, userID string, endpointID uuid.UUID) error {
+	logger := middleware.GetLogger(ctx)
+
+	if err := s.webhookRepo.DeleteEndpoint(ctx.Request().Context(), userID, endpointID); err != nil {
+		logger.Error().Err(err).Msg("failed to delete webhook endpoint")
+		return err
+	}
+
+	logger.Info().
+		Str("event", "webhook_endpoint_deleted").
+		Str("endpoint_id", endpointID.String()).
+		Msg("webhook endpoint deleted successfully")
+
+	return nil
+}
+
+func (s *WebhookService) GetDeliveries(ctx echo.Context, userID string, query *webhook.GetDeliveriesQuery) (*model.PaginatedResponse[webhook.Delivery], error) {
+	logger := middleware.GetLogger(ctx)
+
+	if _, err := s.webhookRepo.GetEndpointByID(ctx.Request().Context(), userID, query.ID); err != nil {
+		logger.Error().Err(err).Msg("failed to fetch webhook endpoint by ID")
+		return nil, err
+	}
+
+	result, err := s.webhookRepo.GetDeliveries(ctx.Request().Context(), userID, query)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to fetch webhook deliveries")
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Redeliver sends a logged event again as a new delivery, whatever the outcome of the original
+func (s *WebhookService) Redeliver(ctx echo.Context, userID string, payload *webhook.RedeliverPayload) (*webhook.Delivery, error) {
+	logger := middleware.GetLogger(ctx)
+
+	delivery, err := s.webhookRepo.Redeliver(ctx.Request().Context(), userID, payload.ID, payload.DeliveryID)
+	if err != nil {
+		logger.Error().Err(err).Msg("failed to create webhook redelivery")
+		return nil, err
+	}
+
+	if err := s.enqueue(ctx.Request().Context(), delivery.ID); err != nil {
+		logger.Error().Err(err).Msg("failed to enqueue webhook redelivery")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("event", "webhook_redelivery_queued").
+		Str("delivery_id", delivery.ID.String()).
+		Str("redelivery_of", payload.DeliveryID.String()).
+		Msg("webhook redelivery queued successfully")
+
+	return delivery, nil
+}
+
+// Dispatch queues an event for every endpoint of the owner subscribed to it. The change that
+// raised the event has already been committed, so failures are logged instead of returned.
+func (s *WebhookService) Dispatch(ctx echo.Context, userID string, eventType webhook.EventType, data any) {
+	logger := middleware.GetLogger(ctx)
+
+	event := &webhook.Event{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+
+	deliveries, err := s.webhookRepo.CreateDeliveries(ctx.Request().Context(), userID, event)
+	if err != nil {
+		logger.Error().Err(err).Str("event_type", string(eventType)).Msg("failed to create webhook deliveries")
+		return
+	}
+
+	for _, delivery := range deliveries {
+		if err := s.enqueue(ctx.Request().Context(), delivery.ID); err != nil {
+			logger.Error().Err(err).Str("delivery_id", delivery.ID.String()).Msg("failed to enqueue webhook delivery")
+		}
+	}
+}
+
+func (s *WebhookService) enqueue(ctx context.Context, deliveryID uuid.UUID) error {
+	task, err := job.NewDeliverWebhookTask(deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook task for delivery id=%s: %w", deliveryID, err)
+	}
+
+	if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil {
+		return fmt.Errorf("failed to enqueue webhook task for delivery id=%s: %w", deliveryID, err)
+	}
+
+	return nil
+}
+
+// HandleDeliverWebhookTask sends one delivery. A failed attempt returns an error so asynq retries it
+// with backoff; the delivery stays pending until it succeeds or the last retry fails.
+func (s *WebhookService) HandleDeliverWebhookTask(ctx context.Context, t *asynq.Task) error {
+	var p job.DeliverWebhookPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal webhook delivery payload: %w", err)
+	}
+
+	logger := s.server.Logger.With().Str("delivery_id", p.DeliveryID.String()).Logger()
+
+	delivery, endpoint, err := s.webhookRepo.GetDeliveryWithEndpoint(ctx, p.DeliveryID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the endpoint was deleted, taking its deliveries with it
+		logger.Info().Msg("skipping webhook delivery of deleted endpoint")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !endpoint.Active {
+		errMsg := "endpoint is disabled"
+		return s.webhookRepo.RecordDeliveryAttempt(ctx, delivery.ID, webhook.DeliveryFailed, nil, nil, &errMsg)
+	}
+
+	responseStatus, responseBody, sendErr := s.send(ctx, endpoint, delivery)
+	if sendErr == nil {
+		if err := s.webhookRepo.RecordDeliveryAttempt(ctx, delivery.ID, webhook.DeliverySucceeded, responseStatus, responseBody, nil); err != nil {
+			return err
+		}
+
+		logger.Info().
+			Str("event", "webhook_delivered").
+			Str("endpoint_id", endpoint.ID.String()).
+			Str("event_type", string(delivery.EventType)).
+			Msg("webhook delivered successfully")
+		return nil
+	}
+
+	status := webhook.DeliveryPending
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	if retried >= maxRetry {
+		status = webhook.DeliveryFailed
+	}
+
+	errMsg := sendErr.Error()
+	if err := s.webhookRepo.RecordDeliveryAttempt(ctx, delivery.ID, status, responseStatus, responseBody, &errMsg); err != nil {
+		return err
+	}
+
+	logger.Warn().
+		Err(sendErr).
+		Str("endpoint_id", endpoint.ID.String()).
+		Int("retried", retried).
+		Str("status", string(status)).
+		Msg("webhook delivery attempt failed")
+
+	return sendErr
+}
+
+// send POSTs the delivery payload, signed with the endpoint secret. Any non-2xx response is an error.
+func (s *WebhookService) send(ctx context.Context, endpoint *webhook.Endpoint, delivery *webhook.Delivery) (*int, *string, error) {
+	timestamp := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("User-Agent", "Fixr-Webhooks/1.0")
+	req.Header.Set(webhook.HeaderEvent, string(delivery.EventType))
+	req.Header.Set(webhook.HeaderDelivery, delivery.ID.String())
+	req.Header.Set(webhook.HeaderTimestamp, strconv.FormatInt(timestamp.Unix(), 10))
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	// TEXT columns take neither invalid UTF-8 nor NUL bytes
+	responseBody := strings.ReplaceAll(strings.ToValidUTF8(string(body), ""), "\x00", "")
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &resp.StatusCode, &responseBody, fmt.Errorf("webhook receiver responded with status %d", resp.StatusCode)
+	}
+
+	return &resp.StatusCode, &responseBody, nil
+}
@@ -63,6 +63,8 @@ func generateErrorCode(tableName string, errType Code) string {
 		action = "REQUIRED"
 	case CheckViolation:
 		action = "INVALID"
+	case ExcludeViolation:
+		action = "CONFLICT"
 	}
 
 	return fmt.Sprintf("%s_%s", domain, action)
@@ -89,6 +91,8 @@ func formatUserFriendlyMessage(sqlErr *Error) string {
 			return fmt.Sprintf("The %s value does not meet required conditions", fieldName)
 		}
 		return "One or more values do not meet required conditions"
+	case ExcludeViolation:
+		return fmt.Sprintf("The %s conflicts with an existing record", entityName)
 	default:
 		return "An error occurred while processing your request"
 	}
@@ -188,6 +192,9 @@ func HandleError(err error) error {
 		case CheckViolation:
 			return errs.NewBadRequestError(userMessage, true, &errorCode, nil, nil)
 
+		case ExcludeViolation:
+			return errs.NewConflictError(userMessage, true, &errorCode)
+
 		default:
 			return errs.NewInternalServerError()
 		}
@@ -22,10 +22,14 @@ type TestDB struct {
 	Config    *config.Config
 }
 
-// SetupTestDB creates a Postgres container and applies migrations
+// SetupTestDB creates a Postgres container and applies migrations, skipping the test when Docker is
+// not available
 func SetupTestDB(t *testing.T) (*TestDB, func()) {
 	t.Helper()
 
+	// database tests need Docker; without it they are skipped rather than failing the whole run
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
 	ctx := context.Background()
 	dbName := fmt.Sprintf("test_db_%s", uuid.New().String()[:8])
 	dbUser := "testuser"
@@ -112,3 +112,15 @@ var uuidRegex = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4
 func IsValidUUID(uuid string) bool {
 	return uuidRegex.MatchString(uuid)
 }
+
+// FieldErrors converts an error returned by Validate into field errors, for payloads that are
+// validated outside of BindAndValidate (e.g. rows of an imported file)
+func FieldErrors(err error) []errs.FieldError {
+	switch err.(type) {
+	case validator.ValidationErrors, CustomValidationErrors:
+		_, fieldErrors := extractValidationErrors(err)
+		return fieldErrors
+	default:
+		return []errs.FieldError{{Error: err.Error()}}
+	}
+}